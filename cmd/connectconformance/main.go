@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"connectrpc.com/conformance/internal"
 	"connectrpc.com/conformance/internal/app/connectconformance"
@@ -49,6 +50,23 @@ const (
 	portFlagName          = "port"
 	bindFlagName          = "bind"
 	traceFlagName         = "trace"
+	traceDirFlagName      = "trace-dir"
+	traceFailuresFlagName = "trace-failures-only"
+	traceFramesFlagName   = "trace-frames"
+	traceDiskFlagName     = "trace-disk-limit"
+	diffReferenceFlagName = "diff-reference"
+	timelineFlagName      = "timeline"
+	otlpFileFlagName      = "otlp-file"
+	pcapFileFlagName      = "pcap-file"
+	malformedFlagName     = "capture-malformed"
+	handshakeFlagName     = "handshake"
+	clientListenFlagName  = "client-listen"
+	clientFlagName        = "client"
+	serverFlagName        = "server"
+	baselineFlagName      = "baseline"
+	saveBaselineFlagName  = "save-baseline"
+	timeoutFlagName       = "timeout"
+	memorySpikeFlagName   = "memory-spike-threshold"
 )
 
 type flags struct {
@@ -69,13 +87,32 @@ type flags struct {
 	port                 uint
 	bind                 string
 	trace                bool
+	traceDir             string
+	traceFailuresOnly    bool
+	traceFrames          bool
+	traceDiskLimit       uint
+	diffReference        bool
+	timelineFile         string
+	otlpFile             string
+	pcapFile             string
+	captureMalformed     bool
+	handshake            bool
+	clientListen         string
+	clients              []string
+	servers              []string
+	baselineFile         string
+	saveBaselineFile     string
+	timeout              time.Duration
+	memorySpikeThreshold uint
 }
 
 func main() {
 	flagset := &flags{}
 	rootCmd := &cobra.Command{
 		Use: `connectconformance --mode [client|server] -- command...
-  connectconformance --mode both -- client-command... ---- server-command...`,
+  connectconformance --mode both -- client-command... ---- server-command...
+  connectconformance --mode client --client-listen network:address
+  connectconformance --mode both --client name=command... --server name=command...`,
 		Short: "Runs conformance tests against the given command.",
 		Long: `Runs conformance tests against a Connect implementation. Depending on the mode,
 the given command must be either a conformance client or a conformance server.
@@ -93,6 +130,15 @@ when it has read all test cases (i.e reached EOF of stdin) and then issued RPCs
 and recorded all results to stdout. The command should also exit and abort any
 in-progress RPCs if it receives a SIGTERM signal.
 
+If the client under test cannot be started as a child process, use the
+--client-listen flag instead of providing a command. The test runner then listens
+on the given address, like "tcp:127.0.0.1:9876" or "unix:/tmp/conformance.sock",
+and waits for the client to connect. The messages that would otherwise be read
+from stdin and written to stdout are instead exchanged over that connection. When
+all test cases have been sent, the test runner closes its side of the connection
+for writing. The client should close the connection after it has written all
+results.
+
 A conformance server tests a server implementation: the command reads the required
 server properties from stdin. This comes in the form of a binary-encoded Protobuf
 message of type connectrpc.conformance.v1.ServerCompatRequest, prefixed with a
@@ -109,6 +155,25 @@ or server under test supports. This is used to filter the set of test cases
 that will be executed. If no config file is indicated, default configuration
 will be used.
 
+Alternatively, the client or server under test can report its own features via
+a handshake, enabled with the --handshake flag. In that case, the command is
+started with the environment variable CONNECT_CONFORMANCE_HANDSHAKE set to "1".
+The first message it reads from stdin is a connectrpc.conformance.v1.HandshakeRequest,
+and the first message it must write to stdout is a
+connectrpc.conformance.v1.HandshakeResponse, which describes its features. Both
+messages use the same fixed-32-bit length prefix. If a config file is also given,
+the config file is used, but warnings are printed for any differences between it
+and the features reported in the handshake.
+
+To test multiple implementations against each other, use mode both with the
+--client and --server flags instead of positional arguments. Each flag value is a
+name and a command-line, separated by an equals sign, like "go=./client -v". The
+name identifies the implementation in the results, and the command-line is split
+on whitespace. Both flags can be specified more than once. Test cases are then run
+for every pairing of client and server. Server processes are shared by all of the
+clients, and the results are summarized in a grid of pass counts by client and
+server.
+
 Flags can also be specified to filter the list of test case permutations run
 and change how results are interpreted. These are the --run, --skip,
 --known-failing, and --known-flaky flags. The --run and --skip flags should
@@ -121,6 +186,13 @@ failures are fixed. All of these flags support reading the list of test case
 patterns from a file using the "@" prefix. So a flag value with this prefix
 should be the path to a text file, which contains names or patterns, one per
 line.
+
+As an alternative to a list of known-failing test cases, the outcomes of a run
+can be saved to a file using the --save-baseline flag. A later run can then be
+compared to that file using the --baseline flag. In that case, only the
+differences are reported: test cases that newly fail, that newly pass, that
+fail with a different message, or that are no longer run. The run is only
+considered a failure if any test cases newly fail.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			run(flagset, cmd.Flags(), args)
@@ -168,6 +240,40 @@ func bind(cmd *cobra.Command, flags *flags) {
 		"in client mode, the bind address on which the reference server should listen (0.0.0.0 means listen on all interfaces)")
 	cmd.Flags().BoolVar(&flags.trace, traceFlagName, false,
 		"if true, full HTTP traces will be captured and shown alongside failing test cases")
+	cmd.Flags().StringVar(&flags.traceDir, traceDirFlagName, "",
+		"a directory to which full HTTP traces will be written, as HAR files, one per test case")
+	cmd.Flags().BoolVar(&flags.traceFailuresOnly, traceFailuresFlagName, false,
+		"if true, only traces for failing test cases are written to the --trace-dir directory")
+	cmd.Flags().BoolVar(&flags.traceFrames, traceFramesFlagName, false,
+		"if true, traces also include the individual HTTP/2 frames sent and received, including connection-level frames")
+	cmd.Flags().UintVar(&flags.traceDiskLimit, traceDiskFlagName, 0,
+		"if non-zero, traces kept for the report or for --trace-dir are stored in a temporary directory instead of in memory, using at most this many megabytes; when full, the oldest traces of passing test cases are discarded first")
+	cmd.Flags().BoolVar(&flags.diffReference, diffReferenceFlagName, false,
+		"in server mode, failed test cases are re-run against the reference server and a diff of the two HTTP traces is shown alongside each failure")
+	cmd.Flags().StringVar(&flags.timelineFile, timelineFlagName, "",
+		"a file to which a timeline of the run is written, in Chrome trace-event format, for viewing in Perfetto or chrome://tracing")
+	cmd.Flags().StringVar(&flags.otlpFile, otlpFileFlagName, "",
+		"a file to which a span for each test case's RPC is appended, in OTLP JSON format; the reference client and server also propagate a W3C trace context for each RPC, so these spans can be correlated with those of the implementation under test")
+	cmd.Flags().StringVar(&flags.pcapFile, pcapFileFlagName, "",
+		"a file to which the bytes of HTTP/2 connections made or accepted by the reference client or server are written, in pcapng format, for viewing in Wireshark; TLS secrets are written to a file with the same name but a .keylog extension")
+	cmd.Flags().BoolVar(&flags.captureMalformed, malformedFlagName, false,
+		"in client mode, the reference server captures the raw bytes sent by the client, before they are parsed, so that data that it can't parse is reported for the test case that sent it")
+	cmd.Flags().StringVar(&flags.clientListen, clientListenFlagName, "",
+		"in client mode, instead of running a command, the address on which to listen for the client under test to connect; must be in the form 'tcp:host:port' or 'unix:path'")
+	cmd.Flags().StringArrayVar(&flags.clients, clientFlagName, nil,
+		"in mode both, a client under test, in the form 'name=command'; can be specified more than once to test all clients against all servers")
+	cmd.Flags().StringArrayVar(&flags.servers, serverFlagName, nil,
+		"in mode both, a server under test, in the form 'name=command'; can be specified more than once to test all clients against all servers")
+	cmd.Flags().StringVar(&flags.baselineFile, baselineFlagName, "",
+		"a file, previously written using --save-baseline, to compare against; only differences are reported and only newly failing test cases cause the run to fail")
+	cmd.Flags().StringVar(&flags.saveBaselineFile, saveBaselineFlagName, "",
+		"a file to which the outcomes of all test cases will be written, for use with a later run's --baseline flag")
+	cmd.Flags().DurationVar(&flags.timeout, timeoutFlagName, 0,
+		"the maximum duration of the whole run; when it elapses, in-flight test cases are printed and marked as could-not-run, all processes are stopped, and results are reported; zero means no limit")
+	cmd.Flags().UintVar(&flags.memorySpikeThreshold, memorySpikeFlagName, 0,
+		"the maximum growth, in megabytes, of the peak memory usage of the implementation under test while handling a compression bomb test case; cases that exceed it fail; zero disables the check, which is only supported on Linux")
+	cmd.Flags().BoolVar(&flags.handshake, handshakeFlagName, false,
+		"if true, the client and/or server under test will be asked for their supported features via a handshake")
 }
 
 func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:gocyclo
@@ -181,10 +287,60 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 		os.Exit(1)
 	}
 
-	if len(command) == 0 {
+	if flags.clientListen != "" {
+		if flags.mode != "client" {
+			fatal(fmt.Sprintf("Cannot specify --%s flag when mode is %s", clientListenFlagName, flags.mode))
+		}
+		if len(command) > 0 {
+			fatal(fmt.Sprintf("Positional arguments cannot be used with --%s flag.", clientListenFlagName))
+		}
+		if flags.handshake {
+			fatal(fmt.Sprintf("Cannot specify --%s flag with --%s flag", handshakeFlagName, clientListenFlagName))
+		}
+		if flags.memorySpikeThreshold > 0 {
+			// Memory usage can only be measured for a process that the test runner starts.
+			fatal(fmt.Sprintf("Cannot specify --%s flag with --%s flag", memorySpikeFlagName, clientListenFlagName))
+		}
+	} else if len(flags.clients) > 0 || len(flags.servers) > 0 {
+		if flags.mode != "both" {
+			fatal(fmt.Sprintf("Cannot specify --%s or --%s flags when mode is %s", clientFlagName, serverFlagName, flags.mode))
+		}
+		if len(flags.clients) == 0 || len(flags.servers) == 0 {
+			fatal(fmt.Sprintf("Both --%s and --%s flags must be specified when either is used.", clientFlagName, serverFlagName))
+		}
+		if len(command) > 0 {
+			fatal(fmt.Sprintf("Positional arguments cannot be used with --%s and --%s flags.", clientFlagName, serverFlagName))
+		}
+		if flags.handshake {
+			fatal(fmt.Sprintf("Cannot specify --%s flag with --%s and --%s flags", handshakeFlagName, clientFlagName, serverFlagName))
+		}
+		if flags.baselineFile != "" || flags.saveBaselineFile != "" {
+			fatal(fmt.Sprintf("Cannot specify --%s or --%s flags with --%s and --%s flags", baselineFlagName, saveBaselineFlagName, clientFlagName, serverFlagName))
+		}
+		if flags.trace || flags.traceDir != "" || flags.traceFailuresOnly || flags.traceFrames || flags.traceDiskLimit > 0 {
+			fatal(fmt.Sprintf("Cannot specify --%s, --%s, --%s, --%s, or --%s flags with --%s and --%s flags",
+				traceFlagName, traceDirFlagName, traceFailuresFlagName, traceFramesFlagName, traceDiskFlagName, clientFlagName, serverFlagName))
+		}
+		if flags.diffReference {
+			fatal(fmt.Sprintf("Cannot specify --%s flag with --%s and --%s flags", diffReferenceFlagName, clientFlagName, serverFlagName))
+		}
+		if flags.timelineFile != "" {
+			fatal(fmt.Sprintf("Cannot specify --%s flag with --%s and --%s flags", timelineFlagName, clientFlagName, serverFlagName))
+		}
+		if flags.otlpFile != "" {
+			fatal(fmt.Sprintf("Cannot specify --%s flag with --%s and --%s flags", otlpFileFlagName, clientFlagName, serverFlagName))
+		}
+		if flags.pcapFile != "" {
+			fatal(fmt.Sprintf("Cannot specify --%s flag with --%s and --%s flags", pcapFileFlagName, clientFlagName, serverFlagName))
+		}
+	} else if len(command) == 0 {
 		fatal(`Positional arguments are required to configure the command line of the client or server under test.`)
 	}
 
+	if flags.baselineFile != "" && len(flags.knownFailingPatterns) > 0 {
+		fatal(fmt.Sprintf("Cannot specify --%s flag with --%s flag", knownFailingFlagName, baselineFlagName))
+	}
+
 	if flags.maxServers == 0 {
 		fatal(`Invalid max servers: must be greater than zero`)
 	}
@@ -198,14 +354,40 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 	if flags.parallel == 0 {
 		fatal(`Invalid parallelism: must be greater than zero`)
 	}
+	if flags.timeout < 0 {
+		fatal(`Invalid timeout: must not be negative`)
+	}
+	if flags.traceFailuresOnly && flags.traceDir == "" {
+		fatal(fmt.Sprintf("Cannot specify --%s flag without --%s flag", traceFailuresFlagName, traceDirFlagName))
+	}
+	if flags.traceFrames && !flags.trace && flags.traceDir == "" {
+		fatal(fmt.Sprintf("Cannot specify --%s flag without --%s or --%s flag", traceFramesFlagName, traceFlagName, traceDirFlagName))
+	}
+	if flags.traceDiskLimit > 0 && !flags.trace && flags.traceDir == "" && !flags.diffReference {
+		fatal(fmt.Sprintf("Cannot specify --%s flag without --%s, --%s, or --%s flag", traceDiskFlagName, traceFlagName, traceDirFlagName, diffReferenceFlagName))
+	}
+	if flags.memorySpikeThreshold > 0 && runtime.GOOS != "linux" {
+		fatal(fmt.Sprintf("The --%s flag is only supported on Linux", memorySpikeFlagName))
+	}
+
+	clients, err := parseNamedCommands(clientFlagName, flags.clients)
+	if err != nil {
+		fatal("%s", err)
+	}
+	servers, err := parseNamedCommands(serverFlagName, flags.servers)
+	if err != nil {
+		fatal("%s", err)
+	}
 
 	var clientCommand, serverCommand []string
-	switch flags.mode {
-	case "client":
+	switch {
+	case len(clients) > 0:
+		// Commands are provided via flags instead of positional args.
+	case flags.mode == "client":
 		clientCommand = command
-	case "server":
+	case flags.mode == "server":
 		serverCommand = command
-	case "both":
+	case flags.mode == "both":
 		pos := positionOf(command, "----")
 		if pos < 0 {
 			fatal(`Command is missing "----" separator. In mode "both", positional args should include client command, "----", then server command.`)
@@ -237,11 +419,17 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 		if cobraFlags.Changed(bindFlagName) {
 			fatal(fmt.Sprintf("Cannot specify --%s flag when mode is %s", bindFlagName, flags.mode))
 		}
+		if cobraFlags.Changed(malformedFlagName) {
+			fatal(fmt.Sprintf("Cannot specify --%s flag when mode is %s", malformedFlagName, flags.mode))
+		}
 	}
 	if flags.mode != "server" {
 		if cobraFlags.Changed(parallelFlagName) {
 			fatal(fmt.Sprintf("Cannot specify --%s/-%s flag when mode is %s", parallelFlagName, parallelFlagShortName, flags.mode))
 		}
+		if cobraFlags.Changed(diffReferenceFlagName) {
+			fatal(fmt.Sprintf("Cannot specify --%s flag when mode is %s", diffReferenceFlagName, flags.mode))
+		}
 	}
 
 	switch {
@@ -263,7 +451,11 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 		_ = file.Close()
 	}
 
-	for _, cmd := range [][]string{clientCommand, serverCommand} {
+	commands := [][]string{clientCommand, serverCommand}
+	for _, namedCommand := range append(clients, servers...) {
+		commands = append(commands, namedCommand.Command)
+	}
+	for _, cmd := range commands {
 		if len(cmd) == 0 {
 			continue
 		}
@@ -303,7 +495,10 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 			Verbose:              flags.verbose || flags.veryVerbose,
 			VeryVerbose:          flags.veryVerbose,
 			ClientCommand:        clientCommand,
+			ClientListen:         flags.clientListen,
 			ServerCommand:        serverCommand,
+			Clients:              clients,
+			Servers:              servers,
 			MaxServers:           flags.maxServers,
 			Parallelism:          flags.parallel,
 			TLSCertFile:          flags.tlsCertFile,
@@ -311,6 +506,20 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 			ServerPort:           flags.port,
 			ServerBind:           flags.bind,
 			HTTPTrace:            flags.trace,
+			TraceDir:             flags.traceDir,
+			TraceFailuresOnly:    flags.traceFailuresOnly,
+			TraceFrames:          flags.traceFrames,
+			TraceDiskLimit:       flags.traceDiskLimit,
+			DiffReference:        flags.diffReference,
+			TimelineFile:         flags.timelineFile,
+			OTLPFile:             flags.otlpFile,
+			PcapFile:             flags.pcapFile,
+			CaptureMalformed:     flags.captureMalformed,
+			Handshake:            flags.handshake,
+			BaselineFile:         flags.baselineFile,
+			SaveBaselineFile:     flags.saveBaselineFile,
+			Timeout:              flags.timeout,
+			MemorySpikeThreshold: flags.memorySpikeThreshold,
 		},
 		internal.NewPrinter(os.Stdout),
 		internal.NewPrinter(os.Stderr),
@@ -323,6 +532,27 @@ func run(flags *flags, cobraFlags *pflag.FlagSet, command []string) { //nolint:g
 	}
 }
 
+// parseNamedCommands parses flag values in the form "name=command". The command
+// is split into arguments on whitespace. Names must be unique.
+func parseNamedCommands(flagName string, values []string) ([]connectconformance.NamedCommand, error) {
+	commands := make([]connectconformance.NamedCommand, 0, len(values))
+	names := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		name, command, ok := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		args := strings.Fields(command)
+		if !ok || name == "" || len(args) == 0 {
+			return nil, fmt.Errorf("invalid --%s flag %q: should be in the form \"name=command\"", flagName, value)
+		}
+		if _, exists := names[name]; exists {
+			return nil, fmt.Errorf("invalid --%s flag %q: name %q is used more than once", flagName, value, name)
+		}
+		names[name] = struct{}{}
+		commands = append(commands, connectconformance.NamedCommand{Name: name, Command: args})
+	}
+	return commands, nil
+}
+
 func positionOf(slice []string, item string) int {
 	for i, str := range slice {
 		if str == item {
@@ -17,7 +17,9 @@ package main
 import (
 	"testing"
 
+	"connectrpc.com/conformance/internal/app/connectconformance"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParsePatterns(t *testing.T) {
@@ -40,3 +42,20 @@ func TestParsePatterns(t *testing.T) {
 	}
 	assert.Equal(t, expectedResult, patterns)
 }
+
+func TestParseNamedCommands(t *testing.T) {
+	t.Parallel()
+	commands, err := parseNamedCommands("client", []string{"go=./client -v", " node = node  client.js "})
+	require.NoError(t, err)
+	assert.Equal(t, []connectconformance.NamedCommand{
+		{Name: "go", Command: []string{"./client", "-v"}},
+		{Name: "node", Command: []string{"node", "client.js"}},
+	}, commands)
+
+	_, err = parseNamedCommands("client", []string{"./client"})
+	assert.ErrorContains(t, err, `should be in the form "name=command"`)
+	_, err = parseNamedCommands("client", []string{"go="})
+	assert.ErrorContains(t, err, `should be in the form "name=command"`)
+	_, err = parseNamedCommands("server", []string{"go=./a", "go=./b"})
+	assert.ErrorContains(t, err, `name "go" is used more than once`)
+}
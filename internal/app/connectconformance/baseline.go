@@ -0,0 +1,188 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"connectrpc.com/conformance/internal"
+)
+
+// baseline is the saved outcome of all test cases in a run. A later run can be
+// compared against it to report only what changed.
+type baseline struct {
+	// The version of the test runner that produced the baseline.
+	RunnerVersion string `json:"runnerVersion"`
+	// The outcomes of all test cases that were run, keyed by test case name.
+	Cases map[string]baselineCase `json:"cases"`
+}
+
+// baselineCase is the outcome of a single test case in a baseline.
+type baselineCase struct {
+	// True if the test case passed.
+	Passed bool `json:"passed"`
+	// If the test case failed, a description of why.
+	Failure string `json:"failure,omitempty"`
+}
+
+// loadBaseline reads a baseline from the given file, which was previously
+// written by saveBaseline.
+func loadBaseline(fileName string) (*baseline, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, internal.EnsureFileName(err, fileName)
+	}
+	var base baseline
+	if err := json.Unmarshal(data, &base); err != nil {
+		return nil, fmt.Errorf("%s: failed to parse baseline: %w", fileName, err)
+	}
+	if base.Cases == nil {
+		return nil, fmt.Errorf("%s: baseline does not contain any test cases", fileName)
+	}
+	return &base, nil
+}
+
+// saveBaseline writes the given baseline to the given file.
+func saveBaseline(fileName string, base *baseline) error {
+	data, err := json.MarshalIndent(base, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if err := os.WriteFile(fileName, data, 0o600); err != nil {
+		return internal.EnsureFileName(err, fileName)
+	}
+	return nil
+}
+
+// baselineChanges describes the differences between a baseline and the
+// outcomes of the current run. All slices are sorted by test case name.
+type baselineChanges struct {
+	// Test cases that passed (or did not exist) in the baseline but now fail.
+	newlyFailing []string
+	// Test cases that failed in the baseline but now pass.
+	newlyPassing []string
+	// Test cases that failed in both, but with a different failure message.
+	changedFailures []string
+	// Test cases that are in the baseline but were not run.
+	removed []string
+}
+
+func (c *baselineChanges) empty() bool {
+	return len(c.newlyFailing) == 0 && len(c.newlyPassing) == 0 &&
+		len(c.changedFailures) == 0 && len(c.removed) == 0
+}
+
+// compareBaseline computes the differences between the given baseline and the
+// given current outcomes. Test cases that are known to be flaky are excluded,
+// since their outcomes are expected to change from one run to the next.
+func compareBaseline(base, current *baseline, knownFlaky *testTrie) *baselineChanges {
+	var changes baselineChanges
+	for name, currentCase := range current.Cases {
+		if knownFlaky.matchPattern(name) {
+			continue
+		}
+		baseCase, inBase := base.Cases[name]
+		switch {
+		case !currentCase.Passed && (!inBase || baseCase.Passed):
+			changes.newlyFailing = append(changes.newlyFailing, name)
+		case currentCase.Passed && inBase && !baseCase.Passed:
+			changes.newlyPassing = append(changes.newlyPassing, name)
+		case !currentCase.Passed && currentCase.Failure != baseCase.Failure:
+			changes.changedFailures = append(changes.changedFailures, name)
+		}
+	}
+	for name := range base.Cases {
+		if _, inCurrent := current.Cases[name]; !inCurrent && !knownFlaky.matchPattern(name) {
+			changes.removed = append(changes.removed, name)
+		}
+	}
+	sort.Strings(changes.newlyFailing)
+	sort.Strings(changes.newlyPassing)
+	sort.Strings(changes.changedFailures)
+	sort.Strings(changes.removed)
+	return &changes
+}
+
+// toBaseline returns the outcomes of all test cases, in a form that can be
+// saved and later compared to another run.
+func (r *testResults) toBaseline() *baseline {
+	r.settle()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	base := &baseline{
+		RunnerVersion: internal.Version,
+		Cases:         make(map[string]baselineCase, len(r.outcomes)),
+	}
+	for name, outcome := range r.outcomes {
+		var baseCase baselineCase
+		if outcome.actualFailure == nil {
+			baseCase.Passed = true
+		} else {
+			baseCase.Failure = outcome.actualFailure.Error()
+		}
+		base.Cases[name] = baseCase
+	}
+	return base
+}
+
+// reportChanges is an alternative to report, which only reports how the
+// outcomes differ from the given baseline. It returns false if any test
+// cases newly failed.
+func (r *testResults) reportChanges(base *baseline, printer internal.Printer) bool {
+	current := r.toBaseline()
+	changes := compareBaseline(base, current, r.knownFlaky)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range changes.newlyFailing {
+		printer.Printf("NEWLY FAILING: %s:\n%s", name, indent(current.Cases[name].Failure))
+		if !r.printTraces {
+			continue
+		}
+		if trace := r.traces.Get(name); trace != nil {
+			printer.Printf("---- HTTP Trace ----")
+			trace.Print(printer)
+			printer.Printf("--------------------")
+		}
+	}
+	for _, name := range changes.changedFailures {
+		printer.Printf("CHANGED FAILURE: %s:\n  was:\n%s\n  now:\n%s",
+			name, indent(indent(base.Cases[name].Failure)), indent(indent(current.Cases[name].Failure)))
+	}
+	for _, name := range changes.newlyPassing {
+		printer.Printf("NEWLY PASSING: %s", name)
+	}
+	for _, name := range changes.removed {
+		printer.Printf("NO LONGER RUN: %s", name)
+	}
+	if !changes.empty() {
+		// Add a blank line to separate summary from messages above
+		printer.Printf("\n")
+	}
+
+	for _, impl := range r.implementations {
+		printer.Printf("%s", impl)
+	}
+	printer.Printf("Total cases: %d\nCompared to baseline: %d newly failing, %d newly passing, %d with changed failures, %d no longer run",
+		len(current.Cases), len(changes.newlyFailing), len(changes.newlyPassing), len(changes.changedFailures), len(changes.removed))
+	if couldNotRun := r.totalTestCount - len(current.Cases); couldNotRun > 0 {
+		printer.Printf("Another %d could not be run due to client timing out or exiting prematurely.", couldNotRun)
+	}
+	return len(changes.newlyFailing) == 0
+}
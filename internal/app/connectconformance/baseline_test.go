@@ -0,0 +1,124 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaseline_SaveAndLoad(t *testing.T) {
+	t.Parallel()
+	results := newResults(0, &testTrie{}, &testTrie{}, nil)
+	results.setOutcome("foo/bar/1", false, nil)
+	results.setOutcome("foo/bar/2", false, errors.New("fail"))
+	results.setOutcome("foo/bar/3", true, errors.New("could not start"))
+
+	fileName := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, saveBaseline(fileName, results.toBaseline()))
+	base, err := loadBaseline(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, internal.Version, base.RunnerVersion)
+	assert.Equal(t, map[string]baselineCase{
+		"foo/bar/1": {Passed: true},
+		"foo/bar/2": {Failure: "fail"},
+		"foo/bar/3": {Failure: "could not start"},
+	}, base.Cases)
+
+	_, err = loadBaseline(filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+	badFileName := filepath.Join(t.TempDir(), "bad.json")
+	require.NoError(t, os.WriteFile(badFileName, []byte(`{"runnerVersion": "v1.0.0"}`), 0o600))
+	_, err = loadBaseline(badFileName)
+	require.ErrorContains(t, err, "baseline does not contain any test cases")
+}
+
+func TestCompareBaseline(t *testing.T) {
+	t.Parallel()
+	base := &baseline{Cases: map[string]baselineCase{
+		"still-passing":     {Passed: true},
+		"still-failing":     {Failure: "fail"},
+		"newly-failing":     {Passed: true},
+		"newly-passing":     {Failure: "fail"},
+		"changed-failure":   {Failure: "fail"},
+		"removed":           {Passed: true},
+		"known-to-flake/1":  {Passed: true},
+		"known-to-flake/2":  {Passed: true},
+		"known-to-flake/3":  {Failure: "flake"},
+		"removed-but-flaky": {Passed: true},
+	}}
+	current := &baseline{Cases: map[string]baselineCase{
+		"still-passing":    {Passed: true},
+		"still-failing":    {Failure: "fail"},
+		"newly-failing":    {Failure: "fail"},
+		"newly-passing":    {Passed: true},
+		"changed-failure":  {Failure: "different"},
+		"new-and-passing":  {Passed: true},
+		"new-and-failing":  {Failure: "fail"},
+		"known-to-flake/1": {Failure: "flake"},
+		"known-to-flake/3": {Passed: true},
+	}}
+	changes := compareBaseline(base, current, parsePatterns([]string{"known-to-flake/**", "removed-but-flaky"}))
+	assert.Equal(t, []string{"new-and-failing", "newly-failing"}, changes.newlyFailing)
+	assert.Equal(t, []string{"newly-passing"}, changes.newlyPassing)
+	assert.Equal(t, []string{"changed-failure"}, changes.changedFailures)
+	assert.Equal(t, []string{"removed"}, changes.removed)
+
+	assert.True(t, compareBaseline(current, current, &testTrie{}).empty())
+}
+
+func TestResults_ReportChanges(t *testing.T) {
+	t.Parallel()
+	base := &baseline{Cases: map[string]baselineCase{
+		"foo/bar/1": {Passed: true},
+		"foo/bar/2": {Failure: "fail"},
+		"foo/bar/3": {Failure: "fail"},
+		"foo/bar/4": {Failure: "fail"},
+	}}
+
+	results := newResults(3, &testTrie{}, &testTrie{}, nil)
+	results.setOutcome("foo/bar/1", false, nil)
+	results.setOutcome("foo/bar/2", false, errors.New("fail"))
+	results.setOutcome("foo/bar/3", false, nil)
+	logger := &internal.SimplePrinter{}
+	// The only changes are that one case newly passes and another is no longer run.
+	require.True(t, results.reportChanges(base, logger))
+	assert.Equal(t, []string{
+		"NEWLY PASSING: foo/bar/3\n",
+		"NO LONGER RUN: foo/bar/4\n",
+		"\n",
+		"Total cases: 3\nCompared to baseline: 0 newly failing, 1 newly passing, 0 with changed failures, 1 no longer run\n",
+	}, logger.Messages)
+
+	results = newResults(3, &testTrie{}, &testTrie{}, nil)
+	results.setOutcome("foo/bar/1", false, errors.New("fail"))
+	results.setOutcome("foo/bar/2", false, errors.New("fail again"))
+	results.setOutcome("foo/bar/3", false, errors.New("fail"))
+	logger = &internal.SimplePrinter{}
+	require.False(t, results.reportChanges(base, logger))
+	assert.Equal(t, []string{
+		"NEWLY FAILING: foo/bar/1:\n\tfail\n",
+		"CHANGED FAILURE: foo/bar/2:\n  was:\n\t\tfail\n  now:\n\t\tfail again\n",
+		"NO LONGER RUN: foo/bar/4\n",
+		"\n",
+		"Total cases: 3\nCompared to baseline: 1 newly failing, 0 newly passing, 1 with changed failures, 1 no longer run\n",
+	}, logger.Messages)
+}
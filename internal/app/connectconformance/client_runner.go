@@ -45,6 +45,9 @@ type clientRunner interface {
 
 	isRunning() bool
 	stop()
+	// pid returns the ID of the client's OS process, or zero if the
+	// client is not a separate OS process.
+	pid() int
 }
 
 func runClient(ctx context.Context, start processStarter) (clientRunner, error) {
@@ -58,7 +61,7 @@ func runClient(ctx context.Context, start processStarter) (clientRunner, error)
 		pendingOps: map[string]func(string, *conformancev1.ClientCompatResponse, error){},
 	}
 	proc.whenDone(func(_ error) {
-		result.terminated.Store(false)
+		result.terminated.Store(true)
 	})
 	go result.consumeOutput()
 	return result, nil
@@ -171,6 +174,10 @@ func (c *clientProcessRunner) isRunning() bool {
 	return !c.terminated.Load()
 }
 
+func (c *clientProcessRunner) pid() int {
+	return c.proc.pid()
+}
+
 func (c *clientProcessRunner) stop() {
 	c.proc.abort()
 	c.terminated.Store(true)
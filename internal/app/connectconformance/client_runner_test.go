@@ -19,7 +19,10 @@ import (
 	"errors"
 	"io"
 	"math/rand"
+	"net"
+	"sync"
 	"testing"
+	"time"
 
 	"connectrpc.com/conformance/internal"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
@@ -133,6 +136,123 @@ func TestRunClient(t *testing.T) {
 	}
 }
 
+func TestRunClient_OverConnection(t *testing.T) {
+	t.Parallel()
+
+	testReqs := []*conformancev1.ClientCompatRequest{
+		{
+			TestName: "TestSuite1/testcase1",
+		},
+		{
+			TestName: "TestSuite1/testcase2",
+		},
+		{
+			TestName: "TestSuite2/testcase1",
+		},
+		{
+			TestName: "TestSuite2/testcase2",
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		clientFunc      func(_ context.Context, _ []string, in io.ReadCloser, out, _ io.WriteCloser) error
+		expectedResults map[string]bool
+	}{
+		{
+			name:       "simple",
+			clientFunc: (&testClientProcess{}).run,
+			expectedResults: map[string]bool{
+				"TestSuite1/testcase1": true,
+				"TestSuite1/testcase2": true,
+				"TestSuite2/testcase1": true,
+				"TestSuite2/testcase2": true,
+			},
+		},
+		{
+			name:       "random order",
+			clientFunc: testClientProcessRand,
+			expectedResults: map[string]bool{
+				"TestSuite1/testcase1": true,
+				"TestSuite1/testcase2": true,
+				"TestSuite2/testcase1": true,
+				"TestSuite2/testcase2": true,
+			},
+		},
+		{
+			name:       "client disconnects",
+			clientFunc: (&testClientProcess{failAfter: 2}).run,
+			expectedResults: map[string]bool{
+				"TestSuite1/testcase1": true,
+				"TestSuite1/testcase2": true,
+				"TestSuite2/testcase1": false,
+				"TestSuite2/testcase2": false,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			listener, err := listen("tcp:127.0.0.1:0")
+			require.NoError(t, err)
+			go func() {
+				conn, err := net.Dial(listener.Addr().Network(), listener.Addr().String())
+				if err != nil {
+					t.Logf("failed to connect: %v", err)
+					return
+				}
+				defer func() {
+					_ = conn.Close()
+				}()
+				_ = testCase.clientFunc(context.Background(), nil, conn, conn, nil)
+			}()
+			runner, err := runClient(context.Background(), acceptConnection(listener, time.Minute))
+			require.NoError(t, err)
+
+			var mu sync.Mutex
+			actualResults := make(map[string]bool, len(testReqs))
+			for _, req := range testReqs {
+				err := runner.sendRequest(req, func(name string, _ *conformancev1.ClientCompatResponse, err error) {
+					mu.Lock()
+					defer mu.Unlock()
+					actualResults[name] = err == nil
+				})
+				if err != nil {
+					// Client could disconnect while we're still sending.
+					mu.Lock()
+					actualResults[req.TestName] = false
+					mu.Unlock()
+				}
+			}
+			runner.closeSend()
+			_ = runner.waitForResponses()
+			assert.Eventually(t, func() bool { return !runner.isRunning() }, time.Second, 10*time.Millisecond)
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Empty(t, cmp.Diff(testCase.expectedResults, actualResults))
+		})
+	}
+}
+
+func TestAcceptConnection_Timeout(t *testing.T) {
+	t.Parallel()
+	listener, err := listen("tcp:127.0.0.1:0")
+	require.NoError(t, err)
+	_, err = runClient(context.Background(), acceptConnection(listener, 100*time.Millisecond))
+	assert.ErrorContains(t, err, "timed out waiting for connection")
+}
+
+func TestListen_InvalidAddress(t *testing.T) {
+	t.Parallel()
+	_, err := listen("127.0.0.1:1234")
+	assert.ErrorContains(t, err, "network should be tcp, tcp4, tcp6, or unix")
+	_, err = listen("tcp")
+	assert.ErrorContains(t, err, `should be in the form "network:address"`)
+}
+
 // testClientProcess reads requests from in and immediately writes a corresponding response to out.
 type testClientProcess struct {
 	failAfter int
@@ -37,6 +37,9 @@ type configCase struct {
 	UseTLSClientCerts      bool
 	UseConnectGET          bool
 	UseMessageReceiveLimit bool
+	UseReflection          bool
+	UseHealth              bool
+	UseCORS                bool
 	ConnectVersionMode     conformancev1.TestSuite_ConnectVersionMode
 }
 
@@ -56,6 +59,9 @@ type supportedFeatures struct {
 	SupportsHalfDuplexBidiOverHTTP1 bool
 	SupportsConnectGet              bool
 	SupportsMessageReceiveLimit     bool
+	SupportsReflection              bool
+	SupportsHealth                  bool
+	SupportsCORS                    bool
 }
 
 // parseConfig loads all config cases from the given file name. If the given
@@ -78,7 +84,7 @@ func parseConfig(configFileName string, data []byte) ([]configCase, error) {
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", configFileName, err)
 	}
-	cases := computeCasesFromFeatures(features, nil, nil, nil)
+	cases := computeCasesFromFeatures(features, nil, nil, nil, nil, nil, nil)
 	for i, includeCase := range config.IncludeCases {
 		resolvedIncludes, err := resolveCase(features, includeCase)
 		if err != nil {
@@ -124,6 +130,9 @@ func resolveFeatures(features *conformancev1.Features) (supportedFeatures, error
 		SupportsHalfDuplexBidiOverHTTP1: features.GetSupportsHalfDuplexBidiOverHttp1(),
 		SupportsConnectGet:              features.GetSupportsConnectGet(),
 		SupportsMessageReceiveLimit:     features.GetSupportsMessageReceiveLimit(),
+		SupportsReflection:              features.GetSupportsReflection(),
+		SupportsHealth:                  features.GetSupportsHealth(),
+		SupportsCORS:                    features.GetSupportsCors(),
 	}
 
 	// These flags should default to true if not provided
@@ -260,8 +269,8 @@ func resolveFeatures(features *conformancev1.Features) (supportedFeatures, error
 
 // computeCasesFromFeatures expands the given features into all matching config
 // permutations.
-func computeCasesFromFeatures(features supportedFeatures, tlsCases, tlsClientCertCases, msgRecvLimitCases []bool) map[configCase]struct{} { //nolint:gocyclo
-	// if tlsCases, tlsClientCertCases, and msgRecvLimitCases not explicitly provided, derive them from features
+func computeCasesFromFeatures(features supportedFeatures, tlsCases, tlsClientCertCases, msgRecvLimitCases, reflectionCases, healthCases, corsCases []bool) map[configCase]struct{} { //nolint:gocyclo
+	// if tlsCases, tlsClientCertCases, msgRecvLimitCases, reflectionCases, healthCases, and corsCases not explicitly provided, derive them from features
 	if len(tlsCases) == 0 {
 		if features.SupportsTLS {
 			tlsCases = []bool{false, true}
@@ -283,6 +292,27 @@ func computeCasesFromFeatures(features supportedFeatures, tlsCases, tlsClientCer
 			msgRecvLimitCases = []bool{false}
 		}
 	}
+	if len(reflectionCases) == 0 {
+		if features.SupportsReflection {
+			reflectionCases = []bool{false, true}
+		} else {
+			reflectionCases = []bool{false}
+		}
+	}
+	if len(healthCases) == 0 {
+		if features.SupportsHealth {
+			healthCases = []bool{false, true}
+		} else {
+			healthCases = []bool{false}
+		}
+	}
+	if len(corsCases) == 0 {
+		if features.SupportsCORS {
+			corsCases = []bool{false, true}
+		} else {
+			corsCases = []bool{false}
+		}
+	}
 	cases := map[configCase]struct{}{}
 	for _, version := range features.Versions {
 		for _, tlsCase := range tlsCases {
@@ -328,17 +358,26 @@ func computeCasesFromFeatures(features supportedFeatures, tlsCases, tlsClientCer
 							for _, compression := range features.Compressions {
 								for _, connectGetCase := range connectGetCases {
 									for _, msgRecvLimitCase := range msgRecvLimitCases {
-										cases[configCase{
-											Version:                version,
-											Protocol:               protocol,
-											Codec:                  codec,
-											Compression:            compression,
-											StreamType:             streamType,
-											UseTLS:                 tlsCase,
-											UseTLSClientCerts:      tlsClientCertCase,
-											UseConnectGET:          connectGetCase,
-											UseMessageReceiveLimit: msgRecvLimitCase,
-										}] = struct{}{}
+										for _, reflectionCase := range reflectionCases {
+											for _, healthCase := range healthCases {
+												for _, corsCase := range corsCases {
+													cases[configCase{
+														Version:                version,
+														Protocol:               protocol,
+														Codec:                  codec,
+														Compression:            compression,
+														StreamType:             streamType,
+														UseTLS:                 tlsCase,
+														UseTLSClientCerts:      tlsClientCertCase,
+														UseConnectGET:          connectGetCase,
+														UseMessageReceiveLimit: msgRecvLimitCase,
+														UseReflection:          reflectionCase,
+														UseHealth:              healthCase,
+														UseCORS:                corsCase,
+													}] = struct{}{}
+												}
+											}
+										}
 									}
 								}
 							}
@@ -401,7 +440,7 @@ func resolveCase(features supportedFeatures, unresolvedCase *conformancev1.Confi
 		}
 		impliedFeatures.StreamTypes = []conformancev1.StreamType{unresolvedCase.StreamType}
 	}
-	var tlsCases, tlsClientCertCases, msgReceiveLimitCases []bool
+	var tlsCases, tlsClientCertCases, msgReceiveLimitCases, reflectionCases, healthCases, corsCases []bool
 	if unresolvedCase.UseTls != nil {
 		tlsCases = []bool{unresolvedCase.GetUseTls()}
 	}
@@ -419,7 +458,16 @@ func resolveCase(features supportedFeatures, unresolvedCase *conformancev1.Confi
 	if unresolvedCase.UseMessageReceiveLimit != nil {
 		msgReceiveLimitCases = []bool{unresolvedCase.GetUseMessageReceiveLimit()}
 	}
-	return computeCasesFromFeatures(impliedFeatures, tlsCases, tlsClientCertCases, msgReceiveLimitCases), nil
+	if unresolvedCase.UseReflection != nil {
+		reflectionCases = []bool{unresolvedCase.GetUseReflection()}
+	}
+	if unresolvedCase.UseHealth != nil {
+		healthCases = []bool{unresolvedCase.GetUseHealth()}
+	}
+	if unresolvedCase.UseCors != nil {
+		corsCases = []bool{unresolvedCase.GetUseCors()}
+	}
+	return computeCasesFromFeatures(impliedFeatures, tlsCases, tlsClientCertCases, msgReceiveLimitCases, reflectionCases, healthCases, corsCases), nil
 }
 
 func checkForDeprecations(config *conformancev1.Config) {
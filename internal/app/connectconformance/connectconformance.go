@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"connectrpc.com/conformance/internal"
 	"connectrpc.com/conformance/internal/app/connectconformance/testsuites"
@@ -47,7 +48,10 @@ type Flags struct {
 	Verbose              bool
 	VeryVerbose          bool
 	ClientCommand        []string
+	ClientListen         string
 	ServerCommand        []string
+	Clients              []NamedCommand
+	Servers              []NamedCommand
 	TestFiles            []string
 	MaxServers           uint
 	Parallelism          uint
@@ -56,21 +60,62 @@ type Flags struct {
 	ServerPort           uint
 	ServerBind           string
 	HTTPTrace            bool
+	TraceDir             string
+	TraceFailuresOnly    bool
+	TraceFrames          bool
+	TraceDiskLimit       uint
+	DiffReference        bool
+	TimelineFile         string
+	OTLPFile             string
+	PcapFile             string
+	CaptureMalformed     bool
+	Handshake            bool
+	BaselineFile         string
+	SaveBaselineFile     string
+	Timeout              time.Duration
+	MemorySpikeThreshold uint
 }
 
 func Run(flags *Flags, logPrinter internal.Printer, errPrinter internal.Printer) (bool, error) {
-	var configData []byte
-	if flags.ConfigFile != "" {
+	var handshakes []*handshakeResult
+	var handshakeCases map[configCase]struct{}
+	if flags.Handshake {
 		var err error
-		if configData, err = os.ReadFile(flags.ConfigFile); err != nil {
-			return false, internal.EnsureFileName(err, flags.ConfigFile)
+		if handshakes, err = performHandshakes(context.Background(), flags); err != nil {
+			return false, err
+		}
+		if handshakeCases, err = casesFromHandshakes(handshakes); err != nil {
+			return false, err
 		}
-	} else if flags.Verbose {
-		logPrinter.Printf("No config file provided. Using defaults.")
 	}
-	configCases, err := parseConfig(flags.ConfigFile, configData)
-	if err != nil {
-		return false, err
+
+	var configCases []configCase
+	if flags.ConfigFile == "" && flags.Handshake {
+		if flags.Verbose {
+			logPrinter.Printf("No config file provided. Using features reported via handshake.")
+		}
+		configCases = make([]configCase, 0, len(handshakeCases))
+		for c := range handshakeCases {
+			configCases = append(configCases, c)
+		}
+	} else {
+		var configData []byte
+		if flags.ConfigFile != "" {
+			var err error
+			if configData, err = os.ReadFile(flags.ConfigFile); err != nil {
+				return false, internal.EnsureFileName(err, flags.ConfigFile)
+			}
+		} else if flags.Verbose {
+			logPrinter.Printf("No config file provided. Using defaults.")
+		}
+		var err error
+		configCases, err = parseConfig(flags.ConfigFile, configData)
+		if err != nil {
+			return false, err
+		}
+		if flags.Handshake {
+			warnConfigMismatch(flags.ConfigFile, configCases, handshakeCases, handshakes, errPrinter)
+		}
 	}
 	if flags.Verbose {
 		logPrinter.Printf("Computed %d config case permutations.", len(configCases))
@@ -91,6 +136,7 @@ func Run(flags *Flags, logPrinter internal.Printer, errPrinter internal.Printer)
 	skipPatterns := parsePatterns(flags.SkipPatterns)
 
 	var testSuiteData map[string][]byte
+	var err error
 	if len(flags.TestFiles) > 0 {
 		testSuiteData, err = testsuites.LoadTestSuitesFromFiles(flags.TestFiles)
 		if err != nil {
@@ -114,13 +160,68 @@ func Run(flags *Flags, logPrinter internal.Printer, errPrinter internal.Printer)
 		logPrinter.Printf("Loaded %d test suite(s), %d test case template(s).", len(allSuites), numCases)
 	}
 
+	var base *baseline
+	if flags.BaselineFile != "" {
+		if base, err = loadBaseline(flags.BaselineFile); err != nil {
+			return false, err
+		}
+		if flags.Verbose {
+			logPrinter.Printf("Loaded baseline with %d test case outcome(s).", len(base.Cases))
+		}
+	}
+
+	if len(flags.Clients) > 0 || len(flags.Servers) > 0 {
+		plan, err := planRun(configCases, knownFailing, knownFlaky, runPatterns, skipPatterns, allSuites, logPrinter, flags, false, false)
+		if err != nil {
+			return false, err
+		}
+		matrix, err := runMatrix(plan, namedProcesses(flags.Clients), namedProcesses(flags.Servers),
+			knownFailing, knownFlaky, logPrinter, errPrinter, flags)
+		if matrix == nil {
+			return false, err
+		}
+		if err != nil {
+			errPrinter.Printf("%v", err)
+		}
+		return matrix.report(logPrinter) && err == nil, nil
+	}
+
 	results, err := run(configCases, knownFailing, knownFlaky, runPatterns, skipPatterns, allSuites, logPrinter, errPrinter, flags)
 	if results == nil {
 		return false, err
 	}
+	defer func() {
+		_ = results.closeTraces()
+	}()
+	for _, result := range handshakes {
+		results.setImplementation(result.role, result.identity())
+	}
 	if err != nil {
 		errPrinter.Printf("%v", err)
 	}
+	if flags.SaveBaselineFile != "" {
+		if saveErr := saveBaseline(flags.SaveBaselineFile, results.toBaseline()); saveErr != nil {
+			return false, fmt.Errorf("failed to save baseline: %w", saveErr)
+		}
+	}
+	if flags.TraceDir != "" {
+		if traceErr := results.writeTraces(flags.TraceDir, flags.TraceFailuresOnly); traceErr != nil {
+			return false, fmt.Errorf("failed to write traces: %w", traceErr)
+		}
+	}
+	if flags.TimelineFile != "" {
+		if timelineErr := results.writeTimeline(flags.TimelineFile); timelineErr != nil {
+			return false, fmt.Errorf("failed to write timeline: %w", timelineErr)
+		}
+	}
+	if flags.OTLPFile != "" {
+		if spansErr := results.writeSpans(flags.OTLPFile); spansErr != nil {
+			return false, fmt.Errorf("failed to write spans: %w", spansErr)
+		}
+	}
+	if base != nil {
+		return results.reportChanges(base, logPrinter) && err == nil, nil
+	}
 	return results.report(logPrinter) && err == nil, nil
 }
 
@@ -134,10 +235,312 @@ func run( //nolint:gocyclo
 	logPrinter internal.Printer,
 	errPrinter internal.Printer,
 	flags *Flags,
-) (*testResults, error) {
-	mode := conformancev1.TestSuite_TEST_MODE_UNSPECIFIED
-	useReferenceClient := len(flags.ClientCommand) == 0
+) (_ *testResults, err error) {
+	useReferenceClient := len(flags.ClientCommand) == 0 && flags.ClientListen == ""
 	useReferenceServer := len(flags.ServerCommand) == 0
+	plan, err := planRun(configCases, knownFailing, knownFlaky, run, skip, allSuites, logPrinter, flags, useReferenceClient, useReferenceServer)
+	if err != nil {
+		return nil, err
+	}
+	testCaseLib, svrInstances, filter := plan.testCaseLib, plan.svrInstances, plan.filter
+	serverCreds, clientCreds := plan.serverCreds, plan.clientCreds
+
+	var trace *tracer.Tracer
+	if flags.HTTPTrace || flags.TraceDir != "" || flags.DiffReference || flags.TimelineFile != "" || flags.OTLPFile != "" ||
+		flags.PcapFile != "" {
+		trace = &tracer.Tracer{RecordFrames: flags.TraceFrames, PropagateTraceContext: flags.OTLPFile != ""}
+	}
+	if flags.PcapFile != "" {
+		files, captureErr := openCaptureFiles(flags.PcapFile)
+		if captureErr != nil {
+			return nil, fmt.Errorf("failed to create packet capture: %w", captureErr)
+		}
+		files.apply(trace)
+		defer func() {
+			if closeErr := files.close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("failed to write packet capture: %w", closeErr)
+			}
+		}()
+	}
+	var runTimeline *timeline
+	if flags.TimelineFile != "" {
+		runTimeline = newTimeline()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var clients []processInfo
+	if useReferenceClient {
+		clients = referenceClients(flags, trace)
+	} else if flags.ClientListen != "" {
+		listener, err := listen(flags.ClientListen)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen for client: %w", err)
+		}
+		defer func() {
+			_ = listener.Close()
+		}()
+		logPrinter.Printf("Waiting for client under test to connect to %s %s...", listener.Addr().Network(), listener.Addr())
+		clients = []processInfo{
+			{
+				start: acceptConnection(listener, clientConnectTimeout),
+			},
+		}
+	} else {
+		start := runCommand(flags.ClientCommand)
+		if flags.Handshake {
+			start = withHandshake(runCommand(flags.ClientCommand, handshakeEnv), "client")
+		}
+		clients = []processInfo{
+			{
+				start: start,
+			},
+		}
+	}
+
+	results := newResults(plan.filteredTestCount, knownFailing, knownFlaky, trace)
+	results.printTraces = flags.HTTPTrace
+	results.keepAllTraces = flags.TraceDir != ""
+	if trace != nil && flags.TraceDiskLimit > 0 {
+		// Traces that are kept until the end of the run, for the
+		// report or for writing to files, are stored on disk.
+		store, err := tracer.NewDiskStore(int64(flags.TraceDiskLimit) * 1024 * 1024)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create trace storage: %w", err)
+		}
+		results.traces = store
+	}
+	results.timeline = runTimeline
+	if flags.OTLPFile != "" {
+		results.spans = newSpanExporter()
+	}
+	timer := startRunTimer(flags.Timeout, func(err error) {
+		errPrinter.Printf("ERROR: %v; stopping all processes", err)
+		printInFlight(errPrinter, "", results.timeOut(err))
+		cancel()
+	})
+	defer func() {
+		timer.stop()
+		err = timer.check(err)
+	}()
+
+	for _, clientInfo := range clients {
+		clientProcess, err := runClient(ctx, clientInfo.start)
+		if err != nil {
+			return nil, fmt.Errorf("error starting client: %w", err)
+		}
+		defer clientProcess.stop()
+		clientName := clientInfo.name
+		if clientName == "" {
+			clientName = "client under test"
+		}
+		clientLane := runTimeline.newLane(clientName, clientInfo.isReferenceImpl)
+
+		var servers []processInfo
+		if useReferenceServer {
+			servers = referenceServers(flags, trace)
+		} else {
+			start := runCommand(flags.ServerCommand)
+			if flags.Handshake {
+				start = withHandshake(runCommand(flags.ServerCommand, handshakeEnv), "server")
+			}
+			servers = []processInfo{
+				{
+					start: start,
+				},
+			}
+		}
+
+		err = func() error {
+			var wg sync.WaitGroup
+			defer wg.Wait()
+			sema := semaphore.NewWeighted(int64(flags.MaxServers))
+
+			for _, serverInfo := range servers {
+				for _, svrInstance := range svrInstances {
+					testCases := testCaseLib.casesByServer[svrInstance]
+					testCases = testCaseLib.filterGRPCImplTestCases(testCases, clientInfo.isGrpcImpl, serverInfo.isGrpcImpl)
+					testCases = filter.apply(testCases)
+					if len(testCases) == 0 {
+						continue
+					}
+
+					weight := semaphoreWeight(svrInstance, flags)
+					if err := sema.Acquire(ctx, weight); err != nil {
+						return err
+					}
+
+					// Double-check that client is still running before spawning a server process.
+					if !clientProcess.isRunning() {
+						err := clientProcess.waitForResponses()
+						if err == nil {
+							err = errors.New("client process unexpectedly stopped")
+						}
+						return err
+					}
+
+					if flags.Verbose {
+						var with string
+						switch {
+						case clientInfo.name != "" && serverInfo.name != "":
+							with = clientInfo.name + " and " + serverInfo.name
+						case clientInfo.name != "":
+							with = clientInfo.name
+						case serverInfo.name != "":
+							with = serverInfo.name
+						}
+						logTestCaseInfo(with, svrInstance, len(testCases), logPrinter)
+					}
+
+					serverName := serverInfo.name
+					if serverName == "" {
+						serverName = "server under test"
+					}
+					serverLane := runTimeline.newLane(serverName+" "+svrInstance.String(), serverInfo.isReferenceImpl)
+
+					wg.Add(1)
+					go func(ctx context.Context, clientInfo processInfo, serverInfo processInfo, svrInstance serverInstance) {
+						defer wg.Done()
+						defer sema.Release(weight)
+						runTestCasesForServer(
+							ctx,
+							clientInfo.isReferenceImpl,
+							serverInfo.isReferenceImpl,
+							svrInstance,
+							testCases,
+							serverCreds,
+							clientCreds,
+							runTimeline.serverStarter(serverLane, svrInstance, serverInfo.start),
+							logPrinter,
+							errPrinter,
+							results,
+							runTimeline.client(clientProcess, clientLane, serverLane),
+							trace,
+							flags.VeryVerbose,
+							flags.MemorySpikeThreshold,
+						)
+					}(ctx, clientInfo, serverInfo, svrInstance)
+				}
+			}
+			return nil
+		}()
+
+		if err != nil {
+			return results, err
+		}
+
+		clientProcess.closeSend()
+		if err := clientProcess.waitForResponses(); err != nil {
+			return results, err
+		}
+	}
+
+	if flags.DiffReference && useReferenceClient && !useReferenceServer {
+		failed := results.unexpectedFailures()
+		if len(failed) > 0 {
+			if flags.Verbose {
+				logPrinter.Printf("Re-running %d failed test case(s) against the reference server...", len(failed))
+			}
+			referenceTraces, err := traceWithReferenceServer(ctx, failed, testCaseLib, svrInstances, serverCreds, clientCreds, logPrinter, errPrinter, flags)
+			if err != nil {
+				return results, fmt.Errorf("failed to re-run test cases against the reference server: %w", err)
+			}
+			results.setReferenceTraces(referenceTraces)
+		}
+	}
+
+	return results, nil
+}
+
+// referenceClients returns the reference client processes, which report
+// traces to the given tracer.
+func referenceClients(flags *Flags, trace *tracer.Tracer) []processInfo {
+	return []processInfo{
+		{
+			name: "reference client",
+			start: runInProcess([]string{
+				"reference-client",
+				"-p", strconv.Itoa(int(flags.Parallelism)),
+			}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
+				return referenceclient.RunInReferenceMode(ctx, args, inReader, outWriter, errWriter, trace)
+			}),
+			isReferenceImpl: true,
+		},
+		{
+			name: "reference client (grpc)",
+			start: runInProcess([]string{
+				"grpc-reference-client",
+				"-p", strconv.Itoa(int(flags.Parallelism)),
+			}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
+				return grpcclient.RunWithTrace(ctx, args, inReader, outWriter, errWriter, trace)
+			}),
+			isGrpcImpl: true,
+		},
+	}
+}
+
+// referenceServers returns the reference server processes, which report
+// traces to the given tracer.
+func referenceServers(flags *Flags, trace *tracer.Tracer) []processInfo {
+	args := []string{
+		"reference-server",
+		"-port", strconv.FormatUint(uint64(flags.ServerPort), 10),
+		"-bind", flags.ServerBind,
+		"-cert", flags.TLSCertFile,
+		"-key", flags.TLSKeyFile,
+	}
+	if flags.CaptureMalformed {
+		args = append(args, "-capture-malformed")
+	}
+	return []processInfo{
+		{
+			name: "reference server",
+			start: runInProcess(args, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
+				return referenceserver.RunInReferenceMode(ctx, args, inReader, outWriter, errWriter, trace)
+			}),
+			isReferenceImpl: true,
+		},
+		{
+			name: "reference server (grpc)",
+			start: runInProcess([]string{
+				"grpc-reference-server",
+				"-port", strconv.FormatUint(uint64(flags.ServerPort), 10),
+				"-bind", flags.ServerBind,
+			}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
+				return grpcserver.RunWithTrace(ctx, args, inReader, outWriter, errWriter, trace)
+			}),
+			isGrpcImpl: true,
+		},
+	}
+}
+
+// runPlan describes the test cases to run and the server instances
+// that are needed to run them.
+type runPlan struct {
+	testCaseLib       *testCaseLibrary
+	svrInstances      []serverInstance
+	filter            *testCaseFilter
+	filteredTestCount int
+	serverCreds       *conformancev1.TLSCreds
+	clientCreds       *conformancev1.TLSCreds
+}
+
+// planRun computes the test cases to run, validates the given patterns
+// against them, and creates any credentials needed for TLS.
+func planRun( //nolint:gocyclo
+	configCases []configCase,
+	knownFailing *testTrie,
+	knownFlaky *testTrie,
+	run *testTrie,
+	skip *testTrie,
+	allSuites map[string]*conformancev1.TestSuite,
+	logPrinter internal.Printer,
+	flags *Flags,
+	useReferenceClient bool,
+	useReferenceServer bool,
+) (*runPlan, error) {
+	mode := conformancev1.TestSuite_TEST_MODE_UNSPECIFIED
 	switch {
 	case useReferenceServer && !useReferenceClient:
 		// Client mode uses a reference server to test a given client
@@ -215,12 +618,7 @@ func run( //nolint:gocyclo
 	allServerConfigs, filteredServerConfigs := map[serverConfig]struct{}{}, map[serverConfig]struct{}{}
 	for _, testCase := range allPermutations {
 		svrConfig := serverConfig{
-			serverInstance: serverInstance{
-				protocol:          testCase.Request.Protocol,
-				httpVersion:       testCase.Request.HttpVersion,
-				useTLS:            len(testCase.Request.ServerTlsCert) > 0,
-				useTLSClientCerts: testCase.Request.ClientTlsCreds != nil,
-			},
+			serverInstance: serverInstanceForCase(testCase),
 			isGrpcClient: strings.Contains(testCase.Request.TestName, grpcImplMarker) ||
 				strings.Contains(testCase.Request.TestName, grpcClientImplMarker),
 			isGrpcServer: strings.Contains(testCase.Request.TestName, grpcImplMarker) ||
@@ -268,168 +666,14 @@ func run( //nolint:gocyclo
 		}
 	}
 
-	var trace *tracer.Tracer
-	if flags.HTTPTrace {
-		trace = &tracer.Tracer{}
-	}
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	var clients []processInfo
-	if useReferenceClient {
-		clients = []processInfo{
-			{
-				name: "reference client",
-				start: runInProcess([]string{
-					"reference-client",
-					"-p", strconv.Itoa(int(flags.Parallelism)),
-				}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
-					return referenceclient.RunInReferenceMode(ctx, args, inReader, outWriter, errWriter, trace)
-				}),
-				isReferenceImpl: true,
-			},
-			{
-				name: "reference client (grpc)",
-				start: runInProcess([]string{
-					"grpc-reference-client",
-					"-p", strconv.Itoa(int(flags.Parallelism)),
-				}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
-					return grpcclient.RunWithTrace(ctx, args, inReader, outWriter, errWriter, trace)
-				}),
-				isGrpcImpl: true,
-			},
-		}
-	} else {
-		clients = []processInfo{
-			{
-				start: runCommand(flags.ClientCommand),
-			},
-		}
-	}
-
-	results := newResults(filteredTestCount, knownFailing, knownFlaky, trace)
-
-	for _, clientInfo := range clients {
-		clientProcess, err := runClient(ctx, clientInfo.start)
-		if err != nil {
-			return nil, fmt.Errorf("error starting client: %w", err)
-		}
-		defer clientProcess.stop()
-
-		var servers []processInfo
-		if useReferenceServer {
-			servers = []processInfo{
-				{
-					name: "reference server",
-					start: runInProcess([]string{
-						"reference-server",
-						"-port", strconv.FormatUint(uint64(flags.ServerPort), 10),
-						"-bind", flags.ServerBind,
-						"-cert", flags.TLSCertFile,
-						"-key", flags.TLSKeyFile,
-					}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
-						return referenceserver.RunInReferenceMode(ctx, args, inReader, outWriter, errWriter, trace)
-					}),
-					isReferenceImpl: true,
-				},
-				{
-					name: "reference server (grpc)",
-					start: runInProcess([]string{
-						"grpc-reference-server",
-						"-port", strconv.FormatUint(uint64(flags.ServerPort), 10),
-						"-bind", flags.ServerBind,
-					}, func(ctx context.Context, args []string, inReader io.ReadCloser, outWriter, errWriter io.WriteCloser) error {
-						return grpcserver.RunWithTrace(ctx, args, inReader, outWriter, errWriter, trace)
-					}),
-					isGrpcImpl: true,
-				},
-			}
-		} else {
-			servers = []processInfo{
-				{
-					start: runCommand(flags.ServerCommand),
-				},
-			}
-		}
-
-		err = func() error {
-			var wg sync.WaitGroup
-			defer wg.Wait()
-			sema := semaphore.NewWeighted(int64(flags.MaxServers))
-
-			for _, serverInfo := range servers {
-				for _, svrInstance := range svrInstances {
-					testCases := testCaseLib.casesByServer[svrInstance]
-					testCases = testCaseLib.filterGRPCImplTestCases(testCases, clientInfo.isGrpcImpl, serverInfo.isGrpcImpl)
-					testCases = filter.apply(testCases)
-					if len(testCases) == 0 {
-						continue
-					}
-
-					if err := sema.Acquire(ctx, 1); err != nil {
-						return err
-					}
-
-					// Double-check that client is still running before spawning a server process.
-					if !clientProcess.isRunning() {
-						err := clientProcess.waitForResponses()
-						if err == nil {
-							err = errors.New("client process unexpectedly stopped")
-						}
-						return err
-					}
-
-					if flags.Verbose {
-						var with string
-						switch {
-						case clientInfo.name != "" && serverInfo.name != "":
-							with = clientInfo.name + " and " + serverInfo.name
-						case clientInfo.name != "":
-							with = clientInfo.name
-						case serverInfo.name != "":
-							with = serverInfo.name
-						}
-						logTestCaseInfo(with, svrInstance, len(testCases), logPrinter)
-					}
-
-					wg.Add(1)
-					go func(ctx context.Context, clientInfo processInfo, serverInfo processInfo, svrInstance serverInstance) {
-						defer wg.Done()
-						defer sema.Release(1)
-						runTestCasesForServer(
-							ctx,
-							clientInfo.isReferenceImpl,
-							serverInfo.isReferenceImpl,
-							svrInstance,
-							testCases,
-							serverCreds,
-							clientCreds,
-							serverInfo.start,
-							logPrinter,
-							errPrinter,
-							results,
-							clientProcess,
-							trace,
-							flags.VeryVerbose,
-						)
-					}(ctx, clientInfo, serverInfo, svrInstance)
-				}
-			}
-			return nil
-		}()
-
-		if err != nil {
-			return results, err
-		}
-
-		clientProcess.closeSend()
-		if err := clientProcess.waitForResponses(); err != nil {
-			return results, err
-		}
-	}
-
-	return results, nil
+	return &runPlan{
+		testCaseLib:       testCaseLib,
+		svrInstances:      svrInstances,
+		filter:            filter,
+		filteredTestCount: filteredTestCount,
+		serverCreds:       serverCreds,
+		clientCreds:       clientCreds,
+	}, nil
 }
 
 func serverInstancesSlice(testCaseLib *testCaseLibrary, sorted bool) []serverInstance {
@@ -455,18 +699,19 @@ func serverInstancesSlice(testCaseLib *testCaseLibrary, sorted bool) []serverIns
 	return svrInstances
 }
 
-func logTestCaseInfo(with string, svrInstance serverInstance, numCases int, logPrinter internal.Printer) {
-	var tlsMode string
-	switch {
-	case !svrInstance.useTLS:
-		tlsMode = "false"
-	case svrInstance.useTLS && svrInstance.useTLSClientCerts:
-		tlsMode = "true (with client certs)"
-	default:
-		tlsMode = "true"
+// semaphoreWeight returns the weight to acquire from the semaphore that limits
+// the number of concurrent servers. When memory usage is being checked, server
+// instances that check memory acquire the entire semaphore, so that no other
+// test cases can run concurrently and distort the measurements.
+func semaphoreWeight(svrInstance serverInstance, flags *Flags) int64 {
+	if svrInstance.checksMemory && flags.MemorySpikeThreshold > 0 {
+		return int64(flags.MaxServers)
 	}
-	logPrinter.Printf("Running %d tests with %s for server config {%s, %s, TLS:%s}...",
-		numCases, with, svrInstance.httpVersion, svrInstance.protocol, tlsMode)
+	return 1
+}
+
+func logTestCaseInfo(with string, svrInstance serverInstance, numCases int, logPrinter internal.Printer) {
+	logPrinter.Printf("Running %d tests with %s for server config %s...", numCases, with, svrInstance)
 }
 
 func tryMatchPatterns(what string, patterns *testTrie, testCases []*conformancev1.TestCase) (int, error) {
@@ -0,0 +1,240 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+const (
+	handshakeTimeout         = 10 * time.Second
+	maxHandshakeResponseSize = 1024 * 1024 // 1 MB
+
+	// The maximum number of mismatched config cases to describe when
+	// the config file disagrees with what a handshake reports.
+	maxMismatchesToShow = 10
+
+	handshakeEnv = internal.HandshakeEnvVar + "=1"
+)
+
+// handshakeResult is the result of a handshake with an implementation
+// under test.
+type handshakeResult struct {
+	// Either "client" or "server".
+	role string
+	resp *conformancev1.HandshakeResponse
+}
+
+// identity returns a description of the implementation under test,
+// suitable for including in a report. It returns the empty string if
+// the implementation did not identify itself.
+func (h *handshakeResult) identity() string {
+	return strings.TrimSpace(h.resp.ImplementationName + " " + h.resp.ImplementationVersion)
+}
+
+// withHandshake returns a process starter that performs a handshake with
+// each process that the given starter starts. The handshake response is
+// discarded; it is only needed from probeImplementation.
+func withHandshake(start processStarter, role string) processStarter {
+	return func(ctx context.Context, pipeStderr bool) (*process, error) {
+		proc, err := start(ctx, pipeStderr)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := handshake(proc, role); err != nil {
+			proc.abort()
+			return nil, err
+		}
+		return proc, nil
+	}
+}
+
+// probeImplementation starts a process, just to perform a handshake and
+// query its supported features, and then stops it.
+func probeImplementation(ctx context.Context, start processStarter, role string) (*handshakeResult, error) {
+	proc, err := start(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("error starting %s for handshake: %w", role, err)
+	}
+	resp, err := handshake(proc, role)
+	// The process should exit once it sees EOF on stdin.
+	_ = proc.stdin.Close()
+	done := make(chan struct{})
+	proc.whenDone(func(error) { close(done) })
+	select {
+	case <-done:
+	case <-time.After(gracefulShutdownPeriod):
+		proc.abort()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &handshakeResult{role: role, resp: resp}, nil
+}
+
+func handshake(proc *process, role string) (*conformancev1.HandshakeResponse, error) {
+	req := &conformancev1.HandshakeRequest{RunnerVersion: internal.Version}
+	if err := internal.WriteDelimitedMessage(proc.stdin, req); err != nil {
+		return nil, fmt.Errorf("error writing handshake request to %s: %w", role, err)
+	}
+	var resp conformancev1.HandshakeResponse
+	if err := internal.ReadDelimitedMessage(proc.stdout, &resp, role+" handshake", handshakeTimeout, maxHandshakeResponseSize); err != nil {
+		return nil, fmt.Errorf("error reading handshake response from %s: %w", role, err)
+	}
+	return &resp, nil
+}
+
+// performHandshakes performs a handshake with the client and/or server
+// under test, as indicated by the commands in the given flags.
+func performHandshakes(ctx context.Context, flags *Flags) ([]*handshakeResult, error) {
+	var results []*handshakeResult
+	if len(flags.ClientCommand) > 0 {
+		result, err := probeImplementation(ctx, runCommand(flags.ClientCommand, handshakeEnv), "client")
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	if len(flags.ServerCommand) > 0 {
+		result, err := probeImplementation(ctx, runCommand(flags.ServerCommand, handshakeEnv), "server")
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		return nil, errors.New("a handshake requires a client or server command")
+	}
+	return results, nil
+}
+
+// casesFromHandshakes computes the config cases that are supported by all
+// of the given implementations under test, based on the features reported
+// in their handshakes.
+func casesFromHandshakes(handshakes []*handshakeResult) (map[configCase]struct{}, error) {
+	var cases map[configCase]struct{}
+	for _, result := range handshakes {
+		features := result.resp.Features
+		if features == nil {
+			features = &conformancev1.Features{}
+		}
+		resolved, err := resolveFeatures(features)
+		if err != nil {
+			return nil, fmt.Errorf("features reported in %s handshake: %w", result.role, err)
+		}
+		implCases := computeCasesFromFeatures(resolved, nil, nil, nil, nil, nil, nil)
+		if cases == nil {
+			cases = implCases
+			continue
+		}
+		for c := range cases {
+			if _, ok := implCases[c]; !ok {
+				delete(cases, c)
+			}
+		}
+	}
+	if len(cases) == 0 {
+		return nil, errors.New("features reported in handshake resulted in zero cases to test")
+	}
+	return cases, nil
+}
+
+// warnConfigMismatch prints warnings if the config cases computed from the
+// given config file differ from the config cases computed from handshakes.
+func warnConfigMismatch(configFileName string, configCases []configCase, handshakeCases map[configCase]struct{}, handshakes []*handshakeResult, printer internal.Printer) {
+	roles := make([]string, len(handshakes))
+	for i, result := range handshakes {
+		roles[i] = result.role
+	}
+	who := strings.Join(roles, " and ") + " under test"
+
+	var notDeclared []string
+	fromConfig := make(map[configCase]struct{}, len(configCases))
+	for _, c := range configCases {
+		fromConfig[c] = struct{}{}
+		if _, ok := handshakeCases[c]; !ok {
+			notDeclared = append(notDeclared, describeConfigCase(c))
+		}
+	}
+	var notConfigured []string
+	for c := range handshakeCases {
+		if _, ok := fromConfig[c]; !ok {
+			notConfigured = append(notConfigured, describeConfigCase(c))
+		}
+	}
+	if len(notDeclared) > 0 {
+		printer.Printf("WARNING: %s: config includes %d case(s) that the %s did not declare support for in its handshake:",
+			configFileName, len(notDeclared), who)
+		printMismatches(notDeclared, printer)
+	}
+	if len(notConfigured) > 0 {
+		printer.Printf("WARNING: %s: config excludes %d case(s) that the %s declared support for in its handshake:",
+			configFileName, len(notConfigured), who)
+		printMismatches(notConfigured, printer)
+	}
+}
+
+func printMismatches(descriptions []string, printer internal.Printer) {
+	sort.Strings(descriptions)
+	for i, desc := range descriptions {
+		if i == maxMismatchesToShow {
+			printer.Printf("\t... and %d more", len(descriptions)-i)
+			break
+		}
+		printer.Printf("\t%s", desc)
+	}
+}
+
+// describeConfigCase returns a concise, human-readable description of
+// the given config case.
+func describeConfigCase(c configCase) string {
+	parts := []string{
+		c.Version.String(),
+		c.Protocol.String(),
+		c.Codec.String(),
+		c.Compression.String(),
+		c.StreamType.String(),
+	}
+	if c.UseTLS {
+		parts = append(parts, "TLS")
+	}
+	if c.UseTLSClientCerts {
+		parts = append(parts, "TLS client certs")
+	}
+	if c.UseConnectGET {
+		parts = append(parts, "Connect GET")
+	}
+	if c.UseMessageReceiveLimit {
+		parts = append(parts, "message receive limit")
+	}
+	if c.UseReflection {
+		parts = append(parts, "reflection")
+	}
+	if c.UseHealth {
+		parts = append(parts, "health")
+	}
+	if c.UseCORS {
+		parts = append(parts, "CORS")
+	}
+	return strings.Join(parts, ", ")
+}
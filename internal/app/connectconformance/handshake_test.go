@@ -0,0 +1,151 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProbeImplementation(t *testing.T) {
+	t.Parallel()
+
+	expected := &conformancev1.HandshakeResponse{
+		Features: &conformancev1.Features{
+			Protocols: []conformancev1.Protocol{conformancev1.Protocol_PROTOCOL_CONNECT},
+		},
+		ImplementationName:    "test-impl",
+		ImplementationVersion: "v1.2.3",
+	}
+	var sawEOF bool
+	start := runInProcess([]string{"test-impl"}, func(_ context.Context, _ []string, in io.ReadCloser, out, _ io.WriteCloser) error {
+		codec := internal.NewCodec(false)
+		decoder := codec.NewDecoder(in)
+		if err := internal.RespondToHandshake(decoder, codec.NewEncoder(out), expected); err != nil {
+			return err
+		}
+		// Runner should close stdin after the handshake.
+		var req conformancev1.ServerCompatRequest
+		sawEOF = errors.Is(decoder.DecodeNext(&req), io.EOF)
+		return nil
+	})
+	result, err := probeImplementation(context.Background(), start, "server")
+	require.NoError(t, err)
+	assert.True(t, sawEOF)
+	assert.Equal(t, "server", result.role)
+	assert.True(t, proto.Equal(expected, result.resp))
+	assert.Equal(t, "test-impl v1.2.3", result.identity())
+
+	// Implementation that doesn't know about handshakes.
+	start = runInProcess([]string{"test-impl"}, func(_ context.Context, _ []string, _ io.ReadCloser, _, _ io.WriteCloser) error {
+		return nil
+	})
+	_, err = probeImplementation(context.Background(), start, "client")
+	assert.ErrorContains(t, err, "handshake request to client")
+}
+
+func TestCasesFromHandshakes(t *testing.T) {
+	t.Parallel()
+
+	clientFeatures := &conformancev1.Features{
+		Versions:  []conformancev1.HTTPVersion{conformancev1.HTTPVersion_HTTP_VERSION_1},
+		Protocols: []conformancev1.Protocol{conformancev1.Protocol_PROTOCOL_CONNECT, conformancev1.Protocol_PROTOCOL_GRPC_WEB},
+		Codecs:    []conformancev1.Codec{conformancev1.Codec_CODEC_PROTO, conformancev1.Codec_CODEC_JSON},
+	}
+	serverFeatures := &conformancev1.Features{
+		Versions:  []conformancev1.HTTPVersion{conformancev1.HTTPVersion_HTTP_VERSION_1},
+		Protocols: []conformancev1.Protocol{conformancev1.Protocol_PROTOCOL_CONNECT},
+		Codecs:    []conformancev1.Codec{conformancev1.Codec_CODEC_PROTO},
+	}
+	client := &handshakeResult{role: "client", resp: &conformancev1.HandshakeResponse{Features: clientFeatures}}
+	server := &handshakeResult{role: "server", resp: &conformancev1.HandshakeResponse{Features: serverFeatures}}
+
+	clientCases, err := casesFromHandshakes([]*handshakeResult{client})
+	require.NoError(t, err)
+	serverCases, err := casesFromHandshakes([]*handshakeResult{server})
+	require.NoError(t, err)
+	bothCases, err := casesFromHandshakes([]*handshakeResult{client, server})
+	require.NoError(t, err)
+
+	assert.Equal(t, serverCases, bothCases)
+	assert.Greater(t, len(clientCases), len(bothCases))
+	for c := range bothCases {
+		assert.Contains(t, clientCases, c)
+		assert.Equal(t, conformancev1.Protocol_PROTOCOL_CONNECT, c.Protocol)
+		assert.Equal(t, conformancev1.Codec_CODEC_PROTO, c.Codec)
+	}
+
+	// Disjoint features result in no cases.
+	other := &handshakeResult{role: "server", resp: &conformancev1.HandshakeResponse{
+		Features: &conformancev1.Features{
+			Versions:  []conformancev1.HTTPVersion{conformancev1.HTTPVersion_HTTP_VERSION_2},
+			Protocols: []conformancev1.Protocol{conformancev1.Protocol_PROTOCOL_GRPC},
+		},
+	}}
+	_, err = casesFromHandshakes([]*handshakeResult{client, other})
+	assert.ErrorContains(t, err, "zero cases")
+
+	// Invalid features are reported.
+	supportsTLS := false
+	invalid := &handshakeResult{role: "client", resp: &conformancev1.HandshakeResponse{
+		Features: &conformancev1.Features{
+			Versions:    []conformancev1.HTTPVersion{conformancev1.HTTPVersion_HTTP_VERSION_3},
+			SupportsTls: &supportsTLS,
+		},
+	}}
+	_, err = casesFromHandshakes([]*handshakeResult{invalid})
+	assert.ErrorContains(t, err, "features reported in client handshake")
+}
+
+func TestWarnConfigMismatch(t *testing.T) {
+	t.Parallel()
+
+	handshakes := []*handshakeResult{{role: "client", resp: &conformancev1.HandshakeResponse{}}}
+	handshakeCases, err := casesFromHandshakes(handshakes)
+	require.NoError(t, err)
+	configCases := make([]configCase, 0, len(handshakeCases))
+	for c := range handshakeCases {
+		configCases = append(configCases, c)
+	}
+
+	// No differences, no warnings.
+	printer := &internal.SimplePrinter{}
+	warnConfigMismatch("config.yaml", configCases, handshakeCases, handshakes, printer)
+	assert.Empty(t, printer.Messages)
+
+	extra := configCase{
+		Version:     conformancev1.HTTPVersion_HTTP_VERSION_3,
+		Protocol:    conformancev1.Protocol_PROTOCOL_CONNECT,
+		Codec:       conformancev1.Codec_CODEC_PROTO,
+		Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
+		StreamType:  conformancev1.StreamType_STREAM_TYPE_UNARY,
+		UseTLS:      true,
+	}
+	printer = &internal.SimplePrinter{}
+	warnConfigMismatch("config.yaml", append(configCases[1:], extra), handshakeCases, handshakes, printer)
+	require.Len(t, printer.Messages, 4)
+	assert.Equal(t, "WARNING: config.yaml: config includes 1 case(s) that the client under test did not declare support for in its handshake:\n", printer.Messages[0])
+	assert.Equal(t, "\tHTTP_VERSION_3, PROTOCOL_CONNECT, CODEC_PROTO, COMPRESSION_IDENTITY, STREAM_TYPE_UNARY, TLS\n", printer.Messages[1])
+	assert.Equal(t, "WARNING: config.yaml: config excludes 1 case(s) that the client under test declared support for in its handshake:\n", printer.Messages[2])
+	assert.Equal(t, "\t"+describeConfigCase(configCases[0])+"\n", printer.Messages[3])
+}
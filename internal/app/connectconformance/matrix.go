@@ -0,0 +1,261 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"connectrpc.com/conformance/internal"
+	"golang.org/x/sync/semaphore"
+)
+
+// NamedCommand is the command-line for a client or server under test, along
+// with a name that is used to identify it in the results.
+type NamedCommand struct {
+	Name    string
+	Command []string
+}
+
+// namedProcesses returns process info for running each of the given commands.
+func namedProcesses(commands []NamedCommand) []processInfo {
+	processes := make([]processInfo, len(commands))
+	for i, command := range commands {
+		processes[i] = processInfo{
+			name:  command.Name,
+			start: runCommand(command.Command),
+		}
+	}
+	return processes
+}
+
+// implPair identifies a pairing of a client and a server in a matrix run.
+type implPair struct {
+	client, server string
+}
+
+// matrixResults are the results of running test cases for every pairing of
+// multiple clients and multiple servers.
+type matrixResults struct {
+	clientNames []string
+	serverNames []string
+	results     map[implPair]*testResults
+	// errors that prevented some or all of the test cases for a pair from running
+	errors map[implPair]error
+}
+
+func newMatrixResults(clients, servers []processInfo, totalTestCount int, knownFailing, knownFlaky *testTrie) *matrixResults {
+	matrix := &matrixResults{
+		results: make(map[implPair]*testResults, len(clients)*len(servers)),
+		errors:  map[implPair]error{},
+	}
+	for _, client := range clients {
+		matrix.clientNames = append(matrix.clientNames, client.name)
+	}
+	for _, server := range servers {
+		matrix.serverNames = append(matrix.serverNames, server.name)
+		for _, client := range clients {
+			pair := implPair{client: client.name, server: server.name}
+			matrix.results[pair] = newResults(totalTestCount, knownFailing, knownFlaky, nil)
+		}
+	}
+	return matrix
+}
+
+// report prints the results for each pair, followed by a grid that summarizes
+// how many test cases passed for each pair. It returns true if all test cases
+// for all pairs passed (or failed as expected).
+func (m *matrixResults) report(printer internal.Printer) bool {
+	counts := make(map[implPair]resultCounts, len(m.results))
+	success := true
+	for _, server := range m.serverNames {
+		for _, client := range m.clientNames {
+			pair := implPair{client: client, server: server}
+			printer.Printf("---- client %s / server %s ----", client, server)
+			pairCounts := m.results[pair].printOutcomes(printer)
+			if err := m.errors[pair]; err != nil {
+				printer.Printf("ERROR: %v", err)
+				success = false
+			}
+			if pairCounts.failed > 0 || pairCounts.couldNotRun > 0 {
+				success = false
+			}
+			counts[pair] = pairCounts
+		}
+	}
+
+	var buf strings.Builder
+	tabs := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tabs, "client \\ server\t%s\n", strings.Join(m.serverNames, "\t"))
+	for _, client := range m.clientNames {
+		cells := make([]string, 0, len(m.serverNames))
+		for _, server := range m.serverNames {
+			pairCounts := counts[implPair{client: client, server: server}]
+			cell := fmt.Sprintf("%d/%d", pairCounts.succeeded+pairCounts.expectedFailures, pairCounts.total+pairCounts.couldNotRun)
+			if m.errors[implPair{client: client, server: server}] != nil {
+				cell += " (error)"
+			}
+			cells = append(cells, cell)
+		}
+		_, _ = fmt.Fprintf(tabs, "%s\t%s\n", client, strings.Join(cells, "\t"))
+	}
+	_ = tabs.Flush()
+	printer.Printf("\nPassed test cases by client and server:\n%s", strings.TrimSuffix(buf.String(), "\n"))
+	return success
+}
+
+// runMatrix runs the planned test cases for every pairing of the given clients
+// and servers. A separate client process is started for each pair, but each
+// server process is shared by all clients that need the same server instance.
+//
+// Errors for a particular pair, such as a client process exiting prematurely,
+// are recorded in the returned results and do not prevent other pairs from
+// running.
+func runMatrix(
+	plan *runPlan,
+	clients []processInfo,
+	servers []processInfo,
+	knownFailing *testTrie,
+	knownFlaky *testTrie,
+	logPrinter internal.Printer,
+	errPrinter internal.Printer,
+	flags *Flags,
+) (_ *matrixResults, err error) {
+	matrix := newMatrixResults(clients, servers, plan.filteredTestCount, knownFailing, knownFlaky)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timer := startRunTimer(flags.Timeout, func(err error) {
+		errPrinter.Printf("ERROR: %v; stopping all processes", err)
+		for _, server := range matrix.serverNames {
+			for _, client := range matrix.clientNames {
+				inFlight := matrix.results[implPair{client: client, server: server}].timeOut(err)
+				printInFlight(errPrinter, fmt.Sprintf("client %s / server %s, ", client, server), inFlight)
+			}
+		}
+		cancel()
+	})
+	defer func() {
+		timer.stop()
+		err = timer.check(err)
+	}()
+
+	for _, serverInfo := range servers {
+		err := func() error {
+			clientProcesses := make([]clientRunner, len(clients))
+			for i, clientInfo := range clients {
+				pair := implPair{client: clientInfo.name, server: serverInfo.name}
+				clientProcess, err := runClient(ctx, clientInfo.start)
+				if err != nil {
+					matrix.errors[pair] = fmt.Errorf("error starting client: %w", err)
+					continue
+				}
+				defer clientProcess.stop()
+				clientProcesses[i] = clientProcess
+			}
+
+			var wg sync.WaitGroup
+			defer wg.Wait()
+			sema := semaphore.NewWeighted(int64(flags.MaxServers))
+
+			for _, svrInstance := range plan.svrInstances {
+				var targets []serverClient
+				var numCases int
+				for i, clientInfo := range clients {
+					clientProcess := clientProcesses[i]
+					if clientProcess == nil || !clientProcess.isRunning() {
+						// Remaining test cases for this pair will be reported as
+						// not run. The process's error is recorded below, after
+						// all servers are done.
+						continue
+					}
+					testCases := plan.testCaseLib.casesByServer[svrInstance]
+					testCases = plan.testCaseLib.filterGRPCImplTestCases(testCases, clientInfo.isGrpcImpl, serverInfo.isGrpcImpl)
+					testCases = plan.filter.apply(testCases)
+					if len(testCases) == 0 {
+						continue
+					}
+					numCases += len(testCases)
+					targets = append(targets, serverClient{
+						client:            clientProcess,
+						isReferenceClient: clientInfo.isReferenceImpl,
+						testCases:         testCases,
+						results:           matrix.results[implPair{client: clientInfo.name, server: serverInfo.name}],
+					})
+				}
+				if len(targets) == 0 {
+					continue
+				}
+
+				weight := semaphoreWeight(svrInstance, flags)
+				if err := sema.Acquire(ctx, weight); err != nil {
+					return err
+				}
+
+				if flags.Verbose {
+					with := serverInfo.name + " and " + strconv.Itoa(len(targets)) + " client(s)"
+					logTestCaseInfo(with, svrInstance, numCases, logPrinter)
+				}
+
+				wg.Add(1)
+				go func(serverInfo processInfo, svrInstance serverInstance, targets []serverClient) {
+					defer wg.Done()
+					defer sema.Release(weight)
+					runTestCasesForSharedServer(
+						ctx,
+						serverInfo.isReferenceImpl,
+						svrInstance,
+						plan.serverCreds,
+						plan.clientCreds,
+						serverInfo.start,
+						logPrinter,
+						errPrinter,
+						nil,
+						flags.VeryVerbose,
+						flags.MemorySpikeThreshold,
+						targets,
+					)
+				}(serverInfo, svrInstance, targets)
+			}
+			wg.Wait()
+
+			for i, clientProcess := range clientProcesses {
+				if clientProcess == nil {
+					continue
+				}
+				pair := implPair{client: clients[i].name, server: serverInfo.name}
+				wasRunning := clientProcess.isRunning()
+				clientProcess.closeSend()
+				err := clientProcess.waitForResponses()
+				if err == nil && !wasRunning {
+					err = errors.New("client process unexpectedly stopped")
+				}
+				if err != nil {
+					matrix.errors[pair] = err
+				}
+			}
+			return nil
+		}()
+		if err != nil {
+			return matrix, err
+		}
+	}
+	return matrix, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/app/connectconformance/testsuites"
+	"connectrpc.com/conformance/internal/app/referenceclient"
+	"connectrpc.com/conformance/internal/app/referenceserver"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunMatrix(t *testing.T) {
+	t.Parallel()
+
+	testSuiteData, err := testsuites.LoadTestSuites()
+	require.NoError(t, err)
+	allSuites, err := parseTestSuites(testSuiteData)
+	require.NoError(t, err)
+	configCases := []configCase{
+		{
+			Version:     conformancev1.HTTPVersion_HTTP_VERSION_1,
+			Protocol:    conformancev1.Protocol_PROTOCOL_CONNECT,
+			Codec:       conformancev1.Codec_CODEC_JSON,
+			Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
+			StreamType:  conformancev1.StreamType_STREAM_TYPE_UNARY,
+		},
+		{
+			Version:     conformancev1.HTTPVersion_HTTP_VERSION_2,
+			Protocol:    conformancev1.Protocol_PROTOCOL_GRPC,
+			Codec:       conformancev1.Codec_CODEC_PROTO,
+			Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
+			StreamType:  conformancev1.StreamType_STREAM_TYPE_UNARY,
+		},
+	}
+	logger := &testPrinter{t}
+	flags := &Flags{Verbose: true, MaxServers: 2}
+	plan, err := planRun(configCases, &testTrie{}, &testTrie{}, nil, nil, allSuites, logger, flags, false, false)
+	require.NoError(t, err)
+	require.Positive(t, plan.filteredTestCount)
+
+	referenceClient := func(name string) processInfo {
+		return processInfo{
+			name:  name,
+			start: runInProcess([]string{"reference-client"}, referenceclient.Run),
+		}
+	}
+	referenceServer := func(name string) processInfo {
+		return processInfo{
+			name:  name,
+			start: runInProcess([]string{"reference-server"}, referenceserver.Run),
+		}
+	}
+	exitingClient := processInfo{
+		name: "exits",
+		start: runInProcess([]string{"exiting-client"}, func(context.Context, []string, io.ReadCloser, io.WriteCloser, io.WriteCloser) error {
+			return errors.New("oops")
+		}),
+	}
+
+	t.Run("all pass", func(t *testing.T) {
+		t.Parallel()
+		clients := []processInfo{referenceClient("client-a"), referenceClient("client-b")}
+		servers := []processInfo{referenceServer("server-a"), referenceServer("server-b")}
+		matrix, err := runMatrix(plan, clients, servers, &testTrie{}, &testTrie{}, logger, logger, flags)
+		require.NoError(t, err)
+		require.Len(t, matrix.results, 4)
+		for pair, results := range matrix.results {
+			assert.Len(t, results.outcomes, plan.filteredTestCount, "%s / %s", pair.client, pair.server)
+		}
+		assert.Empty(t, matrix.errors)
+		assert.True(t, matrix.report(logger))
+	})
+	t.Run("client exits", func(t *testing.T) {
+		t.Parallel()
+		clients := []processInfo{referenceClient("client-a"), exitingClient}
+		servers := []processInfo{referenceServer("server-a")}
+		matrix, err := runMatrix(plan, clients, servers, &testTrie{}, &testTrie{}, logger, logger, flags)
+		require.NoError(t, err)
+		good := matrix.results[implPair{client: "client-a", server: "server-a"}]
+		assert.Len(t, good.outcomes, plan.filteredTestCount)
+		for name, outcome := range good.outcomes {
+			assert.NoError(t, outcome.actualFailure, name)
+		}
+		assert.ErrorContains(t, matrix.errors[implPair{client: "exits", server: "server-a"}], "oops")
+		assert.False(t, matrix.report(logger))
+	})
+}
+
+func TestMatrixResults_Report(t *testing.T) {
+	t.Parallel()
+	clients := []processInfo{{name: "go"}, {name: "node"}}
+	servers := []processInfo{{name: "go"}, {name: "rust"}}
+	matrix := newMatrixResults(clients, servers, 2, &testTrie{}, &testTrie{})
+	for pair, results := range matrix.results {
+		results.setOutcome("foo/bar/1", false, nil)
+		if pair.client == "node" && pair.server == "rust" {
+			results.setOutcome("foo/bar/2", false, errors.New("fail"))
+		} else {
+			results.setOutcome("foo/bar/2", false, nil)
+		}
+	}
+	matrix.errors[implPair{client: "go", server: "rust"}] = errors.New("client process unexpectedly stopped")
+
+	printer := &internal.SimplePrinter{}
+	assert.False(t, matrix.report(printer))
+	output := strings.Join(printer.Messages, "")
+	assert.Contains(t, output, "---- client node / server rust ----\nFAILED: foo/bar/2:\n")
+	assert.Contains(t, output, "---- client go / server rust ----\nERROR: client process unexpectedly stopped\n")
+	assert.True(t, strings.HasSuffix(output,
+		"client \\ server  go   rust\n"+
+			"go               2/2  2/2 (error)\n"+
+			"node             2/2  1/2\n"), output)
+}
@@ -0,0 +1,114 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// memoryMonitor measures the peak memory usage of a process. It is used to
+// detect implementations whose memory usage spikes while handling a test
+// case, such as when decompressing a message all at once instead of
+// enforcing the receive limit while it is decompressed.
+//
+// It relies on the Linux /proc filesystem. The peak value is the process's
+// "high water mark" for resident set size, which can be reset between test
+// cases. Since resetting it affects all measurements of the process, the
+// same monitor must be used for all test cases that measure a process, so
+// that they are measured one at a time. See start and finish.
+type memoryMonitor struct {
+	pid int
+
+	mu       sync.Mutex
+	baseline int64
+}
+
+// newMemoryMonitor returns a monitor for the process with the given ID.
+// It returns nil if the given ID is not valid, which is the case for
+// processes that are actually goroutines in the current process.
+func newMemoryMonitor(pid int) *memoryMonitor {
+	if pid <= 0 {
+		return nil
+	}
+	return &memoryMonitor{pid: pid}
+}
+
+// start starts a measurement. If another measurement is in progress, this
+// waits for it to finish. If start returns nil, finish must be called.
+func (m *memoryMonitor) start() error {
+	m.mu.Lock()
+	if err := m.reset(); err != nil {
+		m.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// finish finishes the measurement started with start, returning the number
+// of bytes by which the process's peak memory usage grew.
+func (m *memoryMonitor) finish() (int64, error) {
+	defer m.mu.Unlock()
+	return m.growth()
+}
+
+// reset resets the process's peak memory usage and records its current
+// memory usage as the baseline.
+func (m *memoryMonitor) reset() error {
+	// Writing "5" resets the peak resident set size to the current size.
+	err := os.WriteFile(fmt.Sprintf("/proc/%d/clear_refs", m.pid), []byte("5"), 0)
+	if err != nil {
+		return err
+	}
+	m.baseline, err = m.readStatus("VmRSS")
+	return err
+}
+
+// growth returns the number of bytes by which the process's peak memory
+// usage exceeds the baseline recorded in the last call to reset.
+func (m *memoryMonitor) growth() (int64, error) {
+	peak, err := m.readStatus("VmHWM")
+	if err != nil {
+		return 0, err
+	}
+	return peak - m.baseline, nil
+}
+
+func (m *memoryMonitor) readStatus(key string) (int64, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", m.pid))
+	if err != nil {
+		return 0, err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		name, val, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || name != key {
+			continue
+		}
+		// Values are in the form "1234 kB".
+		val = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(val), "kB"))
+		kilobytes, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("could not parse %s from process status: %w", key, err)
+		}
+		return kilobytes * 1024, nil
+	}
+	return 0, fmt.Errorf("process status did not include %s", key)
+}
@@ -0,0 +1,64 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryMonitor(t *testing.T) {
+	t.Parallel()
+	assert.Nil(t, newMemoryMonitor(0))
+	if runtime.GOOS != "linux" {
+		t.Skip("memory monitoring is only supported on Linux")
+	}
+
+	monitor := newMemoryMonitor(os.Getpid())
+	require.NotNil(t, monitor)
+	require.NoError(t, monitor.reset())
+	// Allocate and touch 64 MB, so it is resident.
+	data := make([]byte, 64<<20)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	growth, err := monitor.growth()
+	require.NoError(t, err)
+	runtime.KeepAlive(data)
+	assert.GreaterOrEqual(t, growth, int64(32<<20))
+
+	// Concurrent measurements happen one at a time.
+	require.NoError(t, monitor.start())
+	started := make(chan struct{})
+	go func() {
+		defer close(started)
+		if err := monitor.start(); err == nil {
+			_, _ = monitor.finish()
+		}
+	}()
+	select {
+	case <-started:
+		t.Fatal("second measurement should not start until first is finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+	_, err = monitor.finish()
+	require.NoError(t, err)
+	<-started
+}
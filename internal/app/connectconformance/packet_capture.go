@@ -0,0 +1,118 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/tracer"
+)
+
+// captureFiles are the files to which the bytes of traced connections are
+// written, along with the TLS secrets for those connections.
+type captureFiles struct {
+	pcapFile *os.File
+	capture  *tracer.PacketCapture
+	keyLog   *keyLogFile
+}
+
+// openCaptureFiles creates the given file, for a packet capture in pcapng
+// format. The TLS secrets are written to a separate file in the same
+// directory, with the same base name but a ".keylog" extension. That file
+// is only created if any TLS connections are made.
+func openCaptureFiles(pcapFileName string) (*captureFiles, error) {
+	file, err := os.Create(pcapFileName)
+	if err != nil {
+		return nil, internal.EnsureFileName(err, pcapFileName)
+	}
+	capture, err := tracer.NewPacketCapture(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, internal.EnsureFileName(err, pcapFileName)
+	}
+	return &captureFiles{
+		pcapFile: file,
+		capture:  capture,
+		keyLog:   &keyLogFile{name: keyLogFileName(pcapFileName)},
+	}, nil
+}
+
+// apply configures the given tracer to write to these files.
+func (f *captureFiles) apply(trace *tracer.Tracer) {
+	trace.Capture = f.capture
+	trace.KeyLog = f.keyLog
+}
+
+// close flushes any buffered data and closes the files.
+func (f *captureFiles) close() error {
+	err := f.capture.Flush()
+	if closeErr := f.pcapFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		err = internal.EnsureFileName(err, f.pcapFile.Name())
+	}
+	if keyLogErr := f.keyLog.close(); err == nil {
+		err = keyLogErr
+	}
+	return err
+}
+
+// keyLogFileName returns the name of the file to which TLS secrets are
+// written for the given pcapng file.
+func keyLogFileName(pcapFileName string) string {
+	return strings.TrimSuffix(pcapFileName, filepath.Ext(pcapFileName)) + ".keylog"
+}
+
+// keyLogFile is an io.Writer that creates the named file on first write.
+// It is safe for concurrent use.
+type keyLogFile struct {
+	name string
+
+	mu   sync.Mutex
+	file *os.File
+	err  error
+}
+
+func (f *keyLogFile) Write(data []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil && f.err == nil {
+		f.file, f.err = os.Create(f.name)
+	}
+	if f.err != nil {
+		return 0, f.err
+	}
+	return f.file.Write(data)
+}
+
+func (f *keyLogFile) close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	err := f.err
+	if f.file != nil {
+		if closeErr := f.file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return internal.EnsureFileName(err, f.name)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/conformance/internal/tracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyLogFileName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "run.keylog", keyLogFileName("run.pcapng"))
+	assert.Equal(t, "out/run.keylog", keyLogFileName("out/run"))
+	assert.Equal(t, "out.d/run.keylog", keyLogFileName("out.d/run.pcap"))
+}
+
+func TestCaptureFiles(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	pcapFileName := filepath.Join(dir, "run.pcapng")
+	keyLogName := filepath.Join(dir, "run.keylog")
+
+	files, err := openCaptureFiles(pcapFileName)
+	require.NoError(t, err)
+	trace := &tracer.Tracer{}
+	files.apply(trace)
+	assert.True(t, trace.TracesHTTP2Conns())
+	// The key log file is only created when written.
+	_, err = os.Stat(keyLogName)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = trace.KeyLog.Write([]byte("CLIENT_RANDOM 00 11\n"))
+	require.NoError(t, err)
+	require.NoError(t, files.close())
+
+	pcapData, err := os.ReadFile(pcapFileName)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x0A, 0x0D, 0x0D, 0x0A}, pcapData[:4])
+	keyLogData, err := os.ReadFile(keyLogName)
+	require.NoError(t, err)
+	assert.Equal(t, "CLIENT_RANDOM 00 11\n", string(keyLogData))
+}
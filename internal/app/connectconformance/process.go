@@ -20,8 +20,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"os/exec"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
@@ -30,6 +32,7 @@ import (
 
 const (
 	gracefulShutdownPeriod = 5 * time.Second
+	clientConnectTimeout   = 2 * time.Minute
 )
 
 // process represents some asynchronous execution unit. It may be in another
@@ -49,6 +52,9 @@ type processController interface {
 	result() error
 	abort()
 	whenDone(func(error))
+	// pid returns the ID of the OS process. If the process is not a
+	// separate OS process, this returns zero.
+	pid() int
 }
 
 type processStarter func(ctx context.Context, pipeStderr bool) (*process, error)
@@ -61,14 +67,18 @@ type processInfo struct {
 }
 
 // runCommand returns a process starter that invokes the given command-line in
-// a separate OS process.
-func runCommand(command []string) processStarter {
+// a separate OS process. If any env values are given, they are added to the
+// environment of the current process when starting the command.
+func runCommand(command []string, env ...string) processStarter {
 	return makeProcess(func(ctx context.Context, stdin io.ReadCloser, stdout, stderr io.WriteCloser) (processController, error) {
 		ctx, cancel := context.WithCancel(ctx)
 		cmd := exec.CommandContext(ctx, command[0], command[1:]...) //nolint:gosec
 		cmd.Stdin = stdin
 		cmd.Stdout = stdout
 		cmd.Stderr = stderr
+		if len(env) > 0 {
+			cmd.Env = append(os.Environ(), env...)
+		}
 		cmd.Cancel = func() error {
 			err := cmd.Process.Signal(syscall.SIGTERM)
 			if err != nil {
@@ -162,6 +172,80 @@ func runInProcess(args []string, impl func(ctx context.Context, args []string, i
 	})
 }
 
+// acceptConnection returns a process starter that, instead of starting a
+// process, waits for a process that is already running to connect to the
+// given listener. Messages that would otherwise be written to the process's
+// stdin and read from its stdout are instead exchanged over the connection.
+//
+// The listener is closed after the first connection is accepted, so the
+// returned starter can only be used once.
+func acceptConnection(listener net.Listener, connectTimeout time.Duration) processStarter {
+	return func(ctx context.Context, _ bool) (*process, error) {
+		type acceptResult struct {
+			conn net.Conn
+			err  error
+		}
+		accepted := make(chan acceptResult, 1)
+		go func() {
+			conn, err := listener.Accept()
+			accepted <- acceptResult{conn: conn, err: err}
+		}()
+		var conn net.Conn
+		var err error
+		select {
+		case result := <-accepted:
+			conn, err = result.conn, result.err
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-time.After(connectTimeout):
+			err = fmt.Errorf("timed out waiting for connection to %s", listener.Addr())
+		}
+		_ = listener.Close()
+		if err != nil {
+			go func() {
+				// If a connection was accepted concurrently, close it.
+				if result := <-accepted; result.conn != nil {
+					_ = result.conn.Close()
+				}
+			}()
+			return nil, err
+		}
+		proc := &connProcess{
+			conn: conn,
+			done: make(chan struct{}),
+		}
+		go func() {
+			// Like a command, the connection is aborted if the context is cancelled.
+			select {
+			case <-ctx.Done():
+				proc.abort()
+			case <-proc.done:
+			}
+		}()
+		return &process{
+			processController: proc,
+			stdin:             connWriter{proc},
+			stdout:            connReader{proc},
+			stderr:            io.NopCloser(bytes.NewReader(nil)), // empty
+		}, nil
+	}
+}
+
+// listen creates a listener for the given address, which is in the form
+// "network:address", like "tcp:127.0.0.1:9876" or "unix:/tmp/client.sock".
+func listen(addr string) (net.Listener, error) {
+	network, address, ok := strings.Cut(addr, ":")
+	if !ok || address == "" {
+		return nil, fmt.Errorf("invalid listen address %q: should be in the form \"network:address\"", addr)
+	}
+	switch network {
+	case "tcp", "tcp4", "tcp6", "unix":
+	default:
+		return nil, fmt.Errorf("invalid listen address %q: network should be tcp, tcp4, tcp6, or unix", addr)
+	}
+	return net.Listen(network, address)
+}
+
 func makeProcess(procFunc func(ctx context.Context, stdin io.ReadCloser, stdout, stderr io.WriteCloser) (processController, error)) processStarter {
 	return func(ctx context.Context, pipeStderr bool) (*process, error) {
 		stdinReader, stdinWriter := io.Pipe()
@@ -238,6 +322,10 @@ func (c *cmdProcess) whenDone(action func(error)) {
 	}()
 }
 
+func (c *cmdProcess) pid() int {
+	return c.cmd.Process.Pid
+}
+
 func (c *cmdProcess) markDone() {
 	c.doneOnce.Do(func() {
 		close(c.done)
@@ -269,3 +357,80 @@ func (l *localProcess) whenDone(action func(error)) {
 		action(l.err)
 	}()
 }
+
+func (l *localProcess) pid() int {
+	return 0
+}
+
+type connProcess struct {
+	conn     net.Conn
+	doneOnce sync.Once
+	done     chan struct{}
+	err      error
+}
+
+func (c *connProcess) result() error {
+	<-c.done
+	return c.err
+}
+
+func (c *connProcess) abort() {
+	_ = c.conn.Close()
+	c.markDone(net.ErrClosed)
+}
+
+func (c *connProcess) whenDone(action func(error)) {
+	go func() {
+		action(c.result())
+	}()
+}
+
+func (c *connProcess) pid() int {
+	return 0
+}
+
+func (c *connProcess) markDone(err error) {
+	c.doneOnce.Do(func() {
+		c.err = err
+		close(c.done)
+	})
+}
+
+// connReader is the "stdout" of a connProcess. The process is
+// considered done when the remote end closes the connection.
+type connReader struct {
+	proc *connProcess
+}
+
+func (r connReader) Read(data []byte) (int, error) {
+	n, err := r.proc.conn.Read(data)
+	if errors.Is(err, io.EOF) {
+		r.proc.markDone(nil)
+	} else if err != nil {
+		r.proc.markDone(err)
+	}
+	return n, err
+}
+
+// connWriter is the "stdin" of a connProcess. Closing it only closes
+// the write side of the connection (if supported), so that the remote
+// end sees EOF but can still send its remaining output.
+type connWriter struct {
+	proc *connProcess
+}
+
+func (w connWriter) Write(data []byte) (int, error) {
+	n, err := w.proc.conn.Write(data)
+	if errors.Is(err, net.ErrClosed) || errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) {
+		// Report this the same way as writing to a process whose stdin is closed.
+		err = io.ErrClosedPipe
+	}
+	return n, err
+}
+
+func (w connWriter) Close() error {
+	if closeWriter, ok := w.proc.conn.(interface{ CloseWrite() error }); ok {
+		return closeWriter.CloseWrite()
+	}
+	return w.proc.conn.Close()
+}
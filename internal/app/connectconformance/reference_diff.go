@@ -0,0 +1,119 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/tracer"
+)
+
+// traceWithReferenceServer re-runs the given failed test cases, using the
+// reference clients, against the reference server and returns the traces,
+// keyed by test case name. Each test case is run with the same server
+// instance configuration as when it failed. The resulting traces can then
+// be compared with those of the server under test.
+func traceWithReferenceServer(
+	ctx context.Context,
+	failed map[string]struct{},
+	testCaseLib *testCaseLibrary,
+	svrInstances []serverInstance,
+	serverCreds *conformancev1.TLSCreds,
+	clientCreds *conformancev1.TLSCreds,
+	logPrinter internal.Printer,
+	errPrinter internal.Printer,
+	flags *Flags,
+) (map[string]*tracer.Trace, error) {
+	trace := &tracer.Tracer{RecordFrames: flags.TraceFrames}
+	results := newResults(len(failed), &testTrie{}, &testTrie{}, trace)
+	// The test cases will likely pass against the reference server,
+	// but we need their traces regardless.
+	results.keepAllTraces = true
+	// The gRPC reference server is not used since it does not support
+	// all test cases.
+	referenceServer := referenceServers(flags, trace)[0]
+
+	for _, clientInfo := range referenceClients(flags, trace) {
+		err := func() error {
+			clientProcess, err := runClient(ctx, clientInfo.start)
+			if err != nil {
+				return fmt.Errorf("error starting client: %w", err)
+			}
+			defer clientProcess.stop()
+
+			for _, svrInstance := range svrInstances {
+				testCases := testCaseLib.casesByServer[svrInstance]
+				testCases = testCaseLib.filterGRPCImplTestCases(testCases, clientInfo.isGrpcImpl, false)
+				testCases = onlyNamedTestCases(testCases, failed)
+				if len(testCases) == 0 {
+					continue
+				}
+				if !clientProcess.isRunning() {
+					err := clientProcess.waitForResponses()
+					if err == nil {
+						err = errors.New("client process unexpectedly stopped")
+					}
+					return err
+				}
+				runTestCasesForServer(
+					ctx,
+					clientInfo.isReferenceImpl,
+					referenceServer.isReferenceImpl,
+					svrInstance,
+					testCases,
+					serverCreds,
+					clientCreds,
+					referenceServer.start,
+					logPrinter,
+					errPrinter,
+					results,
+					clientProcess,
+					trace,
+					false,
+					0,
+				)
+			}
+			clientProcess.closeSend()
+			return clientProcess.waitForResponses()
+		}()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results.settle()
+	traces := make(map[string]*tracer.Trace, len(failed))
+	for _, name := range results.traces.Names() {
+		if trace := results.traces.Get(name); trace != nil {
+			traces[name] = trace
+		}
+	}
+	return traces, nil
+}
+
+// onlyNamedTestCases returns the test cases whose names are in the given set.
+func onlyNamedTestCases(testCases []*conformancev1.TestCase, names map[string]struct{}) []*conformancev1.TestCase {
+	var filtered []*conformancev1.TestCase
+	for _, testCase := range testCases {
+		if _, ok := names[testCase.Request.TestName]; ok {
+			filtered = append(filtered, testCase)
+		}
+	}
+	return filtered
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"sort"
 	"strconv"
@@ -49,23 +50,56 @@ type testResults struct {
 	knownFailing   *testTrie
 	knownFlaky     *testTrie
 	tracer         *tracer.Tracer
+	// if true, traces are printed alongside failing test cases
+	printTraces bool
+	// if true, traces are kept for all test cases, not just those
+	// that fail, so they can all be written via writeTraces
+	keepAllTraces bool
+	// if non-nil, the run is recorded in this timeline, including
+	// key events from traces
+	timeline *timeline
+	// if non-nil, a span is recorded for each traced test case,
+	// so they can all be written via writeSpans
+	spans *spanExporter
 
 	traceWaitGroup sync.WaitGroup
 
-	mu             sync.Mutex
-	outcomes       map[string]testOutcome
-	traces         map[string]*tracer.Trace
-	serverSideband map[string]string
+	mu       sync.Mutex
+	outcomes map[string]testOutcome
+	// traces of failed test cases (or of all test cases, if keepAllTraces
+	// is true), which may be stored on disk instead of in memory
+	traces tracer.Store
+	// traces of failed test cases re-run against the reference server,
+	// which are diffed against the traces in the traces store
+	referenceTraces map[string]*tracer.Trace
+	serverSideband  map[string]string
+	// descriptions of implementations under test, like "Client under test: foo v1.0"
+	implementations []string
+	// test cases that have been sent to a client but do not yet have an
+	// outcome, mapped to a description of the server they were sent to
+	inFlight map[string]string
+	// if non-nil, the run has timed out, and any outcomes recorded
+	// afterward are recorded as could-not-run with this error
+	timedOut error
 }
 
-func newResults(totalTestCount int, knownFailing, knownFlaky *testTrie, tracer *tracer.Tracer) *testResults {
+// passingTraceStore is implemented by trace stores, like tracer.DiskStore,
+// that can discard the traces of passing test cases before others.
+type passingTraceStore interface {
+	PutPassing(testName string, trace *tracer.Trace)
+}
+
+func newResults(totalTestCount int, knownFailing, knownFlaky *testTrie, trace *tracer.Tracer) *testResults {
 	return &testResults{
 		totalTestCount: totalTestCount,
 		knownFailing:   knownFailing,
 		knownFlaky:     knownFlaky,
-		tracer:         tracer,
+		tracer:         trace,
+		printTraces:    trace != nil,
 		outcomes:       map[string]testOutcome{},
+		traces:         &tracer.MemoryStore{},
 		serverSideband: map[string]string{},
+		inFlight:       map[string]string{},
 	}
 }
 
@@ -80,6 +114,13 @@ func (r *testResults) setOutcome(testCase string, setupError bool, err error) {
 }
 
 func (r *testResults) setOutcomeLocked(testCase string, setupError bool, err error) {
+	delete(r.inFlight, testCase)
+	if r.timedOut != nil {
+		if _, exists := r.outcomes[testCase]; !exists {
+			r.outcomes[testCase] = testOutcome{actualFailure: &couldNotRunError{r.timedOut}, setupError: true}
+		}
+		return
+	}
 	r.outcomes[testCase] = testOutcome{
 		actualFailure: err,
 		setupError:    setupError,
@@ -104,20 +145,59 @@ func (r *testResults) fetchTrace(testCase string) {
 		if err != nil {
 			return
 		}
+		r.timeline.addTraceEvents(testCase, trace)
+		r.spans.add(testCase, trace)
 
 		r.mu.Lock()
 		defer r.mu.Unlock()
 		outcome := r.outcomes[testCase]
-		if outcome.actualFailure == nil || outcome.setupError || outcome.knownFlaky || outcome.knownFailing {
-			return
-		}
-		if r.traces == nil {
-			r.traces = map[string]*tracer.Trace{}
+		reported := outcome.actualFailure != nil && !outcome.setupError && !outcome.knownFlaky && !outcome.knownFailing
+		if !reported {
+			if !r.keepAllTraces {
+				return
+			}
+			if store, ok := r.traces.(passingTraceStore); ok {
+				// Let the store discard this trace first, if it must,
+				// since it won't be needed for the report.
+				store.PutPassing(testCase, trace)
+				return
+			}
 		}
-		r.traces[testCase] = trace
+		r.traces.Put(testCase, trace)
 	}()
 }
 
+// sending records that the given test case is about to be sent to a client,
+// to be run against the given server. The test case is considered in flight
+// until it has an outcome.
+func (r *testResults) sending(testCase string, server string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.outcomes[testCase]; !exists {
+		r.inFlight[testCase] = server
+	}
+}
+
+// timeOut marks all in-flight test cases as could-not-run, with the given
+// error, and returns their names grouped by the server to which they were
+// sent. Outcomes that are recorded after this is called are also recorded
+// as could-not-run.
+func (r *testResults) timeOut(err error) map[string][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timedOut = err
+	inFlight := map[string][]string{}
+	for testCase, server := range r.inFlight {
+		inFlight[server] = append(inFlight[server], testCase)
+		r.outcomes[testCase] = testOutcome{actualFailure: &couldNotRunError{err}, setupError: true}
+	}
+	r.inFlight = map[string]string{}
+	for _, testCases := range inFlight {
+		sort.Strings(testCases)
+	}
+	return inFlight
+}
+
 // failedToStart marks all the given test cases with the given setup error.
 // This convenience method is to mark many tests in a batch when the relevant
 // server process could not be started.
@@ -162,6 +242,7 @@ func (r *testResults) assert(
 
 	errs = append(errs, checkError(expected.Error, actual.Error, definition.OtherAllowedErrorCodes)...)
 	errs = append(errs, checkPayloads(expected.Payloads, actual.Payloads)...)
+	errs = append(errs, checkResponseMessages(expected.ResponseMessages, actual.ResponseMessages)...)
 
 	if len(expected.Payloads) == 0 &&
 		expected.Error != nil &&
@@ -235,21 +316,50 @@ func (r *testResults) processSidebandInfoLocked() {
 }
 
 func (r *testResults) report(printer internal.Printer) bool {
-	r.traceWaitGroup.Wait() // make sure all traces have been received
+	counts := r.printOutcomes(printer)
+	if counts.failed+counts.expectedFailures > 0 {
+		// Add a blank line to separate summary from messages above
+		printer.Printf("\n")
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	if len(r.serverSideband) > 0 {
-		r.processSidebandInfoLocked()
-		r.serverSideband = map[string]string{}
+	for _, impl := range r.implementations {
+		printer.Printf("%s", impl)
+	}
+	printer.Printf("Total cases: %d\n%d passed, %d failed", counts.total, counts.succeeded, counts.failed)
+	if counts.couldNotRun > 0 {
+		printer.Printf("Another %d could not be run due to client timing out or exiting prematurely.", counts.couldNotRun)
+	}
+	if counts.expectedFailures > 0 {
+		printer.Printf("(Another %d failed as expected due to being known failures/flakes.)", counts.expectedFailures)
 	}
+	return counts.failed == 0
+}
+
+// resultCounts summarizes the outcomes of test cases.
+type resultCounts struct {
+	total            int // number of test cases with outcomes
+	succeeded        int
+	failed           int
+	expectedFailures int
+	couldNotRun      int
+}
+
+// printOutcomes prints details about all failed test cases and returns a
+// summary of all outcomes.
+func (r *testResults) printOutcomes(printer internal.Printer) resultCounts {
+	r.settle()
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	testCaseNames := make([]string, 0, len(r.outcomes))
 	for testCaseName := range r.outcomes {
 		testCaseNames = append(testCaseNames, testCaseName)
 	}
-	var succeeded, failed, expectedFailures int
-	couldNotRun := r.totalTestCount - len(testCaseNames)
-	if couldNotRun < 0 {
-		couldNotRun = 0 // Possible in tests that don't bother configuring actual test count.
+	counts := resultCounts{total: len(r.outcomes)}
+	counts.couldNotRun = r.totalTestCount - len(testCaseNames)
+	if counts.couldNotRun < 0 {
+		counts.couldNotRun = 0 // Possible in tests that don't bother configuring actual test count.
 	}
 	sort.Strings(testCaseNames)
 	for _, name := range testCaseNames {
@@ -262,39 +372,95 @@ func (r *testResults) report(printer internal.Printer) bool {
 		var noRun *couldNotRunError
 		switch {
 		case errors.As(outcome.actualFailure, &noRun):
-			couldNotRun++
+			counts.couldNotRun++
 		case !expectError && outcome.actualFailure != nil:
 			printer.Printf("FAILED: %s:\n%s", name, indent(outcome.actualFailure.Error()))
-			trace := r.traces[name]
-			if trace != nil {
+			var trace *tracer.Trace
+			if r.printTraces || r.referenceTraces[name] != nil {
+				trace = r.traces.Get(name)
+			}
+			if trace != nil && r.printTraces {
 				printer.Printf("---- HTTP Trace ----")
 				trace.Print(printer)
 				printer.Printf("--------------------")
 			}
-			failed++
+			if refTrace := r.referenceTraces[name]; trace != nil && refTrace != nil {
+				printer.Printf("---- Diff vs Reference Server ----")
+				tracer.PrintDiff(refTrace, trace, printer)
+				printer.Printf("----------------------------------")
+			}
+			counts.failed++
 		case expectError && outcome.actualFailure == nil:
 			printer.Printf("FAILED: %s was expected to fail but did not", name)
-			failed++
+			counts.failed++
 		case expectError && outcome.actualFailure != nil:
 			printer.Printf("INFO: %s failed (as expected):\n%s", name, indent(outcome.actualFailure.Error()))
-			expectedFailures++
+			counts.expectedFailures++
 		default:
-			succeeded++
+			counts.succeeded++
 		}
 	}
-	if failed+expectedFailures > 0 {
-		// Add a blank line to separate summary from messages above
-		printer.Printf("\n")
+	return counts
+}
+
+// closeTraces releases any resources used to store traces, such as
+// files on disk. This should be called after traces are no longer needed.
+func (r *testResults) closeTraces() error {
+	if closer, ok := r.traces.(io.Closer); ok {
+		return closer.Close()
 	}
+	return nil
+}
 
-	printer.Printf("Total cases: %d\n%d passed, %d failed", len(r.outcomes), succeeded, failed)
-	if couldNotRun > 0 {
-		printer.Printf("Another %d could not be run due to client timing out or exiting prematurely.", couldNotRun)
+// settle waits for all pending traces and incorporates any out-of-band
+// feedback from a reference server into the outcomes. This should be
+// called after all test cases have completed, before examining outcomes.
+func (r *testResults) settle() {
+	r.traceWaitGroup.Wait() // make sure all traces have been received
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.serverSideband) > 0 {
+		r.processSidebandInfoLocked()
+		r.serverSideband = map[string]string{}
 	}
-	if expectedFailures > 0 {
-		printer.Printf("(Another %d failed as expected due to being known failures/flakes.)", expectedFailures)
+}
+
+// unexpectedFailures returns the names of the test cases that failed
+// unexpectedly. Known failing and flaky test cases are excluded, as are
+// test cases that could not be run due to setup errors.
+func (r *testResults) unexpectedFailures() map[string]struct{} {
+	r.settle()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	failed := map[string]struct{}{}
+	for name, outcome := range r.outcomes {
+		if outcome.actualFailure != nil && !outcome.setupError && !outcome.knownFailing && !outcome.knownFlaky {
+			failed[name] = struct{}{}
+		}
 	}
-	return failed == 0
+	return failed
+}
+
+// setReferenceTraces records the traces of failed test cases that were
+// re-run against the reference server. When failures are reported, they
+// include a diff between these traces and the original ones.
+func (r *testResults) setReferenceTraces(traces map[string]*tracer.Trace) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.referenceTraces = traces
+}
+
+// setImplementation records the identity of the client or server under test,
+// so that it can be included in the report. If identity is empty, this does
+// nothing.
+func (r *testResults) setImplementation(role, identity string) {
+	if identity == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.implementations = append(r.implementations,
+		fmt.Sprintf("%s%s under test: %s", strings.ToUpper(role[:1]), role[1:], identity))
 }
 
 type testOutcome struct {
@@ -476,6 +642,29 @@ func checkPayloads(expected, actual []*conformancev1.ConformancePayload) multiEr
 	return errs
 }
 
+func checkResponseMessages(expected, actual []*anypb.Any) multiErrors {
+	var errs multiErrors
+	if len(actual) != len(expected) {
+		errs = append(errs, fmt.Errorf("expecting %d non-conformance response messages but instead got %d", len(expected), len(actual)))
+	}
+	for i := 0; i < len(actual) && i < len(expected); i++ {
+		actualMsg, err := anypb.UnmarshalNew(actual[i], proto.UnmarshalOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("response #%d: failed to unmarshal actual message: %w", i+1, err))
+			continue
+		}
+		expectedMsg, err := anypb.UnmarshalNew(expected[i], proto.UnmarshalOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("response #%d: failed to unmarshal expected message: %w", i+1, err))
+			continue
+		}
+		if diff := cmp.Diff(expectedMsg, actualMsg, protocmp.Transform()); diff != "" {
+			errs = append(errs, fmt.Errorf("response #%d: did not match expected message: - wanted, + got\n%s", i+1, diff))
+		}
+	}
+	return errs
+}
+
 func checkError(expected, actual *conformancev1.Error, otherCodes []conformancev1.Code) multiErrors {
 	switch {
 	case expected == nil && actual == nil:
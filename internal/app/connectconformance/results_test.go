@@ -17,11 +17,13 @@ package connectconformance
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
 	"connectrpc.com/conformance/internal"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/tracer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -672,6 +674,33 @@ func TestResults_Assert_ReportsAllErrors(t *testing.T) {
 				`actual response trailers missing "xyz"`,
 			},
 		},
+		{
+			name: "response messages match",
+			expected: `{
+				"response_messages": [
+					{"@type": "/google.protobuf.StringValue", "value": "abc"},
+					{"@type": "/google.protobuf.Int32Value", "value": 123}
+				]
+			}`,
+		},
+		{
+			name: "response messages mismatch",
+			expected: `{
+				"response_messages": [
+					{"@type": "/google.protobuf.StringValue", "value": "abc"},
+					{"@type": "/google.protobuf.Int32Value", "value": 123}
+				]
+			}`,
+			actual: `{
+				"response_messages": [
+					{"@type": "/google.protobuf.StringValue", "value": "xyz"}
+				]
+			}`,
+			expectedErrors: []string{
+				`expecting 2 non-conformance response messages but instead got 1`,
+				`response #1: did not match expected message`,
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -736,6 +765,50 @@ func TestResults_ServerSideband(t *testing.T) {
 	require.Equal(t, lines[3], "INFO: known-to-fail/2 failed (as expected):\n\tfail\n")
 }
 
+func TestResults_UnexpectedFailures(t *testing.T) {
+	t.Parallel()
+	results := newResults(0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results.setOutcome("foo/bar/1", false, nil)
+	results.setOutcome("foo/bar/2", true, errors.New("fail"))
+	results.setOutcome("foo/bar/3", false, errors.New("fail"))
+	results.setOutcome("foo/bar/4", false, nil)
+	results.setOutcome("known-to-fail/1", false, errors.New("fail"))
+	results.setOutcome("known-to-flake/1", false, errors.New("flake"))
+	results.recordSideband("foo/bar/4", "something awkward in wire format")
+
+	assert.Equal(t, map[string]struct{}{
+		"foo/bar/3": {},
+		"foo/bar/4": {},
+	}, results.unexpectedFailures())
+}
+
+func TestResults_ReferenceTraces(t *testing.T) {
+	t.Parallel()
+	results := newResults(0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results.printTraces = false
+	results.setOutcome("foo/bar/1", false, errors.New("fail"))
+	results.setOutcome("foo/bar/2", false, errors.New("fail"))
+	results.traces.Put("foo/bar/1", &tracer.Trace{Response: &http.Response{StatusCode: http.StatusInternalServerError}})
+	results.traces.Put("foo/bar/2", &tracer.Trace{Response: &http.Response{StatusCode: http.StatusOK}})
+	results.setReferenceTraces(map[string]*tracer.Trace{
+		"foo/bar/1": {Response: &http.Response{StatusCode: http.StatusOK}},
+	})
+
+	logger := &internal.SimplePrinter{}
+	success := results.report(logger)
+	require.False(t, success)
+	output := strings.Join(logger.Messages, "")
+	// Only the failure with a reference trace has a diff, and the traces
+	// themselves are not printed.
+	assert.Equal(t, 1, strings.Count(output, "---- Diff vs Reference Server ----"))
+	assert.Contains(t, output, "FAILED: foo/bar/1:\n\tfail\n"+
+		"---- Diff vs Reference Server ----\n"+
+		"response status:\n"+
+		"    reference: 200\n"+
+		"    actual:    500\n")
+	assert.NotContains(t, output, "---- HTTP Trace ----")
+}
+
 func TestResults_Report(t *testing.T) {
 	t.Parallel()
 	results := newResults(0, makeKnownFailing(), makeKnownFlaky(), nil)
@@ -785,6 +858,16 @@ func TestResults_Report(t *testing.T) {
 	results.setOutcome("known-to-flake/1", false, errors.New("ruh roh"))
 	success = results.report(logger)
 	require.True(t, success)
+
+	// Identity of implementations under test is included.
+	results = newResults(0, makeKnownFailing(), makeKnownFlaky(), nil)
+	results.setImplementation("client", "my-client v1.0.0")
+	results.setImplementation("server", "")
+	logger = &internal.SimplePrinter{}
+	success = results.report(logger)
+	require.True(t, success)
+	require.Len(t, logger.Messages, 2)
+	assert.Equal(t, "Client under test: my-client v1.0.0\n", logger.Messages[0])
 }
 
 func TestCanonicalizeHeaderVals(t *testing.T) {
@@ -19,6 +19,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
@@ -48,7 +49,10 @@ const (
 // If isReferenceServer is true, then the server's stderr will be examined as well, to
 // record out-of-band feedback about the client requests.
 //
-//nolint:gocyclo
+// If memorySpikeThreshold is non-zero and the server instance checks memory, then the
+// memory usage of the implementation under test is measured for each test case. Test
+// cases during which its peak memory usage grows by more than the threshold, in
+// megabytes, are marked as failed.
 func runTestCasesForServer(
 	ctx context.Context,
 	isReferenceClient bool,
@@ -64,17 +68,75 @@ func runTestCasesForServer(
 	client clientRunner,
 	tracer *tracer.Tracer,
 	logEach bool,
+	memorySpikeThreshold uint,
+) {
+	runTestCasesForSharedServer(
+		ctx,
+		isReferenceServer,
+		meta,
+		serverCreds,
+		clientCreds,
+		startServer,
+		logPrinter,
+		errPrinter,
+		tracer,
+		logEach,
+		memorySpikeThreshold,
+		[]serverClient{{
+			client:            client,
+			isReferenceClient: isReferenceClient,
+			testCases:         testCases,
+			results:           results,
+		}},
+	)
+}
+
+// serverClient is a client, along with the test cases it should run against
+// a server and where to record their results.
+type serverClient struct {
+	client            clientRunner
+	isReferenceClient bool
+	testCases         []*conformancev1.TestCase
+	results           *testResults
+}
+
+// runTestCasesForSharedServer is like runTestCasesForServer, except that the
+// server process is shared by multiple clients. Each client's test cases are
+// sent concurrently, and their outcomes are recorded in that client's results.
+//
+//nolint:gocyclo
+func runTestCasesForSharedServer(
+	ctx context.Context,
+	isReferenceServer bool,
+	meta serverInstance,
+	serverCreds *conformancev1.TLSCreds,
+	clientCreds *conformancev1.TLSCreds,
+	startServer processStarter,
+	logPrinter internal.Printer,
+	errPrinter internal.Printer,
+	tracer *tracer.Tracer,
+	logEach bool,
+	memorySpikeThreshold uint,
+	clients []serverClient,
 ) {
-	testCaseNameSet := make(map[string]struct{}, len(testCases))
-	for _, testCase := range testCases {
-		testCaseNameSet[testCase.Request.TestName] = struct{}{}
+	testCaseNameSet := map[string][]*testResults{}
+	for _, client := range clients {
+		for _, testCase := range client.testCases {
+			name := testCase.Request.TestName
+			testCaseNameSet[name] = append(testCaseNameSet[name], client.results)
+		}
+	}
+	failedToStart := func(err error) {
+		for _, client := range clients {
+			client.results.failedToStart(client.testCases, err)
+		}
 	}
 
 	procCtx, procCancel := context.WithCancel(ctx)
 	defer procCancel()
 	serverProcess, err := startServer(procCtx, isReferenceServer)
 	if err != nil {
-		results.failedToStart(testCases, fmt.Errorf("error starting server: %w", err))
+		failedToStart(fmt.Errorf("error starting server: %w", err))
 		return
 	}
 	defer serverProcess.abort()
@@ -95,10 +157,12 @@ func runTestCasesForServer(
 					var isSideband bool
 					parts := strings.SplitN(str, ": ", 2)
 					if len(parts) == 2 {
-						if _, ok := testCaseNameSet[parts[0]]; ok {
+						if allResults, ok := testCaseNameSet[parts[0]]; ok {
 							// appears to be valid message in the form "test case: error message"
 							isSideband = true
-							results.recordSideband(parts[0], parts[1])
+							for _, results := range allResults {
+								results.recordSideband(parts[0], parts[1])
+							}
 						}
 					}
 					if !isSideband {
@@ -133,11 +197,11 @@ func runTestCasesForServer(
 		MessageReceiveLimit: serverReceiveLimit,
 	})
 	if err != nil {
-		results.failedToStart(testCases, fmt.Errorf("error writing server request: %w", err))
+		failedToStart(fmt.Errorf("error writing server request: %w", err))
 		return
 	}
 	if err := serverProcess.stdin.Close(); err != nil {
-		results.failedToStart(testCases, fmt.Errorf("error writing server request: %w", err))
+		failedToStart(fmt.Errorf("error writing server request: %w", err))
 		return
 	}
 
@@ -145,15 +209,117 @@ func runTestCasesForServer(
 	var resp conformancev1.ServerCompatResponse
 	err = internal.ReadDelimitedMessage(serverProcess.stdout, &resp, "server", serverResponseTimeout, maxServerResponseSize)
 	if err != nil {
-		results.failedToStart(testCases, fmt.Errorf("error reading server response: %w", err))
+		failedToStart(fmt.Errorf("error reading server response: %w", err))
 		return
 	}
 	if meta.useTLS && len(resp.PemCert) == 0 {
-		results.failedToStart(testCases, errors.New("server config uses TLS, but server response did not indicate a certificate"))
+		failedToStart(errors.New("server config uses TLS, but server response did not indicate a certificate"))
+		return
+	}
+
+	// If any test cases inject connection faults, they are routed through a proxy.
+	faultProxy, err := startFaultProxy(clients, &resp)
+	if err != nil {
+		failedToStart(fmt.Errorf("error starting fault-injection proxy: %w", err))
 		return
 	}
+	if faultProxy != nil {
+		defer func() {
+			_ = faultProxy.Close()
+		}()
+	}
+
+	// Send all test cases to the clients.
+	completed := make([]bool, len(clients))
+	var clientsWG sync.WaitGroup
+	var serverMonitor *memoryMonitor
+	if meta.checksMemory && memorySpikeThreshold > 0 && !isReferenceServer {
+		// All clients share the monitor, so that test cases sent by
+		// different clients to this server are measured one at a time.
+		serverMonitor = newMemoryMonitor(serverProcess.pid())
+		if serverMonitor == nil {
+			errPrinter.Printf("WARNING: %s: cannot measure the memory usage of the server under test, "+
+				"which is not a separate process; its memory usage will not be checked", meta)
+		}
+	}
+	for i := range clients {
+		clientsWG.Add(1)
+		monitor := serverMonitor
+		if meta.checksMemory && memorySpikeThreshold > 0 && isReferenceServer {
+			// Measure whichever side is the implementation under test.
+			monitor = newMemoryMonitor(clients[i].client.pid())
+			if monitor == nil {
+				errPrinter.Printf("WARNING: %s: cannot measure the memory usage of the client under test, "+
+					"which is not a separate process; its memory usage will not be checked", meta)
+			}
+		}
+		go func(i int) {
+			defer clientsWG.Done()
+			completed[i] = sendTestCases(procCtx, isReferenceServer, meta, &resp, clientCreds, faultProxy, logPrinter, tracer, logEach, monitor, memorySpikeThreshold, clients[i])
+		}(i)
+	}
+	clientsWG.Wait()
+
+	serverProcess.abort()
+	_ = serverProcess.result() // wait for server process to end
+	if isReferenceServer {
+		<-refServerFinished
+	}
+
+	// If there are any tests without outcomes, mark them now.
+	for i, client := range clients {
+		if completed[i] {
+			client.results.failRemaining(client.testCases, &failedToGetResultError{errNoOutcome})
+		}
+	}
+}
+
+// startFaultProxy starts a proxy, in front of the server described by the
+// given response, if any of the given clients' test cases inject connection
+// faults. If there are no such test cases, it returns nil.
+func startFaultProxy(clients []serverClient, resp *conformancev1.ServerCompatResponse) (*tracer.FaultProxy, error) {
+	for _, client := range clients {
+		for _, testCase := range client.testCases {
+			if testCase.ConnectionFault != nil {
+				host := resp.Host
+				if host == "" {
+					host = internal.DefaultHost
+				}
+				return tracer.NewFaultProxy(net.JoinHostPort(host, strconv.Itoa(int(resp.Port))))
+			}
+		}
+	}
+	return nil, nil //nolint:nilnil // no proxy is needed
+}
 
-	// Send all test cases to the client.
+// sendTestCases sends the given client's test cases, directed at the server
+// described by the given response, and then waits for the results. It returns
+// false if the server process terminated before all test cases could be sent.
+//
+// Test cases that inject connection faults are instead directed at the given
+// fault proxy, which must be non-nil if there are any such test cases.
+//
+// If the server instance drains connections, test cases are sent one at a
+// time, each one after the previous one completes. The same is true if the
+// given memory monitor is non-nil, so that the memory usage measured can be
+// attributed to a single test case. The monitor may be shared with concurrent
+// calls that send test cases to the same server, in which case test cases
+// are measured one at a time across all of them.
+func sendTestCases(
+	procCtx context.Context,
+	isReferenceServer bool,
+	meta serverInstance,
+	resp *conformancev1.ServerCompatResponse,
+	clientCreds *conformancev1.TLSCreds,
+	faultProxy *tracer.FaultProxy,
+	logPrinter internal.Printer,
+	tracer *tracer.Tracer,
+	logEach bool,
+	monitor *memoryMonitor,
+	memorySpikeThreshold uint,
+	target serverClient,
+) bool {
+	testCases, results, client := target.testCases, target.results, target.client
 	var wg sync.WaitGroup
 	for i := range testCases {
 		testCase := testCases[i]
@@ -163,7 +329,7 @@ func runTestCasesForServer(
 			for j := i; j < len(testCases); j++ {
 				results.setOutcome(testCases[j].Request.TestName, true, err)
 			}
-			return
+			return false
 		}
 		req := proto.Clone(testCase.Request).(*conformancev1.ClientCompatRequest) //nolint:errcheck,forcetypeassert
 		req.Host = resp.Host
@@ -173,6 +339,12 @@ func runTestCasesForServer(
 		req.Port = resp.Port
 		req.ServerTlsCert = resp.PemCert
 		req.ClientTlsCreds = clientCreds
+		if testCase.ConnectionFault != nil {
+			faultProxy.Inject(req.TestName, testCase.ConnectionFault)
+			proxyAddr := faultProxy.Addr()
+			req.Host = proxyAddr.IP.String()
+			req.Port = uint32(proxyAddr.Port)
+		}
 
 		// We always include test name in request header.
 		testCaseHeader := &conformancev1.Header{Name: "x-test-case-name", Value: []string{testCase.Request.TestName}}
@@ -200,13 +372,29 @@ func runTestCasesForServer(
 					&conformancev1.Header{Name: "x-expect-client-cert", Value: []string{internal.ClientCertName}},
 				)
 			}
+			if req.FollowUpRpcs > 0 {
+				extraHeaders = append(
+					extraHeaders,
+					&conformancev1.Header{Name: "x-expect-follow-up-rpcs", Value: []string{strconv.Itoa(int(req.FollowUpRpcs))}},
+				)
+			}
 			req.RequestHeaders = append(req.RequestHeaders, extraHeaders...)
 			if req.RawRequest != nil {
 				req.RawRequest.Headers = append(req.RawRequest.Headers, extraHeaders...)
 			}
 		}
 
+		measuringMemory := false
+		if monitor != nil {
+			if err := monitor.start(); err != nil {
+				results.recordSideband(req.TestName, fmt.Sprintf("could not measure memory usage: %v", err))
+			} else {
+				measuringMemory = true
+			}
+		}
+
 		tracer.Init(req.TestName)
+		results.sending(req.TestName, meta.String())
 		wg.Add(1)
 		if logEach {
 			logPrinter.Printf("Sending request for %q...", req.TestName)
@@ -227,13 +415,16 @@ func runTestCasesForServer(
 			default:
 				results.setOutcome(name, false, errors.New("client returned a response with neither an error nor result"))
 			}
-			if isReferenceClient && resp.GetResponse() != nil {
+			if target.isReferenceClient && resp.GetResponse() != nil {
 				for _, msg := range resp.GetResponse().Feedback {
 					results.recordSideband(resp.TestName, msg)
 				}
 			}
 		})
 		if err != nil {
+			if measuringMemory {
+				_, _ = monitor.finish()
+			}
 			wg.Done() // call it explicitly since callback above won't be invoked
 			// client pipe broken: mark remaining tests, including this one, as failed
 			for j := i; j < len(testCases); j++ {
@@ -241,19 +432,28 @@ func runTestCasesForServer(
 			}
 			break
 		}
+		if meta.drainsConnections {
+			// Wait for this case to complete, so the next one won't
+			// be on a connection that is being closed.
+			wg.Wait()
+		}
+		if measuringMemory {
+			wg.Wait()
+			growth, err := monitor.finish()
+			switch {
+			case err != nil:
+				results.recordSideband(req.TestName, fmt.Sprintf("could not measure memory usage: %v", err))
+			case growth > int64(memorySpikeThreshold)<<20:
+				results.recordSideband(req.TestName, fmt.Sprintf(
+					"memory usage grew by %d MB while handling test case, which exceeds threshold of %d MB",
+					growth>>20, memorySpikeThreshold))
+			}
+		}
 	}
 
 	// Wait for all responses.
 	wg.Wait()
-
-	serverProcess.abort()
-	_ = serverProcess.result() // wait for server process to end
-	if isReferenceServer {
-		<-refServerFinished
-	}
-
-	// If there are any tests without outcomes, mark them now.
-	results.failRemaining(testCases, &failedToGetResultError{errNoOutcome})
+	return true
 }
 
 type couldNotRunError struct {
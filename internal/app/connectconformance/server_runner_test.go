@@ -277,6 +277,7 @@ func TestRunTestCasesForServer(t *testing.T) {
 				&client,
 				nil,
 				false,
+				0,
 			)
 
 			if testCase.svrFailsToStart {
@@ -302,6 +303,45 @@ func TestRunTestCasesForServer(t *testing.T) {
 	}
 }
 
+func TestRunTestCasesForServer_NoMemoryMonitor(t *testing.T) {
+	t.Parallel()
+
+	var svrResponseBuf bytes.Buffer
+	err := internal.WriteDelimitedMessage(&svrResponseBuf, &conformancev1.ServerCompatResponse{
+		Host: "127.0.0.1",
+		Port: 12345,
+	})
+	require.NoError(t, err)
+	svrInstance := serverInstance{
+		protocol:     conformancev1.Protocol_PROTOCOL_CONNECT,
+		httpVersion:  conformancev1.HTTPVersion_HTTP_VERSION_1,
+		checksMemory: true,
+	}
+	// The fake server, like an in-process implementation, has no process
+	// ID, so its memory usage can't be measured.
+	var errPrinter internal.SimplePrinter
+	runTestCasesForServer(
+		context.Background(),
+		true,
+		false,
+		svrInstance,
+		nil,
+		nil,
+		nil,
+		newFakeProcess(io.Discard, &svrResponseBuf, strings.NewReader("")),
+		discardPrinter{},
+		&errPrinter,
+		newResults(0, &testTrie{}, &testTrie{}, nil),
+		&fakeClient{},
+		nil,
+		false,
+		16,
+	)
+	require.Len(t, errPrinter.Messages, 1)
+	assert.Contains(t, errPrinter.Messages[0], "WARNING: ")
+	assert.Contains(t, errPrinter.Messages[0], "cannot measure the memory usage of the server under test")
+}
+
 // fakeProcess is a process starter that represents a fictitious process
 // that is runs until the stop method is called.
 type fakeProcess struct {
@@ -383,6 +423,10 @@ func (f *fakeProcess) whenDone(action func(error)) {
 	f.atEndActions = append(f.atEndActions, action)
 }
 
+func (f *fakeProcess) pid() int {
+	return 0
+}
+
 // procWriter delegates to the given writer but will instead
 // immediately return an error if the given process has stopped.
 type procWriter struct {
@@ -490,6 +534,10 @@ func (f *fakeClient) isRunning() bool {
 	return false
 }
 
+func (f *fakeClient) pid() int {
+	return 0
+}
+
 func (f *fakeClient) stop() {
 }
 
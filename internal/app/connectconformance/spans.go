@@ -0,0 +1,368 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/tracer"
+)
+
+const (
+	// Values for the kind of span, from the OTLP protocol.
+	otlpSpanKindServer = 2
+	otlpSpanKindClient = 3
+	// Value for the code in the status of a failed span.
+	otlpStatusCodeError = 2
+)
+
+// spanExporter records a span for each traced RPC, so that they can be
+// written as OpenTelemetry spans, in the OTLP JSON format. Each event in
+// the trace becomes an event in the span.
+//
+// The span continues the W3C trace context in the request's "traceparent"
+// header, if present. For traces recorded by the reference client, this is
+// the trace context that was sent to the server under test, so the span is
+// the parent of any spans the server under test exports for the RPC. For
+// traces recorded by the reference server, the span is identified by the
+// trace context in the response's "traceresponse" header, and it is a child
+// of the span that was sent by the client under test. If there is no trace
+// context, the span is the root of a new trace.
+//
+// A nil *spanExporter is valid and records nothing.
+type spanExporter struct {
+	mu    sync.Mutex
+	spans map[string]*otlpSpan
+	// Whether each span was recorded by the client,
+	// keyed by test case name.
+	clientSpans map[string]bool
+}
+
+func newSpanExporter() *spanExporter {
+	return &spanExporter{spans: map[string]*otlpSpan{}, clientSpans: map[string]bool{}}
+}
+
+// writeSpans writes the spans for all traced test cases to the given file,
+// in the OTLP JSON format. The file is appended to, instead of overwritten,
+// so that other processes can write their spans to the same file.
+func (r *testResults) writeSpans(fileName string) error {
+	r.settle() // make sure all traces have been recorded
+	r.mu.Lock()
+	outcomes := make(map[string]testOutcome, len(r.outcomes))
+	for name, outcome := range r.outcomes {
+		outcomes[name] = outcome
+	}
+	r.mu.Unlock()
+	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return internal.EnsureFileName(err, fileName)
+	}
+	writer := bufio.NewWriter(file)
+	err = r.spans.write(writer, outcomes)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return internal.EnsureFileName(err, fileName)
+	}
+	return nil
+}
+
+// add records a span for the given trace.
+func (e *spanExporter) add(testCase string, trace *tracer.Trace) {
+	if e == nil {
+		return
+	}
+	span := newOTLPSpan(testCase, trace)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans[testCase] = span
+	e.clientSpans[testCase] = trace.Client
+}
+
+// write writes the recorded spans to w, ordered by test case name. Each
+// line is a JSON object that contains the span for a single test case,
+// which is the format used by the OpenTelemetry collector's file exporter.
+// The spans include the outcomes of their test cases as attributes.
+func (e *spanExporter) write(w io.Writer, outcomes map[string]testOutcome) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	names := make([]string, 0, len(e.spans))
+	for name := range e.spans {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	enc := json.NewEncoder(w)
+	for _, name := range names {
+		span := *e.spans[name]
+		span.Attributes = append(span.Attributes[:len(span.Attributes):len(span.Attributes)], outcomeAttributes(outcomes[name])...)
+		serviceName := "connectconformance reference server"
+		if e.clientSpans[name] {
+			serviceName = "connectconformance reference client"
+		}
+		data := otlpTracesData{
+			ResourceSpans: []otlpResourceSpans{{
+				Resource: otlpResource{
+					Attributes: []otlpAttribute{stringAttribute("service.name", serviceName)},
+				},
+				ScopeSpans: []otlpScopeSpans{{
+					Scope: otlpScope{Name: "connectrpc.com/conformance", Version: internal.Version},
+					Spans: []otlpSpan{span},
+				}},
+			}},
+		}
+		if err := enc.Encode(&data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newOTLPSpan(testCase string, trace *tracer.Trace) *otlpSpan {
+	kind := otlpSpanKindServer
+	if trace.Client {
+		kind = otlpSpanKindClient
+	}
+	var requestCtx, responseCtx tracer.TraceContext
+	var hasRequestCtx, hasResponseCtx bool
+	if trace.Request != nil {
+		requestCtx, hasRequestCtx = tracer.TraceContextFromHeaders(trace.Request.Header)
+	}
+	if trace.Response != nil {
+		responseCtx, hasResponseCtx = tracer.ParseTraceparent(trace.Response.Header.Get(tracer.TraceresponseHeader))
+	}
+	var traceCtx tracer.TraceContext
+	var parentSpanID string
+	switch {
+	case trace.Client && hasRequestCtx:
+		// This is the context that the client sent,
+		// which identifies the client's span.
+		traceCtx = requestCtx
+	case !trace.Client && hasResponseCtx:
+		// This is the context that the server returned,
+		// which identifies the server's span.
+		traceCtx = responseCtx
+		if hasRequestCtx && requestCtx.TraceID == responseCtx.TraceID {
+			parentSpanID = hex.EncodeToString(requestCtx.SpanID[:])
+		}
+	case !trace.Client && hasRequestCtx:
+		traceCtx = requestCtx.Child()
+		parentSpanID = hex.EncodeToString(requestCtx.SpanID[:])
+	default:
+		traceCtx = tracer.NewTraceContext()
+	}
+
+	span := &otlpSpan{
+		TraceID:           hex.EncodeToString(traceCtx.TraceID[:]),
+		SpanID:            hex.EncodeToString(traceCtx.SpanID[:]),
+		ParentSpanID:      parentSpanID,
+		Name:              testCase,
+		Kind:              kind,
+		StartTimeUnixNano: unixNanos(trace.Start),
+		EndTimeUnixNano:   unixNanos(trace.Start),
+		Attributes:        []otlpAttribute{stringAttribute("conformance.test_case", testCase)},
+	}
+	if trace.Request != nil {
+		span.Name = strings.TrimPrefix(trace.Request.URL.Path, "/")
+		span.Attributes = append(span.Attributes,
+			stringAttribute("http.request.method", trace.Request.Method),
+			stringAttribute("url.path", trace.Request.URL.Path),
+			stringAttribute("network.protocol.version", protocolVersion(trace.Request.ProtoMajor, trace.Request.ProtoMinor)),
+		)
+	}
+	if trace.Response != nil {
+		span.Attributes = append(span.Attributes, intAttribute("http.response.status_code", int64(trace.Response.StatusCode)))
+	}
+	if trace.Err != nil {
+		span.Status = otlpStatus{Code: otlpStatusCodeError, Message: trace.Err.Error()}
+	}
+	for _, event := range trace.Events {
+		otlpEvent := newOTLPEvent(trace.Start, event)
+		if otlpEvent.TimeUnixNano > span.EndTimeUnixNano {
+			span.EndTimeUnixNano = otlpEvent.TimeUnixNano
+		}
+		span.Events = append(span.Events, otlpEvent)
+	}
+	return span
+}
+
+func newOTLPEvent(start time.Time, event tracer.Event) otlpEvent {
+	var name string
+	var offset time.Duration
+	var attrs []otlpAttribute
+	switch event := event.(type) {
+	case *tracer.RequestStart:
+		name, offset = "request start", event.Offset
+	case *tracer.RequestBodyData:
+		name, offset = "request message", event.Offset
+		attrs = messageAttributes(event.MessageIndex, event.Envelope, event.Len)
+	case *tracer.RequestBodyEnd:
+		name, offset = "request end", event.Offset
+		attrs = errorAttributes(event.Err)
+	case *tracer.RequestCanceled:
+		name, offset = "request canceled", event.Offset
+	case *tracer.ResponseStart:
+		name, offset = "response start", event.Offset
+		attrs = []otlpAttribute{intAttribute("http.response.status_code", int64(event.Response.StatusCode))}
+	case *tracer.ResponseError:
+		name, offset = "response error", event.Offset
+		attrs = errorAttributes(event.Err)
+	case *tracer.ResponseBodyData:
+		name, offset = "response message", event.Offset
+		attrs = messageAttributes(event.MessageIndex, event.Envelope, event.Len)
+	case *tracer.ResponseBodyEndStream:
+		name, offset = "response end stream", event.Offset
+		if event.Error != "" {
+			attrs = []otlpAttribute{stringAttribute("error", event.Error)}
+		}
+	case *tracer.ResponseBodyEnd:
+		name, offset = "response end", event.Offset
+		attrs = errorAttributes(event.Err)
+	}
+	return otlpEvent{
+		TimeUnixNano: unixNanos(start.Add(offset)),
+		Name:         name,
+		Attributes:   attrs,
+	}
+}
+
+func messageAttributes(index int, envelope *tracer.Envelope, length uint64) []otlpAttribute {
+	attrs := []otlpAttribute{
+		intAttribute("message.index", int64(index)),
+		intAttribute("message.bytes", int64(length)),
+	}
+	if envelope != nil {
+		attrs = append(attrs,
+			intAttribute("message.envelope.flags", int64(envelope.Flags)),
+			intAttribute("message.envelope.length", int64(envelope.Len)),
+		)
+	}
+	return attrs
+}
+
+func errorAttributes(err error) []otlpAttribute {
+	if err == nil {
+		return nil
+	}
+	return []otlpAttribute{stringAttribute("error", err.Error())}
+}
+
+func outcomeAttributes(outcome testOutcome) []otlpAttribute {
+	if outcome.actualFailure == nil {
+		return []otlpAttribute{stringAttribute("conformance.outcome", "passed")}
+	}
+	return []otlpAttribute{
+		stringAttribute("conformance.outcome", "failed"),
+		stringAttribute("conformance.failure", outcome.actualFailure.Error()),
+	}
+}
+
+func protocolVersion(major, minor int) string {
+	if major == 1 {
+		return "1.1"
+	}
+	if minor == 0 {
+		return strconv.Itoa(major)
+	}
+	return strconv.Itoa(major) + "." + strconv.Itoa(minor)
+}
+
+func unixNanos(when time.Time) uint64 {
+	return uint64(when.UnixNano())
+}
+
+func stringAttribute(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpValue{StringValue: &value}}
+}
+
+func intAttribute(key string, value int64) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpValue{IntValue: &value}}
+}
+
+// The types below model the OTLP JSON format, which is the JSON form of
+// the TracesData message in the OpenTelemetry protocol. Unlike other JSON
+// forms of Protobuf messages, trace and span IDs are hex-encoded.
+
+type otlpTracesData struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano uint64          `json:"startTimeUnixNano,string"`
+	EndTimeUnixNano   uint64          `json:"endTimeUnixNano,string"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Events            []otlpEvent     `json:"events,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano uint64          `json:"timeUnixNano,string"`
+	Name         string          `json:"name"`
+	Attributes   []otlpAttribute `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	// Like other 64-bit integers in the JSON form of Protobuf
+	// messages, this is encoded as a string.
+	IntValue *int64 `json:"intValue,omitempty,string"`
+}
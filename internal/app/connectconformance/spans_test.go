@@ -0,0 +1,168 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal/tracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpanExporter(t *testing.T) {
+	t.Parallel()
+	traceStart := time.Unix(1000, 0)
+	newRequest := func(traceparent string) *http.Request {
+		req := &http.Request{
+			Method:     http.MethodPost,
+			URL:        &url.URL{Path: "/connectrpc.conformance.v1.ConformanceService/Unary"},
+			ProtoMajor: 2,
+			Header:     http.Header{},
+		}
+		if traceparent != "" {
+			req.Header.Set(tracer.TraceparentHeader, traceparent)
+		}
+		return req
+	}
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	exporter := newSpanExporter()
+	exporter.add("foo/bar/2", &tracer.Trace{
+		Start:    traceStart,
+		Client:   true,
+		Request:  newRequest(traceparent),
+		Response: &http.Response{StatusCode: http.StatusOK},
+		Events: []tracer.Event{
+			&tracer.RequestStart{},
+			&tracer.RequestBodyData{Envelope: &tracer.Envelope{Len: 5}, Len: 5},
+			&tracer.ResponseStart{Response: &http.Response{StatusCode: http.StatusOK}},
+			&tracer.ResponseBodyEnd{},
+		},
+	})
+	exporter.add("foo/bar/1", &tracer.Trace{
+		Start:   traceStart,
+		Request: newRequest(traceparent),
+		Err:     errors.New("oops"),
+		Events:  []tracer.Event{&tracer.RequestStart{}},
+	})
+	exporter.add("foo/bar/3", &tracer.Trace{
+		Start:   traceStart,
+		Request: newRequest(""),
+		Events:  []tracer.Event{&tracer.RequestStart{}},
+	})
+	exporter.add("foo/bar/4", &tracer.Trace{
+		Start:   traceStart,
+		Request: newRequest(traceparent),
+		Response: &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Traceresponse": []string{"00-4bf92f3577b34da6a3ce929d0e0e4736-b7ad6b7169203331-01"},
+			},
+		},
+		Events: []tracer.Event{&tracer.RequestStart{}},
+	})
+	var buf bytes.Buffer
+	require.NoError(t, exporter.write(&buf, map[string]testOutcome{
+		"foo/bar/1": {actualFailure: errors.New("wrong status")},
+		"foo/bar/2": {},
+		"foo/bar/3": {},
+		"foo/bar/4": {},
+	}))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 4)
+	spans := make([]otlpSpan, len(lines))
+	services := make([]string, len(lines))
+	for i, line := range lines {
+		var data otlpTracesData
+		require.NoError(t, json.Unmarshal([]byte(line), &data))
+		require.Len(t, data.ResourceSpans, 1)
+		require.Len(t, data.ResourceSpans[0].ScopeSpans, 1)
+		require.Len(t, data.ResourceSpans[0].ScopeSpans[0].Spans, 1)
+		services[i] = *data.ResourceSpans[0].Resource.Attributes[0].Value.StringValue
+		spans[i] = data.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	}
+	attributes := func(span otlpSpan) map[string]any {
+		attrs := map[string]any{}
+		for _, attr := range span.Attributes {
+			if attr.Value.StringValue != nil {
+				attrs[attr.Key] = *attr.Value.StringValue
+			} else {
+				attrs[attr.Key] = *attr.Value.IntValue
+			}
+		}
+		return attrs
+	}
+
+	// Spans are ordered by test case name. A server span is a child of the
+	// span in the request's trace context.
+	assert.Equal(t, "connectconformance reference server", services[0])
+	assert.Equal(t, "connectrpc.conformance.v1.ConformanceService/Unary", spans[0].Name)
+	assert.Equal(t, otlpSpanKindServer, spans[0].Kind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].TraceID)
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].ParentSpanID)
+	assert.NotEqual(t, "00f067aa0ba902b7", spans[0].SpanID)
+	assert.Equal(t, otlpStatus{Code: otlpStatusCodeError, Message: "oops"}, spans[0].Status)
+	assert.Equal(t, map[string]any{
+		"conformance.test_case":    "foo/bar/1",
+		"conformance.outcome":      "failed",
+		"conformance.failure":      "wrong status",
+		"http.request.method":      http.MethodPost,
+		"url.path":                 "/connectrpc.conformance.v1.ConformanceService/Unary",
+		"network.protocol.version": "2",
+	}, attributes(spans[0]))
+
+	// A client span is the span in the request's trace context.
+	assert.Equal(t, "connectconformance reference client", services[1])
+	assert.Equal(t, otlpSpanKindClient, spans[1].Kind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[1].TraceID)
+	assert.Equal(t, "00f067aa0ba902b7", spans[1].SpanID)
+	assert.Empty(t, spans[1].ParentSpanID)
+	assert.Equal(t, otlpStatus{}, spans[1].Status)
+	assert.Equal(t, map[string]any{
+		"conformance.test_case":     "foo/bar/2",
+		"conformance.outcome":       "passed",
+		"http.request.method":       http.MethodPost,
+		"url.path":                  "/connectrpc.conformance.v1.ConformanceService/Unary",
+		"network.protocol.version":  "2",
+		"http.response.status_code": int64(http.StatusOK),
+	}, attributes(spans[1]))
+	eventNames := make([]string, len(spans[1].Events))
+	for i, event := range spans[1].Events {
+		eventNames[i] = event.Name
+	}
+	assert.Equal(t, []string{"request start", "request message", "response start", "response end"}, eventNames)
+	assert.Equal(t, uint64(traceStart.UnixNano()), spans[1].StartTimeUnixNano)
+
+	// Without a trace context, a span is the root of a new trace.
+	assert.Len(t, spans[2].TraceID, 32)
+	assert.NotEqual(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[2].TraceID)
+	assert.Empty(t, spans[2].ParentSpanID)
+
+	// A server span that returned its trace context
+	// in the response is the span in that context.
+	assert.Equal(t, otlpSpanKindServer, spans[3].Kind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[3].TraceID)
+	assert.Equal(t, "b7ad6b7169203331", spans[3].SpanID)
+	assert.Equal(t, "00f067aa0ba902b7", spans[3].ParentSpanID)
+}
@@ -23,10 +23,14 @@ import (
 	"strings"
 
 	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/compression"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
 	"connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1/conformancev1connect"
 	"connectrpc.com/connect"
 	"github.com/bufbuild/protoyaml-go"
+	_ "google.golang.org/grpc/health/grpc_health_v1" // registers types used in health checking test suites
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -54,8 +58,21 @@ var (
 	allCodecs       = allValues[conformancev1.Codec](conformancev1.Codec_name)
 	allCompressions = allValues[conformancev1.Compression](conformancev1.Compression_name)
 	allStreamTypes  = allValues[conformancev1.StreamType](conformancev1.StreamType_name)
+
+	// Test cases in suites that rely on server reflection are written against
+	// the v1 service and are run against each of these versions of it.
+	allReflectionVersions = []reflectionVersion{
+		{name: "v1", service: reflectionv1.ServerReflection_ServiceDesc.ServiceName},
+		{name: "v1alpha", service: reflectionv1alpha.ServerReflection_ServiceDesc.ServiceName},
+	}
 )
 
+// reflectionVersion is a version of the server reflection service.
+type reflectionVersion struct {
+	name    string
+	service string
+}
+
 // testCaseLibrary is the set of all applicable test cases for a run
 // of the conformance tests.
 type testCaseLibrary struct {
@@ -127,6 +144,35 @@ func (lib *testCaseLibrary) expandSuite(suite *conformancev1.TestSuite, configCa
 	if suite.ReliesOnConnectGet && !only(suite.RelevantProtocols, conformancev1.Protocol_PROTOCOL_CONNECT) {
 		return fmt.Errorf("suite %q is misconfigured: it relies on Connect GET support, but has unexpected relevant protocols: %v", suite.Name, suite.RelevantProtocols)
 	}
+	if suite.ReliesOnReflection && suite.Mode != conformancev1.TestSuite_TEST_MODE_SERVER {
+		return fmt.Errorf("suite %q is misconfigured: it relies on server reflection, but its mode is %v", suite.Name, suite.Mode)
+	}
+	if suite.ReliesOnReflection {
+		for _, testCase := range suite.TestCases {
+			if testCase.Request.GetService() != allReflectionVersions[0].service {
+				return fmt.Errorf("suite %q is misconfigured: it relies on server reflection, but test case %q does not invoke service %s", suite.Name, testCase.Request.GetTestName(), allReflectionVersions[0].service)
+			}
+		}
+	}
+	if suite.ReliesOnHealth && suite.Mode != conformancev1.TestSuite_TEST_MODE_SERVER {
+		return fmt.Errorf("suite %q is misconfigured: it relies on health checking, but its mode is %v", suite.Name, suite.Mode)
+	}
+	if suite.ReliesOnCors && suite.Mode != conformancev1.TestSuite_TEST_MODE_SERVER {
+		return fmt.Errorf("suite %q is misconfigured: it relies on CORS, but its mode is %v", suite.Name, suite.Mode)
+	}
+	if suite.ReliesOnConnectionFaults && !only(suite.RelevantHttpVersions, conformancev1.HTTPVersion_HTTP_VERSION_2) {
+		return fmt.Errorf("suite %q is misconfigured: it relies on connection faults, but has unexpected relevant HTTP versions: %v", suite.Name, suite.RelevantHttpVersions)
+	}
+	if suite.ReliesOnConnectionFaults && suite.ReliesOnTls {
+		return fmt.Errorf("suite %q is misconfigured: it relies on connection faults, which cannot be injected when using TLS", suite.Name)
+	}
+	if !suite.ReliesOnConnectionFaults {
+		for _, testCase := range suite.TestCases {
+			if testCase.ConnectionFault != nil {
+				return fmt.Errorf("suite %q is misconfigured: test case %q has a connection fault, but suite does not rely on connection faults", suite.Name, testCase.Request.GetTestName())
+			}
+		}
+	}
 	if suite.ConnectVersionMode == conformancev1.TestSuite_CONNECT_VERSION_MODE_IGNORE && !only(suite.RelevantProtocols, conformancev1.Protocol_PROTOCOL_CONNECT) {
 		return fmt.Errorf("suite %q is misconfigured: it ignores Connect Version headers, but has unexpected relevant protocols: %v", suite.Name, suite.RelevantProtocols)
 	}
@@ -147,6 +193,9 @@ func (lib *testCaseLibrary) expandSuite(suite *conformancev1.TestSuite, configCa
 			if suite.ReliesOnTls {
 				tlsCases = []bool{true} // can't run these cases w/out TLS
 			}
+			if suite.ReliesOnConnectionFaults {
+				tlsCases = []bool{false} // proxy can't inject faults into encrypted traffic
+			}
 			for _, tlsCase := range tlsCases {
 				codecs := suite.RelevantCodecs
 				if len(codecs) == 0 {
@@ -170,10 +219,12 @@ func (lib *testCaseLibrary) expandSuite(suite *conformancev1.TestSuite, configCa
 								UseConnectGET:          suite.ReliesOnConnectGet,
 								ConnectVersionMode:     suite.ConnectVersionMode,
 								UseMessageReceiveLimit: suite.ReliesOnMessageReceiveLimit,
+								UseReflection:          suite.ReliesOnReflection,
+								UseHealth:              suite.ReliesOnHealth,
+								UseCORS:                suite.ReliesOnCors,
 							}
 							if _, ok := configCases[cfgCase]; ok {
-								namePrefix := generateTestCasePrefix(suite, cfgCase)
-								if err := lib.expandCases(cfgCase, namePrefix, suite.TestCases); err != nil {
+								if err := lib.expandSuiteCases(suite, cfgCase); err != nil {
 									return fmt.Errorf("failed to expand test cases for suite %s: %w", suite.Name, err)
 								}
 							}
@@ -186,6 +237,29 @@ func (lib *testCaseLibrary) expandSuite(suite *conformancev1.TestSuite, configCa
 	return nil
 }
 
+// expandSuiteCases expands the test cases in the given suite for the given
+// config case. If the suite relies on server reflection, the test cases are
+// expanded once for each version of the reflection service.
+func (lib *testCaseLibrary) expandSuiteCases(suite *conformancev1.TestSuite, cfgCase configCase) error {
+	if !suite.ReliesOnReflection {
+		return lib.expandCases(cfgCase, generateTestCasePrefix(suite, cfgCase), suite.TestCases)
+	}
+	for _, version := range allReflectionVersions {
+		namePrefix := append(generateTestCasePrefix(suite, cfgCase), "ReflectionVersion:"+version.name)
+		service := version.service
+		testCases := make([]*conformancev1.TestCase, len(suite.TestCases))
+		for i, testCase := range suite.TestCases {
+			testCase := proto.Clone(testCase).(*conformancev1.TestCase) //nolint:errcheck,forcetypeassert
+			testCase.Request.Service = &service
+			testCases[i] = testCase
+		}
+		if err := lib.expandCases(cfgCase, namePrefix, testCases); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (lib *testCaseLibrary) expandCases(cfgCase configCase, namePrefix []string, testCases []*conformancev1.TestCase) error {
 	for i, testCase := range testCases {
 		if testCase.Request.TestName == "" {
@@ -250,6 +324,11 @@ func (lib *testCaseLibrary) expandCases(cfgCase configCase, namePrefix []string,
 		testCase.Request.Protocol = cfgCase.Protocol
 		testCase.Request.Codec = cfgCase.Codec
 		testCase.Request.Compression = cfgCase.Compression
+		if testCase.CompressRawContents {
+			if err := compressRawContents(testCase, cfgCase.Compression); err != nil {
+				return fmt.Errorf("test case #%d: test %s: %w", i+1, simpleName, err)
+			}
+		}
 		// We always set this. If client-under-test does not support it, we just
 		// won't run the test cases that verify that it's enforced.
 		testCase.Request.MessageReceiveLimit = clientReceiveLimit
@@ -310,8 +389,10 @@ func (lib *testCaseLibrary) filterGRPCImplTestCases(testCases []*conformancev1.T
 	filtered := make([]*conformancev1.TestCase, 0, len(testCases))
 	for _, testCase := range testCases {
 		// Client only supports gRPC protocol. Server also supports gRPC-Web.
+		// Neither supports the gRPC-Web text format.
 		if clientIsGRPCImpl && testCase.Request.Protocol != conformancev1.Protocol_PROTOCOL_GRPC ||
-			testCase.Request.Protocol == conformancev1.Protocol_PROTOCOL_CONNECT {
+			testCase.Request.Protocol == conformancev1.Protocol_PROTOCOL_CONNECT ||
+			testCase.Request.Protocol == conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT {
 			continue
 		}
 
@@ -342,9 +423,25 @@ func (lib *testCaseLibrary) filterGRPCImplTestCases(testCases []*conformancev1.T
 		if testCase.Request.RawRequest != nil && clientIsGRPCImpl {
 			continue
 		}
+		if testCase.Request.GetService() != conformancev1connect.ConformanceServiceName && clientIsGRPCImpl {
+			// The client only knows how to invoke the ConformanceService.
+			continue
+		}
 		if hasRawResponse(testCase.Request.RequestMessages) && serverIsGRPCImpl {
 			continue
 		}
+		if internal.RequestsGoAway(testCase.Request.RequestMessages) && serverIsGRPCImpl {
+			continue
+		}
+		if hasServerReceiveDelay(testCase.Request.RequestMessages) && serverIsGRPCImpl {
+			continue
+		}
+		if hasWriteChunking(testCase.Request.RequestMessages) && serverIsGRPCImpl {
+			continue
+		}
+		if hasPerMessageResponseDelays(testCase.Request.RequestMessages) && serverIsGRPCImpl {
+			continue
+		}
 
 		filteredCase := proto.Clone(testCase).(*conformancev1.TestCase) //nolint:errcheck,forcetypeassert
 		baseName := lib.testCaseNames[filteredCase.Request.TestName]
@@ -398,6 +495,34 @@ type serverInstance struct {
 	httpVersion       conformancev1.HTTPVersion
 	useTLS            bool
 	useTLSClientCerts bool
+	// Test cases where the server sends GOAWAY frames get their own server
+	// instance, so that they can't disturb other test cases that would
+	// otherwise share a connection.
+	drainsConnections bool
+	// Test cases that measure memory usage, like compression bombs, also
+	// get their own server instance, so that the memory usage of the
+	// implementation under test can be measured for each one, without
+	// interference from other test cases.
+	checksMemory bool
+}
+
+func (s serverInstance) String() string {
+	var tlsMode string
+	switch {
+	case !s.useTLS:
+		tlsMode = "false"
+	case s.useTLS && s.useTLSClientCerts:
+		tlsMode = "true (with client certs)"
+	default:
+		tlsMode = "true"
+	}
+	if s.drainsConnections {
+		return fmt.Sprintf("{%s, %s, TLS:%s, draining connections}", s.httpVersion, s.protocol, tlsMode)
+	}
+	if s.checksMemory {
+		return fmt.Sprintf("{%s, %s, TLS:%s, checking memory}", s.httpVersion, s.protocol, tlsMode)
+	}
+	return fmt.Sprintf("{%s, %s, TLS:%s}", s.httpVersion, s.protocol, tlsMode)
 }
 
 func serverInstanceForCase(testCase *conformancev1.TestCase) serverInstance {
@@ -406,6 +531,8 @@ func serverInstanceForCase(testCase *conformancev1.TestCase) serverInstance {
 		httpVersion:       testCase.Request.HttpVersion,
 		useTLS:            len(testCase.Request.ServerTlsCert) > 0,
 		useTLSClientCerts: testCase.Request.ClientTlsCreds != nil,
+		drainsConnections: internal.RequestsGoAway(testCase.Request.RequestMessages),
+		checksMemory:      testCase.MeasureMemoryUsage,
 	}
 }
 
@@ -455,10 +582,42 @@ func parseTestSuites(testFileData map[string][]byte) (map[string]*conformancev1.
 				return nil, fmt.Errorf("%s: test case %q has raw response, but that is only allowed when mode is TEST_MODE_CLIENT",
 					testFilePath, testCase.Request.TestName)
 			}
+			if internal.RequestsGoAway(testCase.Request.RequestMessages) && suite.Mode != conformancev1.TestSuite_TEST_MODE_CLIENT {
+				return nil, fmt.Errorf("%s: test case %q has GOAWAY, but that is only allowed when mode is TEST_MODE_CLIENT",
+					testFilePath, testCase.Request.TestName)
+			}
+			if internal.RequestsGoAway(testCase.Request.RequestMessages) && !only(suite.RelevantHttpVersions, conformancev1.HTTPVersion_HTTP_VERSION_2) {
+				return nil, fmt.Errorf("%s: test case %q has GOAWAY, but suite is not only relevant to HTTP_VERSION_2",
+					testFilePath, testCase.Request.TestName)
+			}
+			if hasServerReceiveDelay(testCase.Request.RequestMessages) && suite.Mode != conformancev1.TestSuite_TEST_MODE_CLIENT {
+				return nil, fmt.Errorf("%s: test case %q has server receive delay, but that is only allowed when mode is TEST_MODE_CLIENT",
+					testFilePath, testCase.Request.TestName)
+			}
+			if hasWriteChunking(testCase.Request.RequestMessages) && suite.Mode != conformancev1.TestSuite_TEST_MODE_CLIENT {
+				return nil, fmt.Errorf("%s: test case %q has write chunking, but that is only allowed when mode is TEST_MODE_CLIENT",
+					testFilePath, testCase.Request.TestName)
+			}
+			if hasPerMessageResponseDelays(testCase.Request.RequestMessages) && suite.Mode != conformancev1.TestSuite_TEST_MODE_CLIENT {
+				return nil, fmt.Errorf("%s: test case %q has per-message response delays, but that is only allowed when mode is TEST_MODE_CLIENT",
+					testFilePath, testCase.Request.TestName)
+			}
 			if hasRawResponse(testCase.Request.RequestMessages) && testCase.ExpectedResponse == nil {
 				return nil, fmt.Errorf("%s: test case %q has raw response, but does not specify an explicit expected response",
 					testFilePath, testCase.Request.TestName)
 			}
+			if testCase.CompressRawContents {
+				if testCase.Request.RawRequest == nil && !hasRawResponse(testCase.Request.RequestMessages) {
+					return nil, fmt.Errorf("%s: test case %q specifies compress raw contents directive, but has no raw request or raw response",
+						testFilePath, testCase.Request.TestName)
+				}
+				if len(suite.RelevantCompressions) == 0 ||
+					hasCompression(suite.RelevantCompressions, conformancev1.Compression_COMPRESSION_IDENTITY) ||
+					hasCompression(suite.RelevantCompressions, conformancev1.Compression_COMPRESSION_UNSPECIFIED) {
+					return nil, fmt.Errorf("%s: test case %q specifies compress raw contents directive, but suite's relevant compressions are not all actual compression algorithms",
+						testFilePath, testCase.Request.TestName)
+				}
+			}
 			// The expand request directive uses the proto codec for size calculations, so it doesn't make sense to test with other codecs
 			if len(testCase.ExpandRequests) > 0 && (len(suite.RelevantCodecs) > 1 || !hasCodec(suite.RelevantCodecs, conformancev1.Codec_CODEC_PROTO)) {
 				return nil, fmt.Errorf("%s: test case %q specifies expand requests directive, but includes codecs other than CODEC_PROTO",
@@ -550,6 +709,67 @@ func expandRequestData(testCase *conformancev1.TestCase) error {
 	return nil
 }
 
+// compressRawContents updates the raw request or raw response of the given
+// test case so that its message contents use the given compression, per the
+// compress_raw_contents test case field. Any headers that name the encoding
+// of the contents are also updated.
+func compressRawContents(testCase *conformancev1.TestCase, algorithm conformancev1.Compression) error {
+	name, err := compression.Name(algorithm)
+	if err != nil {
+		return err
+	}
+	if rawReq := testCase.Request.RawRequest; rawReq != nil {
+		setRawEncodingHeaders(rawReq.Headers, name)
+		setRawBodyCompression(rawReq.GetUnary(), rawReq.GetStream(), algorithm)
+		return nil
+	}
+	if len(testCase.Request.RequestMessages) == 0 {
+		return errors.New("compress raw contents directive given, but there are no request messages")
+	}
+	concreteReq, err := testCase.Request.RequestMessages[0].UnmarshalNew()
+	if err != nil {
+		return fmt.Errorf("request message #1: %w", err)
+	}
+	var rawResp *conformancev1.RawHTTPResponse
+	switch concreteReq := concreteReq.(type) {
+	case unaryResponseDefiner:
+		rawResp = concreteReq.GetResponseDefinition().GetRawResponse()
+	case streamResponseDefiner:
+		rawResp = concreteReq.GetResponseDefinition().GetRawResponse()
+	}
+	if rawResp == nil {
+		return errors.New("compress raw contents directive given, but there is no raw request or raw response")
+	}
+	setRawEncodingHeaders(rawResp.Headers, name)
+	setRawBodyCompression(rawResp.GetUnary(), rawResp.GetStream(), algorithm)
+	if err := testCase.Request.RequestMessages[0].MarshalFrom(concreteReq); err != nil {
+		return fmt.Errorf("request message #1: %w", err)
+	}
+	return nil
+}
+
+func setRawEncodingHeaders(headers []*conformancev1.Header, name string) {
+	for _, hdr := range headers {
+		switch strings.ToLower(hdr.Name) {
+		case "content-encoding", "connect-content-encoding", "grpc-encoding":
+			for i := range hdr.Value {
+				hdr.Value[i] = name
+			}
+		}
+	}
+}
+
+func setRawBodyCompression(unary *conformancev1.MessageContents, stream *conformancev1.StreamContents, algorithm conformancev1.Compression) {
+	if unary != nil {
+		unary.Compression = algorithm
+	}
+	for _, item := range stream.GetItems() {
+		if item.Flags&1 != 0 && item.Payload != nil {
+			item.Payload.Compression = algorithm
+		}
+	}
+}
+
 // populateExpectedResponse populates the response we expected to get back from the server
 // by examining the requests we sent.
 func populateExpectedResponse(testCase *conformancev1.TestCase) error {
@@ -604,6 +824,15 @@ func hasCodec(codecs []conformancev1.Codec, target conformancev1.Codec) bool {
 	return false
 }
 
+func hasCompression(compressions []conformancev1.Compression, target conformancev1.Compression) bool {
+	for _, c := range compressions {
+		if c == target {
+			return true
+		}
+	}
+	return false
+}
+
 func hasRawResponse(reqs []*anypb.Any) bool {
 	if len(reqs) == 0 {
 		return false
@@ -625,6 +854,51 @@ func hasRawResponse(reqs []*anypb.Any) bool {
 	return false
 }
 
+func hasServerReceiveDelay(reqs []*anypb.Any) bool {
+	if len(reqs) == 0 {
+		return false
+	}
+	msg, err := reqs[0].UnmarshalNew()
+	if err != nil {
+		return false // we'll deal with this error later
+	}
+	csr, ok := msg.(*conformancev1.ClientStreamRequest)
+	return ok && csr.ReceiveDelayMs > 0
+}
+
+func hasWriteChunking(reqs []*anypb.Any) bool {
+	if len(reqs) == 0 {
+		return false
+	}
+	msg, err := reqs[0].UnmarshalNew()
+	if err != nil {
+		return false // we'll deal with this error later
+	}
+	switch msg := msg.(type) {
+	case unaryResponseDefiner:
+		if msg.GetResponseDefinition().GetWriteChunking() != nil {
+			return true
+		}
+	case streamResponseDefiner:
+		if msg.GetResponseDefinition().GetWriteChunking() != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPerMessageResponseDelays(reqs []*anypb.Any) bool {
+	if len(reqs) == 0 {
+		return false
+	}
+	msg, err := reqs[0].UnmarshalNew()
+	if err != nil {
+		return false // we'll deal with this error later
+	}
+	definer, ok := msg.(streamResponseDefiner)
+	return ok && len(definer.GetResponseDefinition().GetResponseDelaysMs()) > 0
+}
+
 // populates the expected response for a unary test case.
 func populateExpectedUnaryResponse(testCase *conformancev1.TestCase) error {
 	req := testCase.Request.RequestMessages[0]
@@ -724,6 +998,17 @@ func populateExpectedUnaryResponse(testCase *conformancev1.TestCase) error {
 			payload.Data = respType.ResponseData
 		}
 		expected.Payloads = []*conformancev1.ConformancePayload{payload}
+	case *conformancev1.UnaryResponseDefinition_GeneratedResponseData:
+		// If response data is to be generated, the server should return
+		// the same generated data along with the request info
+		data, err := internal.GenerateData(respType.GeneratedResponseData)
+		if err != nil {
+			return err
+		}
+		expected.Payloads = []*conformancev1.ConformancePayload{{
+			RequestInfo: reqInfo,
+			Data:        data,
+		}}
 	default:
 		return fmt.Errorf("provided UnaryRequest.Response has an unexpected type %T", respType)
 	}
@@ -769,12 +1054,17 @@ func populateExpectedStreamResponse(testCase *conformancev1.TestCase) error {
 		Error:            def.Error,
 	}
 
-	// There should be one payload for every ResponseData the client specified
-	expected.Payloads = make([]*conformancev1.ConformancePayload, len(def.ResponseData))
+	// There should be one payload for every ResponseData the client specified,
+	// including data that the server generates
+	responseData, err := internal.StreamResponseData(def)
+	if err != nil {
+		return err
+	}
+	expected.Payloads = make([]*conformancev1.ConformancePayload, len(responseData))
 
 	// The request specified an immediate error with no response
 	// Build a RequestInfo message and append it to the error details
-	if len(def.ResponseData) == 0 && expected.Error != nil {
+	if len(responseData) == 0 && expected.Error != nil {
 		reqInfo := &conformancev1.ConformancePayload_RequestInfo{
 			RequestHeaders: testCase.Request.RequestHeaders,
 			Requests:       testCase.Request.RequestMessages,
@@ -787,7 +1077,7 @@ func populateExpectedStreamResponse(testCase *conformancev1.TestCase) error {
 		expected.Error.Details = append(expected.Error.Details, reqInfoAny)
 	}
 
-	for idx, data := range def.ResponseData {
+	for idx, data := range responseData {
 		expected.Payloads[idx] = &conformancev1.ConformancePayload{
 			Data: data,
 		}
@@ -806,6 +1096,11 @@ func populateExpectedStreamResponse(testCase *conformancev1.TestCase) error {
 		case conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM:
 			// For a full duplex stream, the first request should be echoed back in the first
 			// payload. The second should be echoed back in the second payload, etc. (i.e. a ping pong interaction)
+			if idx >= len(testCase.Request.RequestMessages) {
+				// If there are more responses than requests, the remaining responses are sent
+				// after the request stream is closed, and they include no request information.
+				break
+			}
 			expected.Payloads[idx].RequestInfo = &conformancev1.ConformancePayload_RequestInfo{
 				Requests: []*anypb.Any{testCase.Request.RequestMessages[idx]},
 			}
@@ -408,6 +408,49 @@ func TestNewTestCaseLibrary(t *testing.T) {
 	}
 }
 
+func TestNewTestCaseLibrary_ReflectionVersions(t *testing.T) {
+	t.Parallel()
+
+	testSuites, err := parseTestSuites(map[string][]byte{
+		"reflection.yaml": []byte(`
+                    name: Reflection
+                    mode: TEST_MODE_SERVER
+                    reliesOnReflection: true
+                    relevantProtocols: [PROTOCOL_GRPC]
+                    relevantHttpVersions: [HTTP_VERSION_2]
+                    relevantCodecs: [CODEC_PROTO]
+                    relevantCompressions: [COMPRESSION_IDENTITY]
+                    testCases:
+                      - request:
+                            testName: list-services
+                            service: grpc.reflection.v1.ServerReflection
+                            method: ServerReflectionInfo
+                            streamType: STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM`),
+	})
+	require.NoError(t, err)
+	config := []configCase{
+		{
+			Version:       conformancev1.HTTPVersion_HTTP_VERSION_2,
+			Protocol:      conformancev1.Protocol_PROTOCOL_GRPC,
+			Codec:         conformancev1.Codec_CODEC_PROTO,
+			Compression:   conformancev1.Compression_COMPRESSION_IDENTITY,
+			StreamType:    conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM,
+			UseReflection: true,
+		},
+	}
+	testCaseLib, err := newTestCaseLibrary(testSuites, config, conformancev1.TestSuite_TEST_MODE_SERVER)
+	require.NoError(t, err)
+	services := make(map[string]string, len(testCaseLib.testCases))
+	for name, testCase := range testCaseLib.testCases {
+		services[name] = testCase.Request.GetService()
+	}
+	expected := map[string]string{
+		"Reflection/TLS:false/ReflectionVersion:v1/list-services":      "grpc.reflection.v1.ServerReflection",
+		"Reflection/TLS:false/ReflectionVersion:v1alpha/list-services": "grpc.reflection.v1alpha.ServerReflection",
+	}
+	require.Empty(t, cmp.Diff(expected, services), "- wanted; + got")
+}
+
 func TestParseTestSuites_EmbeddedTestSuites(t *testing.T) {
 	t.Parallel()
 	testSuiteData, err := testsuites.LoadTestSuites()
@@ -865,6 +908,131 @@ func TestExpandRequestData(t *testing.T) {
 	}
 }
 
+func TestCompressRawContents(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name         string
+		testCaseJSON string
+		expectRaw    string
+		expectErr    string
+	}{
+		{
+			name: "raw-request",
+			testCaseJSON: `{
+				"request": {
+					"rawRequest": {
+						"headers": [
+							{"name": "Content-Type", "value": ["application/proto"]},
+							{"name": "Content-Encoding", "value": ["gzip"]}
+						],
+						"unary": {"text": "abc"}
+					}
+				}
+			}`,
+			expectRaw: `{
+				"headers": [
+					{"name": "Content-Type", "value": ["application/proto"]},
+					{"name": "Content-Encoding", "value": ["zstd"]}
+				],
+				"unary": {"text": "abc", "compression": "COMPRESSION_ZSTD"}
+			}`,
+		},
+		{
+			name: "raw-response",
+			testCaseJSON: `{
+				"request": {
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.ServerStreamRequest",
+							"response_definition": {
+								"raw_response": {
+									"headers": [
+										{"name": "content-type", "value": ["application/connect+proto"]},
+										{"name": "connect-content-encoding", "value": ["gzip"]}
+									],
+									"stream": {
+										"items": [
+											{"flags": 1, "payload": {"text": "abc"}},
+											{"flags": 0, "payload": {"text": "def"}},
+											{"flags": 2, "payload": {"text": "{}"}}
+										]
+									}
+								}
+							}
+						}
+					]
+				}
+			}`,
+			expectRaw: `{
+				"headers": [
+					{"name": "content-type", "value": ["application/connect+proto"]},
+					{"name": "connect-content-encoding", "value": ["zstd"]}
+				],
+				"stream": {
+					"items": [
+						{"flags": 1, "payload": {"text": "abc", "compression": "COMPRESSION_ZSTD"}},
+						{"flags": 0, "payload": {"text": "def"}},
+						{"flags": 2, "payload": {"text": "{}"}}
+					]
+				}
+			}`,
+		},
+		{
+			name: "no-raw-contents",
+			testCaseJSON: `{
+				"request": {
+					"requestMessages":[
+						{
+							"@type": "type.googleapis.com/connectrpc.conformance.v1.UnaryRequest",
+							"response_definition": {"response_data": "abcdefgh"}
+						}
+					]
+				}
+			}`,
+			expectErr: "there is no raw request or raw response",
+		},
+		{
+			name: "no-requests",
+			testCaseJSON: `{
+				"request": {
+					"requestMessages":[]
+				}
+			}`,
+			expectErr: "compress raw contents directive given, but there are no request messages",
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			var testCaseProto conformancev1.TestCase
+			err := protojson.Unmarshal([]byte(testCase.testCaseJSON), &testCaseProto)
+			require.NoError(t, err)
+			err = compressRawContents(&testCaseProto, conformancev1.Compression_COMPRESSION_ZSTD)
+			if testCase.expectErr != "" {
+				require.ErrorContains(t, err, testCase.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			var actual, expected proto.Message
+			if testCaseProto.Request.RawRequest != nil {
+				actual = testCaseProto.Request.RawRequest
+				expected = &conformancev1.RawHTTPRequest{}
+			} else {
+				req, err := testCaseProto.Request.RequestMessages[0].UnmarshalNew()
+				require.NoError(t, err)
+				definer, ok := req.(streamResponseDefiner)
+				require.True(t, ok)
+				actual = definer.GetResponseDefinition().GetRawResponse()
+				expected = &conformancev1.RawHTTPResponse{}
+			}
+			err = protojson.Unmarshal([]byte(testCase.expectRaw), expected)
+			require.NoError(t, err)
+			assert.Empty(t, cmp.Diff(expected, actual, protocmp.Transform()))
+		})
+	}
+}
+
 func TestPopulateExpectedResponse(t *testing.T) {
 	t.Parallel()
 
@@ -1748,6 +1916,38 @@ func TestPopulateExpectedResponse(t *testing.T) {
 				ResponseTrailers: responseTrailers,
 			},
 		},
+		{
+			testName: "full duplex bidi stream with more responses than requests",
+			request: &conformancev1.ClientCompatRequest{
+				StreamType: conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM,
+				RequestMessages: asAnySlice(t, &conformancev1.BidiStreamRequest{
+					ResponseDefinition: &conformancev1.StreamResponseDefinition{
+						ResponseData: [][]byte{data1, data2},
+					},
+					FullDuplex: true,
+				}),
+				RequestHeaders: requestHeaders,
+			},
+			expected: &conformancev1.ClientResponseResult{
+				Payloads: []*conformancev1.ConformancePayload{
+					{
+						Data: data1,
+						RequestInfo: &conformancev1.ConformancePayload_RequestInfo{
+							RequestHeaders: requestHeaders,
+							Requests: asAnySlice(t, &conformancev1.BidiStreamRequest{
+								ResponseDefinition: &conformancev1.StreamResponseDefinition{
+									ResponseData: [][]byte{data1, data2},
+								},
+								FullDuplex: true,
+							}),
+						},
+					},
+					{
+						Data: data2,
+					},
+				},
+			},
+		},
 		{
 			testName: "full duplex bidi stream error with responses",
 			request: &conformancev1.ClientCompatRequest{
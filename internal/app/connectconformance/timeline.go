@@ -0,0 +1,275 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/tracer"
+)
+
+// timeline records the activity of a test run, so that it can be written
+// in the Chrome trace-event format, which can be viewed in Perfetto or in
+// Chrome's trace viewer. This shows how test cases were scheduled across
+// client and server processes.
+//
+// Each client and server process gets its own lane. Server lanes show the
+// lifetime of the server process. Each test case is shown as a span in the
+// lane of the client that sent it and in the lane of the server to which it
+// was sent. If HTTP traces are available, key events from the trace are
+// shown as markers in the span of whichever side is the reference
+// implementation, since that is the side that produces the traces.
+//
+// A nil *timeline is valid and records nothing.
+type timeline struct {
+	start time.Time
+
+	mu       sync.Mutex
+	events   []timelineEvent
+	numLanes int
+	numSpans int
+	// The spans in the lanes of reference implementations, keyed by
+	// test case name, to which markers for trace events are added.
+	referenceSpans map[string]timelineSpan
+}
+
+func newTimeline() *timeline {
+	return &timeline{start: time.Now(), referenceSpans: map[string]timelineSpan{}}
+}
+
+// writeTimeline writes the timeline of the run to the given file.
+func (r *testResults) writeTimeline(fileName string) error {
+	r.settle() // make sure all trace events have been recorded
+	var buf bytes.Buffer
+	if err := r.timeline.write(&buf); err != nil {
+		return err
+	}
+	if err := os.WriteFile(fileName, buf.Bytes(), 0o600); err != nil {
+		return internal.EnsureFileName(err, fileName)
+	}
+	return nil
+}
+
+// timelineEvent is an event in the Chrome trace-event format.
+type timelineEvent struct {
+	Name      string         `json:"name"`
+	Category  string         `json:"cat,omitempty"`
+	Phase     string         `json:"ph"`
+	Timestamp float64        `json:"ts"`
+	Duration  *float64       `json:"dur,omitempty"`
+	PID       int            `json:"pid"`
+	TID       int            `json:"tid"`
+	ID        string         `json:"id,omitempty"`
+	Args      map[string]any `json:"args,omitempty"`
+}
+
+// timelineLane is a lane in the timeline, for a single client or server
+// process.
+type timelineLane struct {
+	timeline        *timeline
+	pid             int
+	isReferenceImpl bool
+}
+
+type timelineSpan struct {
+	lane *timelineLane
+	id   string
+}
+
+// newLane adds a new lane with the given name. Lanes are shown in the
+// order in which they are added.
+func (t *timeline) newLane(name string, isReferenceImpl bool) *timelineLane {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.numLanes++
+	lane := &timelineLane{timeline: t, pid: t.numLanes, isReferenceImpl: isReferenceImpl}
+	t.events = append(t.events,
+		timelineEvent{Name: "process_name", Phase: "M", PID: lane.pid, Args: map[string]any{"name": name}},
+		timelineEvent{Name: "process_sort_index", Phase: "M", PID: lane.pid, Args: map[string]any{"sort_index": lane.pid}},
+	)
+	return lane
+}
+
+// serverStarter wraps the given function, which starts a server process
+// for the given server instance, so that the lifetime of the process is
+// shown in the given lane.
+func (t *timeline) serverStarter(lane *timelineLane, svrInstance serverInstance, start processStarter) processStarter {
+	if t == nil {
+		return start
+	}
+	return func(ctx context.Context, pipeStderr bool) (*process, error) {
+		startTime := time.Now()
+		proc, err := start(ctx, pipeStderr)
+		if err != nil {
+			return nil, err
+		}
+		proc.whenDone(func(_ error) {
+			t.addComplete(lane, svrInstance.String(), startTime, time.Now())
+		})
+		return proc, nil
+	}
+}
+
+// client wraps the given client, so that each test case it sends is shown
+// as a span in the given client and server lanes.
+func (t *timeline) client(client clientRunner, clientLane, serverLane *timelineLane) clientRunner {
+	if t == nil {
+		return client
+	}
+	return &timelineClient{clientRunner: client, timeline: t, clientLane: clientLane, serverLane: serverLane}
+}
+
+// addTraceEvents adds markers for the key events in the given trace: when
+// the request starts, when the first byte of the response is received, and
+// when the response stream ends.
+func (t *timeline) addTraceEvents(testCase string, trace *tracer.Trace) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	span, ok := t.referenceSpans[testCase]
+	if !ok {
+		return
+	}
+	var endStream time.Duration
+	var hasEndStream bool
+	for _, event := range trace.Events {
+		switch event := event.(type) {
+		case *tracer.RequestStart:
+			t.addInstantLocked(span, "request start", trace.Start.Add(event.Offset))
+		case *tracer.ResponseStart:
+			t.addInstantLocked(span, "first byte", trace.Start.Add(event.Offset))
+		case *tracer.ResponseBodyEndStream:
+			endStream, hasEndStream = event.Offset, true
+		case *tracer.ResponseBodyEnd:
+			if !hasEndStream {
+				endStream, hasEndStream = event.Offset, true
+			}
+		}
+	}
+	if hasEndStream {
+		t.addInstantLocked(span, "end stream", trace.Start.Add(endStream))
+	}
+}
+
+// write writes the timeline to w as a JSON object, in the Chrome
+// trace-event format.
+func (t *timeline) write(w io.Writer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return json.NewEncoder(w).Encode(map[string]any{
+		"traceEvents":     t.events,
+		"displayTimeUnit": "ms",
+	})
+}
+
+func (t *timeline) beginSpan(lane *timelineLane, testCase string) timelineSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.numSpans++
+	span := timelineSpan{lane: lane, id: strconv.Itoa(t.numSpans)}
+	t.events = append(t.events, timelineEvent{
+		Name:      testCase,
+		Category:  "rpc",
+		Phase:     "b",
+		Timestamp: t.micros(time.Now()),
+		PID:       lane.pid,
+		ID:        span.id,
+	})
+	if lane.isReferenceImpl {
+		t.referenceSpans[testCase] = span
+	}
+	return span
+}
+
+func (t *timeline) endSpan(span timelineSpan, testCase string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, timelineEvent{
+		Name:      testCase,
+		Category:  "rpc",
+		Phase:     "e",
+		Timestamp: t.micros(time.Now()),
+		PID:       span.lane.pid,
+		ID:        span.id,
+	})
+}
+
+func (t *timeline) addComplete(lane *timelineLane, name string, start, end time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	dur := float64(end.Sub(start).Microseconds())
+	t.events = append(t.events, timelineEvent{
+		Name:      name,
+		Category:  "server",
+		Phase:     "X",
+		Timestamp: t.micros(start),
+		Duration:  &dur,
+		PID:       lane.pid,
+	})
+}
+
+func (t *timeline) addInstantLocked(span timelineSpan, name string, when time.Time) {
+	t.events = append(t.events, timelineEvent{
+		Name:      name,
+		Category:  "rpc",
+		Phase:     "n",
+		Timestamp: t.micros(when),
+		PID:       span.lane.pid,
+		ID:        span.id,
+	})
+}
+
+func (t *timeline) micros(when time.Time) float64 {
+	return float64(when.Sub(t.start).Microseconds())
+}
+
+// timelineClient is a client that records the test cases it sends in a
+// timeline.
+type timelineClient struct {
+	clientRunner
+	timeline               *timeline
+	clientLane, serverLane *timelineLane
+}
+
+func (c *timelineClient) sendRequest(req *conformancev1.ClientCompatRequest, whenDone func(string, *conformancev1.ClientCompatResponse, error)) error {
+	testCase := req.TestName
+	clientSpan := c.timeline.beginSpan(c.clientLane, testCase)
+	serverSpan := c.timeline.beginSpan(c.serverLane, testCase)
+	err := c.clientRunner.sendRequest(req, func(name string, resp *conformancev1.ClientCompatResponse, err error) {
+		c.timeline.endSpan(clientSpan, testCase)
+		c.timeline.endSpan(serverSpan, testCase)
+		whenDone(name, resp, err)
+	})
+	if err != nil {
+		// The callback will not be invoked.
+		c.timeline.endSpan(clientSpan, testCase)
+		c.timeline.endSpan(serverSpan, testCase)
+	}
+	return err
+}
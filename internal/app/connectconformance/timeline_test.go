@@ -0,0 +1,108 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/tracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeline(t *testing.T) {
+	t.Parallel()
+	svrInstance := serverInstance{
+		protocol:    conformancev1.Protocol_PROTOCOL_CONNECT,
+		httpVersion: conformancev1.HTTPVersion_HTTP_VERSION_1,
+	}
+	runTimeline := newTimeline()
+	clientLane := runTimeline.newLane("reference client", true)
+	serverLane := runTimeline.newLane("server under test", false)
+
+	var stdin bytes.Buffer
+	start := runTimeline.serverStarter(serverLane, svrInstance, newFakeProcess(&stdin, &bytes.Buffer{}, &bytes.Buffer{}))
+	proc, err := start(context.Background(), false)
+	require.NoError(t, err)
+	client := runTimeline.client(&fakeClient{
+		responses: map[string]*conformancev1.ClientCompatResponse{
+			"foo/bar/1": {TestName: "foo/bar/1"},
+			"foo/bar/2": {TestName: "foo/bar/2"},
+		},
+	}, clientLane, serverLane)
+	for _, name := range []string{"foo/bar/1", "foo/bar/2"} {
+		err := client.sendRequest(&conformancev1.ClientCompatRequest{TestName: name}, func(string, *conformancev1.ClientCompatResponse, error) {})
+		require.NoError(t, err)
+	}
+	proc.abort()
+
+	traceStart := runTimeline.start.Add(time.Millisecond)
+	runTimeline.addTraceEvents("foo/bar/1", &tracer.Trace{
+		Start: traceStart,
+		Events: []tracer.Event{
+			&tracer.RequestStart{},
+			&tracer.ResponseStart{Response: &http.Response{}},
+			&tracer.ResponseBodyEndStream{},
+			&tracer.ResponseBodyEnd{},
+		},
+	})
+	// Traces for unknown test cases are ignored.
+	runTimeline.addTraceEvents("foo/bar/3", &tracer.Trace{Start: traceStart, Events: []tracer.Event{&tracer.RequestStart{}}})
+
+	var buf bytes.Buffer
+	require.NoError(t, runTimeline.write(&buf))
+	var doc struct {
+		TraceEvents []timelineEvent `json:"traceEvents"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	type summary struct {
+		Name  string
+		Phase string
+		PID   int
+		ID    string
+	}
+	summaries := make([]summary, len(doc.TraceEvents))
+	for i, event := range doc.TraceEvents {
+		summaries[i] = summary{Name: event.Name, Phase: event.Phase, PID: event.PID, ID: event.ID}
+	}
+	assert.Equal(t, []summary{
+		{Name: "process_name", Phase: "M", PID: 1},
+		{Name: "process_sort_index", Phase: "M", PID: 1},
+		{Name: "process_name", Phase: "M", PID: 2},
+		{Name: "process_sort_index", Phase: "M", PID: 2},
+		{Name: "foo/bar/1", Phase: "b", PID: 1, ID: "1"},
+		{Name: "foo/bar/1", Phase: "b", PID: 2, ID: "2"},
+		{Name: "foo/bar/1", Phase: "e", PID: 1, ID: "1"},
+		{Name: "foo/bar/1", Phase: "e", PID: 2, ID: "2"},
+		{Name: "foo/bar/2", Phase: "b", PID: 1, ID: "3"},
+		{Name: "foo/bar/2", Phase: "b", PID: 2, ID: "4"},
+		{Name: "foo/bar/2", Phase: "e", PID: 1, ID: "3"},
+		{Name: "foo/bar/2", Phase: "e", PID: 2, ID: "4"},
+		{Name: svrInstance.String(), Phase: "X", PID: 2},
+		// Trace events are added to the span in the reference client's lane.
+		{Name: "request start", Phase: "n", PID: 1, ID: "1"},
+		{Name: "first byte", Phase: "n", PID: 1, ID: "1"},
+		{Name: "end stream", Phase: "n", PID: 1, ID: "1"},
+	}, summaries)
+	assert.Equal(t, "server under test", doc.TraceEvents[2].Args["name"])
+	assert.InDelta(t, 1000, doc.TraceEvents[13].Timestamp, 1)
+}
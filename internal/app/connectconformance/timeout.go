@@ -0,0 +1,83 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+)
+
+// runTimer enforces the time budget for a whole run, which is configured
+// via the Timeout field of Flags.
+type runTimer struct {
+	timer   *time.Timer
+	err     error
+	expired atomic.Bool
+}
+
+// startRunTimer starts a timer that will call expire if the given timeout
+// elapses before the timer is stopped. The error given to expire describes
+// the timeout. If timeout is not positive, expire is never called.
+func startRunTimer(timeout time.Duration, expire func(error)) *runTimer {
+	timer := &runTimer{}
+	if timeout <= 0 {
+		return timer
+	}
+	timer.err = fmt.Errorf("run timed out after %v", timeout)
+	timer.timer = time.AfterFunc(timeout, func() {
+		timer.expired.Store(true)
+		expire(timer.err)
+	})
+	return timer
+}
+
+func (t *runTimer) stop() {
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+}
+
+// check returns an error that describes the timeout if the timer expired.
+// Otherwise, it returns the given error. Errors that occur after the timer
+// expires are usually due to processes being stopped, so they are replaced
+// by the timeout error.
+func (t *runTimer) check(err error) error {
+	if t.expired.Load() {
+		return t.err
+	}
+	return err
+}
+
+// printInFlight prints the test cases that were in flight when a run timed
+// out, grouped by the server configuration to which they were sent. The given
+// prefix is included before each server configuration, to identify which
+// client and server implementations were involved.
+func printInFlight(printer internal.Printer, prefix string, inFlight map[string][]string) {
+	servers := make([]string, 0, len(inFlight))
+	for server := range inFlight {
+		servers = append(servers, server)
+	}
+	sort.Strings(servers)
+	for _, server := range servers {
+		testCases := inFlight[server]
+		printer.Printf("%d test case(s) in flight for %sserver config %s:\n\t%s",
+			len(testCases), prefix, server, strings.Join(testCases, "\n\t"))
+	}
+}
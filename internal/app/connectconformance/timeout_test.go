@@ -0,0 +1,118 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/app/connectconformance/testsuites"
+	"connectrpc.com/conformance/internal/app/referenceserver"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResults_TimeOut(t *testing.T) {
+	t.Parallel()
+	results := newResults(4, &testTrie{}, &testTrie{}, nil)
+	results.sending("foo/bar/1", "server-a")
+	results.sending("foo/bar/2", "server-a")
+	results.sending("foo/bar/3", "server-b")
+	results.setOutcome("foo/bar/1", false, nil)
+
+	timeoutErr := errors.New("run timed out")
+	inFlight := results.timeOut(timeoutErr)
+	assert.Equal(t, map[string][]string{
+		"server-a": {"foo/bar/2"},
+		"server-b": {"foo/bar/3"},
+	}, inFlight)
+
+	// Outcomes that arrive after the timeout don't replace the could-not-run outcome.
+	results.setOutcome("foo/bar/2", false, nil)
+	// And new outcomes are also could-not-run.
+	results.setOutcome("foo/bar/4", true, errors.New("server process terminated unexpectedly"))
+
+	logger := &internal.SimplePrinter{}
+	require.True(t, results.report(logger))
+	assert.Equal(t, []string{
+		"Total cases: 4\n1 passed, 0 failed\n",
+		"Another 3 could not be run due to client timing out or exiting prematurely.\n",
+	}, logger.Messages)
+	for _, name := range []string{"foo/bar/2", "foo/bar/3", "foo/bar/4"} {
+		assert.ErrorIs(t, results.outcomes[name].actualFailure, timeoutErr, name)
+	}
+}
+
+func TestRunMatrix_Timeout(t *testing.T) {
+	t.Parallel()
+
+	testSuiteData, err := testsuites.LoadTestSuites()
+	require.NoError(t, err)
+	allSuites, err := parseTestSuites(testSuiteData)
+	require.NoError(t, err)
+	configCases := []configCase{
+		{
+			Version:     conformancev1.HTTPVersion_HTTP_VERSION_1,
+			Protocol:    conformancev1.Protocol_PROTOCOL_CONNECT,
+			Codec:       conformancev1.Codec_CODEC_PROTO,
+			Compression: conformancev1.Compression_COMPRESSION_IDENTITY,
+			StreamType:  conformancev1.StreamType_STREAM_TYPE_UNARY,
+		},
+	}
+	logger := &testPrinter{t}
+	flags := &Flags{MaxServers: 1, Timeout: time.Second}
+	plan, err := planRun(configCases, &testTrie{}, &testTrie{}, nil, nil, allSuites, logger, flags, false, false)
+	require.NoError(t, err)
+
+	// This client reads all requests but never replies.
+	hangingClient := processInfo{
+		name: "hangs",
+		start: runInProcess([]string{"hanging-client"}, func(ctx context.Context, _ []string, in io.ReadCloser, _, _ io.WriteCloser) error {
+			go func() {
+				_, _ = io.Copy(io.Discard, in)
+			}()
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+	}
+	server := processInfo{
+		name:  "server",
+		start: runInProcess([]string{"reference-server"}, referenceserver.Run),
+	}
+	errPrinter := &internal.SimplePrinter{}
+	start := time.Now()
+	matrix, err := runMatrix(plan, []processInfo{hangingClient}, []processInfo{server}, &testTrie{}, &testTrie{}, logger, errPrinter, flags)
+	require.EqualError(t, err, "run timed out after 1s")
+	assert.Less(t, time.Since(start), 30*time.Second)
+
+	require.NotEmpty(t, errPrinter.Messages)
+	assert.Equal(t, "ERROR: run timed out after 1s; stopping all processes\n", errPrinter.Messages[0])
+	output := strings.Join(errPrinter.Messages, "")
+	assert.Contains(t, output, "test case(s) in flight for client hangs / server server, server config {HTTP_VERSION_1, PROTOCOL_CONNECT, TLS:false}:\n")
+
+	results := matrix.results[implPair{client: "hangs", server: "server"}]
+	require.NotEmpty(t, results.outcomes)
+	for name, outcome := range results.outcomes {
+		var noRun *couldNotRunError
+		assert.ErrorAs(t, outcome.actualFailure, &noRun, name)
+	}
+	assert.False(t, matrix.report(logger))
+}
@@ -0,0 +1,80 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/tracer"
+)
+
+// writeTraces writes the HTTP traces of test cases to the given directory,
+// as HAR files, one per test case. The path of each file, relative to the
+// directory, is derived from the test case name. If failuresOnly is true,
+// only traces for test cases that failed are written.
+func (r *testResults) writeTraces(dir string, failuresOnly bool) error {
+	r.settle()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range r.traces.Names() {
+		if failuresOnly && r.outcomes[name].actualFailure == nil {
+			continue
+		}
+		trace := r.traces.Get(name)
+		if trace == nil {
+			continue // discarded by the store
+		}
+		var buf bytes.Buffer
+		if err := tracer.WriteHAR(&buf, trace); err != nil {
+			return err
+		}
+		fileName := filepath.Join(dir, traceFileName(name))
+		if err := os.MkdirAll(filepath.Dir(fileName), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(fileName, buf.Bytes(), 0o600); err != nil {
+			return internal.EnsureFileName(err, fileName)
+		}
+	}
+	return nil
+}
+
+// traceFileName returns the relative path of the trace file for the given
+// test case name. Each component of the name becomes a directory, with any
+// characters that may not be valid in file names replaced with underscores.
+func traceFileName(testCaseName string) string {
+	parts := strings.Split(testCaseName, "/")
+	for i, part := range parts {
+		part = strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+				r == '-', r == '_', r == '.':
+				return r
+			default:
+				return '_'
+			}
+		}, part)
+		if strings.Trim(part, ".") == "" {
+			// don't allow empty, "." or ".." components
+			part = strings.Repeat("_", len(part)+1)
+		}
+		parts[i] = part
+	}
+	return filepath.Join(parts...) + ".har"
+}
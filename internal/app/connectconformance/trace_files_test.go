@@ -0,0 +1,75 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectconformance
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/conformance/internal/tracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceFileName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t,
+		filepath.Join("Basic", "HTTPVersion_1", "TLS_false", "_grpc_server_impl_", "unary", "success.har"),
+		traceFileName("Basic/HTTPVersion:1/TLS:false/(grpc server impl)/unary/success"),
+	)
+	assert.Equal(t, filepath.Join("Some_Suite", "___", "_", "case.har"), traceFileName("Some Suite/../ /case"))
+}
+
+func TestWriteTraces(t *testing.T) {
+	t.Parallel()
+	results := newResults(2, &testTrie{}, &testTrie{}, nil)
+	results.keepAllTraces = true
+	newTrace := func(name string) *tracer.Trace {
+		return &tracer.Trace{
+			TestName: name,
+			Request:  &http.Request{Method: http.MethodPost, URL: &url.URL{Path: "/foo"}, Header: http.Header{}},
+		}
+	}
+	results.setOutcome("Suite/passes", false, nil)
+	results.setOutcome("Suite/fails", false, errors.New("oops"))
+	results.traces.Put("Suite/passes", newTrace("Suite/passes"))
+	results.traces.Put("Suite/fails", newTrace("Suite/fails"))
+
+	dir := t.TempDir()
+	require.NoError(t, results.writeTraces(dir, true))
+	_, err := os.Stat(filepath.Join(dir, "Suite", "passes.har"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	data, err := os.ReadFile(filepath.Join(dir, "Suite", "fails.har"))
+	require.NoError(t, err)
+	var har struct {
+		Log struct {
+			Entries []struct {
+				TestName string `json:"_testName"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	require.NoError(t, json.Unmarshal(data, &har))
+	require.Len(t, har.Log.Entries, 1)
+	assert.Equal(t, "Suite/fails", har.Log.Entries[0].TestName)
+
+	require.NoError(t, results.writeTraces(dir, false))
+	_, err = os.Stat(filepath.Join(dir, "Suite", "passes.har"))
+	assert.NoError(t, err)
+}
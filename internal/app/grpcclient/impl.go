@@ -150,6 +150,9 @@ func (i *invoker) serverStream(
 
 	totalRcvd := 0
 	for {
+		// Sleep for any specified delay
+		time.Sleep(time.Duration(ccr.ReceiveDelayMs) * time.Millisecond)
+
 		msg, err := stream.Recv()
 		totalRcvd++
 		if err != nil {
@@ -285,6 +288,9 @@ func (i *invoker) bidiStream(
 			break
 		}
 		if fullDuplex {
+			// Sleep for any specified delay
+			time.Sleep(time.Duration(ccr.ReceiveDelayMs) * time.Millisecond)
+
 			// If this is a full duplex stream, receive a response for each request
 			msg, err := stream.Recv()
 			if err != nil {
@@ -345,6 +351,9 @@ func (i *invoker) bidiStream(
 
 	// Receive any remaining responses
 	for {
+		// Sleep for any specified delay
+		time.Sleep(time.Duration(ccr.ReceiveDelayMs) * time.Millisecond)
+
 		msg, err := stream.Recv()
 		if err != nil {
 			if !errors.Is(err, io.EOF) {
@@ -0,0 +1,66 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcserver
+
+import (
+	"connectrpc.com/conformance/internal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// newHealthServer creates a health server that reports the ConformanceService
+// as serving. It also supports the scripted status transitions for Watch
+// calls that are described in the documentation for supports_health in the
+// Features message.
+func newHealthServer() healthv1.HealthServer {
+	server := health.NewServer()
+	server.SetServingStatus(internal.ConformanceServiceName, healthv1.HealthCheckResponse_SERVING)
+	return &healthServer{Server: server}
+}
+
+type healthServer struct {
+	*health.Server
+}
+
+func (s *healthServer) Watch(req *healthv1.HealthCheckRequest, stream healthv1.Health_WatchServer) error {
+	ctx := stream.Context()
+	var transitionsHeader string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(internal.HealthTransitionsHeader); len(vals) > 0 {
+			transitionsHeader = vals[0]
+		}
+	}
+	if transitionsHeader == "" {
+		return s.Server.Watch(req, stream)
+	}
+	transitions, err := internal.ParseHealthTransitions(transitionsHeader)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	initial := healthv1.HealthCheckResponse_SERVICE_UNKNOWN
+	if resp, err := s.Server.Check(ctx, req); err == nil {
+		initial = resp.Status
+	}
+	for _, sendStatus := range append([]healthv1.HealthCheckResponse_ServingStatus{initial}, transitions...) {
+		if err := stream.Send(&healthv1.HealthCheckResponse{Status: sendStatus}); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return status.FromContextError(ctx.Err()).Err()
+}
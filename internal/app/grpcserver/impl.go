@@ -152,6 +152,10 @@ func (c *conformanceServiceServer) ServerStream(
 
 	responseDefinition := req.ResponseDefinition
 	if responseDefinition != nil { //nolint:nestif
+		responseData, err := internal.StreamResponseData(responseDefinition)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
 		headerMD := grpcutil.ConvertProtoHeaderToMetadata(responseDefinition.ResponseHeaders)
 		// Immediately send the headers on the stream so that metadata can be read by the client
 		if err := stream.SendHeader(headerMD); err != nil {
@@ -161,7 +165,7 @@ func (c *conformanceServiceServer) ServerStream(
 		trailerMD := grpcutil.ConvertProtoHeaderToMetadata(responseDefinition.ResponseTrailers)
 		stream.SetTrailer(trailerMD)
 
-		for _, data := range responseDefinition.ResponseData {
+		for _, data := range responseData {
 			resp := &conformancev1.ServerStreamResponse{
 				Payload: &conformancev1.ConformancePayload{
 					Data: data,
@@ -207,6 +211,7 @@ func (c *conformanceServiceServer) BidiStream(
 ) error {
 	ctx := stream.Context()
 	var responseDefinition *conformancev1.StreamResponseDefinition
+	var responseData [][]byte
 	fullDuplex := false
 	firstRecv := true
 	respNum := 0
@@ -239,6 +244,10 @@ func (c *conformanceServiceServer) BidiStream(
 			firstRecv = false
 
 			if responseDefinition != nil {
+				responseData, err = internal.StreamResponseData(responseDefinition)
+				if err != nil {
+					return status.Error(codes.InvalidArgument, err.Error())
+				}
 				headerMD := grpcutil.ConvertProtoHeaderToMetadata(responseDefinition.ResponseHeaders)
 				// Immediately send the headers on the stream so that metadata can be read by the client
 				if err := stream.SendHeader(headerMD); err != nil {
@@ -252,14 +261,14 @@ func (c *conformanceServiceServer) BidiStream(
 
 		// If fullDuplex, then send one of the desired responses each time we get a message on the stream
 		if fullDuplex {
-			if responseDefinition == nil || respNum >= len(responseDefinition.ResponseData) {
+			if responseDefinition == nil || respNum >= len(responseData) {
 				// If there are no responses to send, then break the receive loop
 				// and throw the error specified
 				break
 			}
 			resp := &conformancev1.BidiStreamResponse{
 				Payload: &conformancev1.ConformancePayload{
-					Data: responseDefinition.ResponseData[respNum],
+					Data: responseData[respNum],
 				},
 			}
 			var requestInfo *conformancev1.ConformancePayload_RequestInfo
@@ -291,13 +300,13 @@ func (c *conformanceServiceServer) BidiStream(
 	// both scenarios of half duplex (we haven't sent any responses yet) or full duplex
 	// where the requested responses are greater than the total requests.
 	if responseDefinition != nil { //nolint:nestif
-		for ; respNum < len(responseDefinition.ResponseData); respNum++ {
+		for ; respNum < len(responseData); respNum++ {
 			if err := stream.Context().Err(); err != nil {
 				return err
 			}
 			resp := &conformancev1.BidiStreamResponse{
 				Payload: &conformancev1.ConformancePayload{
-					Data: responseDefinition.ResponseData[respNum],
+					Data: responseData[respNum],
 				},
 			}
 			// Only set the request info if this is the first response being sent back
@@ -368,6 +377,15 @@ func parseUnaryResponseDefinition(
 			payload.Data = respType.ResponseData
 		}
 		return payload, nil
+	case *conformancev1.UnaryResponseDefinition_GeneratedResponseData:
+		data, err := internal.GenerateData(respType.GeneratedResponseData)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return &conformancev1.ConformancePayload{
+			RequestInfo: reqInfo,
+			Data:        data,
+		}, nil
 	default:
 		return nil, status.Errorf(
 			codes.InvalidArgument,
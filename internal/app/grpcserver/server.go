@@ -34,6 +34,8 @@ import (
 	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
 	_ "google.golang.org/grpc/encoding/gzip" // enables GZIP compression w/ gRPC
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 // Run runs the server according to server config read from the 'in' reader.
@@ -117,6 +119,9 @@ func createServer(recvLimit uint32) (*grpc.Server, error) { //nolint:unparam
 		grpc.MaxRecvMsgSize(int(recvLimit)),
 	)
 	conformancev1.RegisterConformanceServiceServer(server, NewConformanceServiceServer())
+	// Registers both v1 and v1alpha versions of server reflection.
+	reflection.Register(server)
+	healthv1.RegisterHealthServer(server, newHealthServer())
 	return server, nil
 }
 
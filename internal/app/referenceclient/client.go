@@ -82,6 +82,11 @@ func run(ctx context.Context, referenceMode bool, args []string, inReader io.Rea
 	codec := internal.NewCodec(*json)
 	decoder := codec.NewDecoder(inReader)
 	encoder := codec.NewEncoder(outWriter)
+	if !referenceMode && internal.HandshakeRequested() {
+		if err := internal.RespondToHandshake(decoder, encoder, internal.ReferenceHandshakeResponse(args[0])); err != nil {
+			return err
+		}
+	}
 	var encoderMu sync.Mutex
 
 	var failure atomic.Pointer[error]
@@ -168,6 +173,9 @@ func invoke(ctx context.Context, req *conformancev1.ClientCompatRequest, referen
 	if err != nil {
 		return nil, err
 	}
+	if tlsConf != nil && trace != nil {
+		tlsConf.KeyLogWriter = trace.KeyLog
+	}
 	var scheme string
 	if tlsConf != nil {
 		scheme = "https://"
@@ -183,6 +191,8 @@ func invoke(ctx context.Context, req *conformancev1.ClientCompatRequest, referen
 	// TODO - We should cache the transports here so that we're not creating one for each
 	// test case
 	var transport http.RoundTripper
+	// The tracer that receives traces from the wire capture transport.
+	wireTrace := trace
 	switch req.HttpVersion {
 	case conformancev1.HTTPVersion_HTTP_VERSION_1:
 		if tlsConf != nil {
@@ -194,8 +204,7 @@ func invoke(ctx context.Context, req *conformancev1.ClientCompatRequest, referen
 		}
 		transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
 			resp, err := tx.RoundTrip(req)
-			if resp != nil &&
-				strings.HasSuffix(req.URL.Path, conformancev1connect.ConformanceServiceBidiStreamProcedure) {
+			if resp != nil && isBidiProcedure(req.URL.Path) {
 				// To force support for bidirectional RPC over HTTP 1.1 (for half-duplex testing),
 				// we "trick" the client into thinking this is HTTP/2. We have to do this because
 				// otherwise, connect-go refuses to support bidi streams over HTTP 1.1.
@@ -206,29 +215,94 @@ func invoke(ctx context.Context, req *conformancev1.ClientCompatRequest, referen
 	case conformancev1.HTTPVersion_HTTP_VERSION_2:
 		if tlsConf != nil {
 			tlsConf.NextProtos = []string{"h2"}
-			transport = &http.Transport{
+		}
+		// Recording frames or capturing bytes requires tracing the
+		// connections, instead of relying on the tracing middleware.
+		// Like with HTTP/3 below, the middleware is still used to
+		// capture wire details, but it must not also report to the
+		// tracer.
+		traceConns := referenceMode && trace.TracesHTTP2Conns()
+		if traceConns {
+			wireTrace = nil
+		}
+		faults := &connFaultTransport{}
+		if tlsConf != nil {
+			tx := &http.Transport{
 				DisableCompression: true,
 				TLSClientConfig:    tlsConf,
 				ForceAttemptHTTP2:  true,
+				DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					conn, err := (&net.Dialer{}).DialContext(ctx, network, addr)
+					if err != nil {
+						return nil, err
+					}
+					return faults.wrapConn(conn), nil
+				},
+			}
+			// Either way, HTTP/2 is provided by the same implementation as
+			// without TLS, whose errors the connFaultTransport recognizes.
+			if traceConns {
+				traceHTTP2Conns(tx, trace)
+			} else if _, err := http2.ConfigureTransports(tx); err != nil {
+				return nil, err
 			}
+			transport = tx
 		} else {
 			transport = &http2.Transport{
 				DisableCompression: true,
 				AllowHTTP:          true,
 				DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
-					return (&net.Dialer{}).DialContext(ctx, network, addr)
+					conn, err := (&net.Dialer{}).DialContext(ctx, network, addr)
+					if err != nil {
+						return nil, err
+					}
+					conn = faults.wrapConn(conn)
+					if !traceConns {
+						return conn, nil
+					}
+					return tracer.TracingHTTP2Conn(conn, false, trace), nil
 				},
 			}
 		}
+		faults.transport = transport
+		transport = faults
+		if internal.RequestsGoAway(req.RequestMessages) {
+			// The HTTP/2 transport transparently retries a request that the
+			// server refuses via a GOAWAY frame, with exponential backoff, if
+			// it can rewind the request body. When the test case has the
+			// server send a GOAWAY, the reference client must fail such RPCs
+			// promptly instead, so it hides the means to rewind.
+			h2Transport := transport
+			transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				noRewind := *req
+				noRewind.GetBody = nil
+				return h2Transport.RoundTrip(&noRewind)
+			})
+		}
 	case conformancev1.HTTPVersion_HTTP_VERSION_3:
 		if tlsConf == nil {
 			return nil, errors.New("HTTP/3 indicated in request but no TLS info provided")
 		}
-		transport = &contextFixTransport{http3.RoundTripper{
+		h3Transport := &contextFixTransport{http3.RoundTripper{
 			DisableCompression: true,
 			TLSClientConfig:    tlsConf,
 			QUICConfig:         &quic.Config{MaxIdleTimeout: 20 * time.Second, KeepAlivePeriod: 5 * time.Second},
 		}}
+		if referenceMode && trace != nil {
+			// Tracing the QUIC connections, instead of relying on the tracing
+			// middleware, shows the actual HTTP/3 headers and stream resets.
+			// The middleware is still used below to capture wire details, but
+			// it must not also report to the tracer.
+			wireTrace = nil
+			h3Transport.Dial = func(ctx context.Context, addr string, tlsCfg *tls.Config, cfg *quic.Config) (quic.EarlyConnection, error) {
+				conn, err := quic.DialAddrEarly(ctx, addr, tlsCfg, cfg)
+				if err != nil {
+					return nil, err
+				}
+				return tracer.TracingHTTP3Conn(conn, false, trace), nil
+			}
+		}
+		transport = h3Transport
 	case conformancev1.HTTPVersion_HTTP_VERSION_UNSPECIFIED:
 		return nil, errors.New("an HTTP version must be specified")
 	}
@@ -236,20 +310,35 @@ func invoke(ctx context.Context, req *conformancev1.ClientCompatRequest, referen
 	if referenceMode {
 		// Wrap the transport with a wire interceptor and an optional tracer.
 		// The wire interceptor wraps a TracingRoundTripper and intercepts values on the
-		// wire using the tracer framework. Note that 'trace' could be nil, in which case,
+		// wire using the tracer framework. Note that 'wireTrace' could be nil, in which case,
 		// any error traces will simply not be printed. The trace itself will still be built.
-		transport = newWireCaptureTransport(transport, trace)
+		transport = newWireCaptureTransport(transport, wireTrace)
+		if trace != nil && trace.PropagateTraceContext && req.RawRequest == nil {
+			// This wraps the tracing transport, so that traces include
+			// the trace context that is sent with each request. Raw
+			// requests are sent exactly as defined in the test case.
+			transport = &traceparentTransport{
+				transport:   transport,
+				traceparent: tracer.NewTraceContext().Traceparent(),
+			}
+		}
 		if req.RawRequest != nil {
 			transport = &rawRequestSender{transport: transport, rawRequest: req.RawRequest}
 		}
 	}
+	if req.Protocol == conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT {
+		// This wraps the tracing transport, so that traces show what
+		// is actually on the wire.
+		transport = &grpcWebTextTransport{transport: transport}
+	}
 
 	// Create client options based on protocol of the implementation
 	clientOptions := []connect.ClientOption{connect.WithHTTPGet()}
 	switch req.Protocol {
 	case conformancev1.Protocol_PROTOCOL_GRPC:
 		clientOptions = append(clientOptions, connect.WithGRPC())
-	case conformancev1.Protocol_PROTOCOL_GRPC_WEB:
+	case conformancev1.Protocol_PROTOCOL_GRPC_WEB, conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT:
+		// For the text variant, the transport translates to and from binary.
 		clientOptions = append(clientOptions, connect.WithGRPCWeb())
 	case conformancev1.Protocol_PROTOCOL_CONNECT:
 		// Do nothing
@@ -329,14 +418,36 @@ func invoke(ctx context.Context, req *conformancev1.ClientCompatRequest, referen
 		clientOptions = append(clientOptions, connect.WithReadMaxBytes(int(req.MessageReceiveLimit)))
 	}
 
+	if referenceMode && req.RawRequest.GetVerb() == http.MethodOptions {
+		// A CORS preflight request does not go to the actual RPC service.
+		return newInvoker(transport, referenceMode, serverURL, clientOptions).preflight(ctx, req)
+	}
+
 	switch req.GetService() {
 	case conformancev1connect.ConformanceServiceName:
-		return newInvoker(transport, referenceMode, serverURL, clientOptions).Invoke(ctx, req)
+		invoker := newInvoker(transport, referenceMode, serverURL, clientOptions)
+		result, err := invoker.Invoke(ctx, req)
+		// Follow-up RPCs use the same invoker, so that they share the
+		// transport and its connections with the first one.
+		for i := uint32(0); i < req.FollowUpRpcs && err == nil && result.GetError() == nil; i++ {
+			result, err = invoker.Invoke(ctx, req)
+		}
+		return result, err
+	case reflectionV1ServiceName, reflectionV1AlphaServiceName:
+		return newInvoker(transport, referenceMode, serverURL, clientOptions).invokeReflection(ctx, req)
+	case healthServiceName:
+		return newInvoker(transport, referenceMode, serverURL, clientOptions).invokeHealth(ctx, req)
 	default:
 		return nil, fmt.Errorf("service name %s is not a valid service", req.GetService())
 	}
 }
 
+func isBidiProcedure(path string) bool {
+	return strings.HasSuffix(path, conformancev1connect.ConformanceServiceBidiStreamProcedure) ||
+		strings.HasSuffix(path, "/"+reflectionV1ServiceName+"/"+reflectionMethodName) ||
+		strings.HasSuffix(path, "/"+reflectionV1AlphaServiceName+"/"+reflectionMethodName)
+}
+
 func createTLSConfig(req *conformancev1.ClientCompatRequest) (*tls.Config, error) {
 	if req.ServerTlsCert == nil {
 		if req.ClientTlsCreds != nil {
@@ -347,6 +458,50 @@ func createTLSConfig(req *conformancev1.ClientCompatRequest) (*tls.Config, error
 	return internal.NewClientTLSConfig(req.ServerTlsCert, req.ClientTlsCreds.GetCert(), req.ClientTlsCreds.GetKey())
 }
 
+// traceHTTP2Conns configures the given transport to apply tracing to the
+// connections that it uses for HTTP/2. The tracing is applied after the TLS
+// handshake, so that the tracer sees the clear-text frames.
+func traceHTTP2Conns(transport *http.Transport, trace *tracer.Tracer) {
+	h2Transport := &http2.Transport{DisableCompression: true}
+	transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{
+		"h2": func(_ string, conn *tls.Conn) http.RoundTripper {
+			clientConn, err := h2Transport.NewClientConn(tracer.TracingHTTP2Conn(conn, false, trace))
+			if err != nil {
+				_ = conn.Close()
+				return roundTripperFunc(func(*http.Request) (*http.Response, error) {
+					return nil, err
+				})
+			}
+			return tracingHTTP2ClientConn{clientConn}
+		},
+	}
+}
+
+// tracingHTTP2ClientConn is an HTTP/2 client connection on a traced TLS
+// connection. Once the connection can no longer be used, such as after the
+// server sends a GOAWAY frame, it reports that to the HTTP transport, so
+// that the transport discards it and dials a new connection.
+type tracingHTTP2ClientConn struct {
+	*http2.ClientConn
+}
+
+func (c tracingHTTP2ClientConn) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !c.CanTakeNewRequest() {
+		return nil, errNoCachedConn{}
+	}
+	return c.ClientConn.RoundTrip(req)
+}
+
+// errNoCachedConn tells the HTTP transport that a connection cannot be used.
+// The IsHTTP2NoCachedConnError method is what the transport looks for.
+type errNoCachedConn struct{}
+
+func (errNoCachedConn) IsHTTP2NoCachedConnError() {}
+
+func (errNoCachedConn) Error() string {
+	return "http2: no cached connection was available"
+}
+
 // contextFixTransport wraps an HTTP/3 transport so that context errors can be correctly
 // classified by the connect-go framework. This is a work-around until a fix
 // can be implemented in connect-go and/or quic-go.
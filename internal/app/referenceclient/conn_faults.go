@@ -0,0 +1,166 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/connect"
+	"golang.org/x/net/http2"
+)
+
+// connFaultTransport reports errors caused by connection-level faults, like
+// a broken connection or a truncated response body, with the codes that the
+// conformance tests expect. Without this, connect-go reports most of them as
+// "unknown", or as "invalid_argument" if the fault happens while reading a
+// message envelope.
+//
+// A broken connection, including one that the server closes after sending a
+// GOAWAY frame, is reported as "unavailable". A response body that ends
+// early, while the connection is still usable, is reported as "internal".
+type connFaultTransport struct {
+	transport http.RoundTripper
+	// Set once a read from any of the transport's connections fails,
+	// as when the server closes or resets the connection.
+	connBroken atomic.Bool
+}
+
+// wrapConn wraps a connection used by the transport, so that the transport
+// can tell when the connection breaks.
+func (t *connFaultTransport) wrapConn(conn net.Conn) net.Conn {
+	return &faultDetectingConn{Conn: conn, broken: &t.connBroken}
+}
+
+func (t *connFaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &connFaultBody{
+		body:       resp.Body,
+		connBroken: &t.connBroken,
+		enveloped:  isEnvelopedContentType(resp.Header.Get("Content-Type")),
+	}
+	return resp, nil
+}
+
+// isEnvelopedContentType returns true if the given response content type
+// is for a body that consists of enveloped messages, which the client can
+// tell has been truncated. Since gRPC-Web text responses are base64-encoded,
+// they are excluded: a truncated body is instead caught when it is decoded.
+func isEnvelopedContentType(contentType string) bool {
+	if strings.HasPrefix(contentType, "application/connect+") {
+		return true
+	}
+	return strings.HasPrefix(contentType, "application/grpc") &&
+		!internal.IsGRPCWebTextContentType(contentType)
+}
+
+type faultDetectingConn struct {
+	net.Conn
+	broken *atomic.Bool
+}
+
+func (c *faultDetectingConn) Read(data []byte) (int, error) {
+	n, err := c.Conn.Read(data)
+	if err != nil {
+		c.broken.Store(true)
+	}
+	return n, err
+}
+
+// connFaultBody is a response body that translates errors caused by
+// connection-level faults into errors with the appropriate codes.
+type connFaultBody struct {
+	body       io.ReadCloser
+	connBroken *atomic.Bool
+	enveloped  bool
+	// The envelope prefix of the current message, which is read once
+	// prefixLen reaches its size.
+	prefix    [5]byte
+	prefixLen int
+	// The number of bytes of the current message that are yet to be read.
+	remaining uint32
+}
+
+func (b *connFaultBody) Read(data []byte) (int, error) {
+	n, err := b.body.Read(data)
+	if b.enveloped {
+		b.track(data[:n])
+	}
+	switch {
+	case err == nil:
+		return n, nil
+	case errors.Is(err, io.EOF):
+		if b.prefixLen > 0 || b.remaining > 0 {
+			// The stream ended normally, but in the middle of a message.
+			err = connect.NewError(connect.CodeInternal, errors.New("response body ended in the middle of a message"))
+		}
+		return n, err
+	default:
+		return n, b.classify(err)
+	}
+}
+
+func (b *connFaultBody) Close() error {
+	return b.body.Close()
+}
+
+// track keeps track of where the given response data falls in the envelopes
+// of the response body.
+func (b *connFaultBody) track(data []byte) {
+	for len(data) > 0 {
+		if b.remaining > 0 {
+			skip := min(b.remaining, uint32(len(data)))
+			b.remaining -= skip
+			data = data[skip:]
+			continue
+		}
+		copied := copy(b.prefix[b.prefixLen:], data)
+		b.prefixLen += copied
+		data = data[copied:]
+		if b.prefixLen == len(b.prefix) {
+			b.remaining = binary.BigEndian.Uint32(b.prefix[1:])
+			b.prefixLen = 0
+		}
+	}
+}
+
+func (b *connFaultBody) classify(err error) error {
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	var goAwayErr http2.GoAwayError
+	switch {
+	case errors.As(err, &goAwayErr), b.connBroken.Load():
+		return connect.NewError(connect.CodeUnavailable, err)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		// The response ended before the declared content length, but the
+		// connection is fine, so the server must have ended it early.
+		return connect.NewError(connect.CodeInternal, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"sync/atomic"
+	"syscall"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+)
+
+func TestConnFaultBody(t *testing.T) {
+	t.Parallel()
+
+	// Two complete messages, with three and zero bytes of data.
+	messages := []byte{0, 0, 0, 0, 3, 'a', 'b', 'c', 0, 0, 0, 0, 0}
+	testCases := []struct {
+		name         string
+		data         []byte
+		enveloped    bool
+		err          error
+		connBroken   bool
+		expectedCode connect.Code // zero if no error is expected
+	}{
+		{
+			name:      "complete messages",
+			data:      messages,
+			enveloped: true,
+			err:       io.EOF,
+		},
+		{
+			name:         "ends in message data",
+			data:         messages[:7],
+			enveloped:    true,
+			err:          io.EOF,
+			expectedCode: connect.CodeInternal,
+		},
+		{
+			name:         "ends in message prefix",
+			data:         messages[:10],
+			enveloped:    true,
+			err:          io.EOF,
+			expectedCode: connect.CodeInternal,
+		},
+		{
+			name: "unenveloped",
+			data: messages[:7],
+			err:  io.EOF,
+		},
+		{
+			name:         "short of content length",
+			data:         []byte("abc"),
+			err:          io.ErrUnexpectedEOF,
+			expectedCode: connect.CodeInternal,
+		},
+		{
+			name:         "connection closed",
+			data:         []byte("abc"),
+			err:          io.ErrUnexpectedEOF,
+			connBroken:   true,
+			expectedCode: connect.CodeUnavailable,
+		},
+		{
+			name:         "connection reset",
+			data:         messages[:7],
+			enveloped:    true,
+			err:          &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+			connBroken:   true,
+			expectedCode: connect.CodeUnavailable,
+		},
+		{
+			name:         "goaway",
+			data:         messages[:7],
+			enveloped:    true,
+			err:          http2.GoAwayError{ErrCode: http2.ErrCodeInternal},
+			expectedCode: connect.CodeUnavailable,
+		},
+		{
+			name:         "already classified",
+			data:         messages[:7],
+			enveloped:    true,
+			err:          connect.NewError(connect.CodeDataLoss, errors.New("oops")),
+			connBroken:   true,
+			expectedCode: connect.CodeDataLoss,
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			var connBroken atomic.Bool
+			connBroken.Store(testCase.connBroken)
+			body := &connFaultBody{
+				body:       io.NopCloser(io.MultiReader(bytes.NewReader(testCase.data), errReader{testCase.err})),
+				connBroken: &connBroken,
+				enveloped:  testCase.enveloped,
+			}
+			data, err := io.ReadAll(body)
+			assert.Equal(t, testCase.data, data)
+			if testCase.expectedCode == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, testCase.expectedCode, connect.CodeOf(err))
+		})
+	}
+}
+
+func TestIsEnvelopedContentType(t *testing.T) {
+	t.Parallel()
+	assert.True(t, isEnvelopedContentType("application/grpc"))
+	assert.True(t, isEnvelopedContentType("application/grpc+json"))
+	assert.True(t, isEnvelopedContentType("application/grpc-web+proto"))
+	assert.True(t, isEnvelopedContentType("application/connect+proto"))
+	assert.False(t, isEnvelopedContentType("application/grpc-web-text+proto"))
+	assert.False(t, isEnvelopedContentType("application/proto"))
+	assert.False(t, isEnvelopedContentType("application/json"))
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,139 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+// grpcWebExposedHeaders are the response headers that must be exposed to
+// browser clients for the gRPC-Web protocol, when present. They appear in
+// the headers of "trailers-only" responses. The Connect protocol does not
+// have any such headers: its status is always conveyed in the response body.
+//
+//nolint:gochecknoglobals
+var grpcWebExposedHeaders = []string{"grpc-status", "grpc-message", "grpc-status-details-bin"}
+
+// preflight sends a CORS preflight request, which is described by the raw
+// request in req. Since the response has no body, this is done without
+// using an RPC client. The response headers and status are recorded in
+// the result, and the CORS headers are verified via examineWireDetails.
+func (i *invoker) preflight(
+	ctx context.Context,
+	req *conformancev1.ClientCompatRequest,
+) (*conformancev1.ClientResponseResult, error) {
+	if req.TimeoutMs != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*req.TimeoutMs)*time.Millisecond)
+		defer cancel()
+	}
+	ctx = i.withWireCapture(ctx)
+	// The transport replaces this request with the raw request. This only
+	// provides the scheme and host of the server.
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, i.baseURL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	result := &conformancev1.ClientResponseResult{}
+	resp, err := i.httpClient.Transport.RoundTrip(httpReq)
+	if err != nil {
+		result.Error = internal.ConvertErrorToProtoError(err)
+		return result, nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	result.ResponseHeaders = internal.ConvertToProtoHeader(resp.Header)
+	result.HttpStatusCode, result.Feedback = i.examineWireDetails(ctx, result.ResponseHeaders, nil)
+	return result, nil
+}
+
+// checkCORS verifies the CORS response headers for the given request, if it
+// is a cross-origin request (i.e. it has an "Origin" header). Any problems
+// are reported to the given printer.
+func checkCORS(req *http.Request, resp *http.Response, printer internal.Printer) {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return
+	}
+	allowOrigin := resp.Header.Get("Access-Control-Allow-Origin")
+	if allowOrigin != "*" && allowOrigin != origin {
+		printer.Printf("CORS: response header Access-Control-Allow-Origin is %q but should allow origin %q", allowOrigin, origin)
+	}
+
+	if req.Method == http.MethodOptions {
+		if resp.StatusCode/100 != 2 {
+			printer.Printf("CORS: preflight response has HTTP status %d but should be 2xx", resp.StatusCode)
+		}
+		switch method := req.Header.Get("Access-Control-Request-Method"); method {
+		case "", http.MethodGet, http.MethodHead, http.MethodPost:
+			// CORS-safelisted methods need not be listed.
+		default:
+			if !corsHeaderCovers(resp.Header.Values("Access-Control-Allow-Methods"), method, true) {
+				printer.Printf("CORS: preflight response header Access-Control-Allow-Methods does not include %q", method)
+			}
+		}
+		allowHeaders := resp.Header.Values("Access-Control-Allow-Headers")
+		for _, hdr := range splitCORSHeader(req.Header.Values("Access-Control-Request-Headers")) {
+			if !corsHeaderCovers(allowHeaders, hdr, false) {
+				printer.Printf("CORS: preflight response header Access-Control-Allow-Headers does not include %q", hdr)
+			}
+		}
+		return
+	}
+
+	if !internal.IsGRPCWebTextContentType(req.Header.Get("Content-Type")) &&
+		!internal.IsGRPCWebBinaryContentType(req.Header.Get("Content-Type")) {
+		return
+	}
+	exposeHeaders := resp.Header.Values("Access-Control-Expose-Headers")
+	for _, hdr := range grpcWebExposedHeaders {
+		if resp.Header.Get(hdr) != "" && !corsHeaderCovers(exposeHeaders, hdr, false) {
+			printer.Printf("CORS: response header Access-Control-Expose-Headers does not include %q", hdr)
+		}
+	}
+}
+
+// corsHeaderCovers returns true if the given CORS response header values,
+// which are comma-separated lists, include the given item. A wildcard
+// value of "*" covers all items. (Browsers do not treat "*" as a wildcard
+// for credentialed requests, but the requests sent by the reference client
+// never include credentials.)
+func corsHeaderCovers(vals []string, item string, caseSensitive bool) bool {
+	for _, val := range splitCORSHeader(vals) {
+		if val == "*" || val == item || (!caseSensitive && strings.EqualFold(val, item)) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitCORSHeader(vals []string) []string {
+	var items []string
+	for _, val := range vals {
+		for _, item := range strings.Split(val, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
@@ -0,0 +1,145 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"net/http"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCORS(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		method           string
+		reqHeaders       http.Header
+		status           int
+		respHeaders      http.Header
+		expectedFeedback []string
+	}{
+		{
+			name:   "not cross-origin",
+			method: http.MethodPost,
+			status: http.StatusOK,
+		},
+		{
+			name:   "preflight ok",
+			method: http.MethodOptions,
+			reqHeaders: http.Header{
+				"Origin":                         []string{"https://example.com"},
+				"Access-Control-Request-Method":  []string{"PUT"},
+				"Access-Control-Request-Headers": []string{"content-type,Connect-Protocol-Version"},
+			},
+			status: http.StatusNoContent,
+			respHeaders: http.Header{
+				"Access-Control-Allow-Origin":  []string{"https://example.com"},
+				"Access-Control-Allow-Methods": []string{"GET, PUT"},
+				"Access-Control-Allow-Headers": []string{"Content-Type", "connect-protocol-version"},
+			},
+		},
+		{
+			name:   "preflight wildcards",
+			method: http.MethodOptions,
+			reqHeaders: http.Header{
+				"Origin":                         []string{"https://example.com"},
+				"Access-Control-Request-Method":  []string{"PUT"},
+				"Access-Control-Request-Headers": []string{"content-type,connect-protocol-version"},
+			},
+			status: http.StatusOK,
+			respHeaders: http.Header{
+				"Access-Control-Allow-Origin":  []string{"*"},
+				"Access-Control-Allow-Methods": []string{"*"},
+				"Access-Control-Allow-Headers": []string{"*"},
+			},
+		},
+		{
+			name:   "preflight missing everything",
+			method: http.MethodOptions,
+			reqHeaders: http.Header{
+				"Origin":                         []string{"https://example.com"},
+				"Access-Control-Request-Method":  []string{"PUT"},
+				"Access-Control-Request-Headers": []string{"content-type,connect-protocol-version"},
+			},
+			status: http.StatusForbidden,
+			respHeaders: http.Header{
+				"Access-Control-Allow-Methods": []string{"put"},
+				"Access-Control-Allow-Headers": []string{"content-type"},
+			},
+			expectedFeedback: []string{
+				`Access-Control-Allow-Origin is "" but should allow origin "https://example.com"`,
+				`preflight response has HTTP status 403`,
+				`Access-Control-Allow-Methods does not include "PUT"`,
+				`Access-Control-Allow-Headers does not include "connect-protocol-version"`,
+			},
+		},
+		{
+			name:   "grpc-web headers exposed",
+			method: http.MethodPost,
+			reqHeaders: http.Header{
+				"Origin":       []string{"https://example.com"},
+				"Content-Type": []string{"application/grpc-web+proto"},
+			},
+			status: http.StatusOK,
+			respHeaders: http.Header{
+				"Access-Control-Allow-Origin":   []string{"https://example.com"},
+				"Access-Control-Expose-Headers": []string{"Grpc-Status,Grpc-Message"},
+				"Grpc-Status":                   []string{"8"},
+				"Grpc-Message":                  []string{"oops"},
+			},
+		},
+		{
+			name:   "grpc-web headers not exposed",
+			method: http.MethodPost,
+			reqHeaders: http.Header{
+				"Origin":       []string{"https://example.com"},
+				"Content-Type": []string{"application/grpc-web-text"},
+			},
+			status: http.StatusOK,
+			respHeaders: http.Header{
+				"Access-Control-Allow-Origin":   []string{"https://example.com"},
+				"Access-Control-Expose-Headers": []string{"Grpc-Status"},
+				"Grpc-Status":                   []string{"8"},
+				"Grpc-Message":                  []string{"oops"},
+			},
+			expectedFeedback: []string{
+				`Access-Control-Expose-Headers does not include "grpc-message"`,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			req := &http.Request{Method: testCase.method, Header: testCase.reqHeaders}
+			if req.Header == nil {
+				req.Header = http.Header{}
+			}
+			resp := &http.Response{StatusCode: testCase.status, Header: testCase.respHeaders}
+			if resp.Header == nil {
+				resp.Header = http.Header{}
+			}
+			printer := &internal.SimplePrinter{}
+			checkCORS(req, resp, printer)
+			assert.Len(t, printer.Messages, len(testCase.expectedFeedback))
+			for i := 0; i < len(printer.Messages) && i < len(testCase.expectedFeedback); i++ {
+				assert.Contains(t, printer.Messages[i], testCase.expectedFeedback[i])
+			}
+		})
+	}
+}
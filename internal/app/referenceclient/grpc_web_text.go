@@ -0,0 +1,76 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/connect"
+)
+
+// grpcWebTextTransport adds support for the "grpc-web-text" variant of
+// gRPC-Web, which connect-go does not support. Binary gRPC-Web requests
+// are converted to text by base64-encoding the body, one chunk per write,
+// the same way a streaming client would encode it. Text responses are
+// decoded and presented to the client as binary gRPC-Web responses.
+type grpcWebTextTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *grpcWebTextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	contentType := req.Header.Get("Content-Type")
+	if internal.IsGRPCWebBinaryContentType(contentType) {
+		req = req.Clone(req.Context())
+		req.Header.Set("Content-Type", internal.GRPCWebBinaryToTextContentType(contentType))
+		if req.Body != nil && req.Body != http.NoBody {
+			req.Body = internal.NewGRPCWebTextEncodingReader(req.Body)
+			req.ContentLength = -1
+			req.GetBody = nil
+		}
+	}
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respContentType := resp.Header.Get("Content-Type")
+	if internal.IsGRPCWebTextContentType(respContentType) {
+		resp.Header.Set("Content-Type", internal.GRPCWebTextToBinaryContentType(respContentType))
+		resp.Body = &grpcWebTextResponseBody{body: internal.NewGRPCWebTextDecodingReader(resp.Body)}
+		resp.ContentLength = -1
+	}
+	return resp, nil
+}
+
+// grpcWebTextResponseBody reports malformed response data with an "internal"
+// error code, like other malformed responses. Without this, connect-go would
+// report it as a protocol error with an "invalid argument" code.
+type grpcWebTextResponseBody struct {
+	body io.ReadCloser
+}
+
+func (r *grpcWebTextResponseBody) Read(data []byte) (int, error) {
+	n, err := r.body.Read(data)
+	if errors.Is(err, internal.ErrInvalidGRPCWebText) {
+		err = connect.NewError(connect.CodeInternal, err)
+	}
+	return n, err
+}
+
+func (r *grpcWebTextResponseBody) Close() error {
+	return r.body.Close()
+}
@@ -0,0 +1,168 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/connect"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const healthServiceName = "grpc.health.v1.Health"
+
+// invokeHealth invokes the gRPC health checking service. The responses
+// are recorded in the result's response messages.
+func (i *invoker) invokeHealth(
+	ctx context.Context,
+	req *conformancev1.ClientCompatRequest,
+) (*conformancev1.ClientResponseResult, error) {
+	if len(req.RequestMessages) != 1 {
+		return nil, fmt.Errorf("calls to %s must specify exactly one request message", req.GetService())
+	}
+	healthReq := &healthv1.HealthCheckRequest{}
+	if err := req.RequestMessages[0].UnmarshalTo(healthReq); err != nil {
+		return nil, err
+	}
+	if req.TimeoutMs != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*req.TimeoutMs)*time.Millisecond)
+		defer cancel()
+	}
+	request := connect.NewRequest(healthReq)
+	internal.AddHeaders(req.RequestHeaders, request.Header())
+
+	switch req.GetMethod() {
+	case "Check":
+		return i.healthCheck(ctx, req, request)
+	case "Watch":
+		return i.healthWatch(ctx, req, request)
+	default:
+		return nil, fmt.Errorf("method name %s does not exist on service %s", req.GetMethod(), req.GetService())
+	}
+}
+
+func (i *invoker) healthCheck(
+	ctx context.Context,
+	req *conformancev1.ClientCompatRequest,
+	request *connect.Request[healthv1.HealthCheckRequest],
+) (*conformancev1.ClientResponseResult, error) {
+	if req.StreamType != conformancev1.StreamType_STREAM_TYPE_UNARY {
+		return nil, fmt.Errorf("method %s of service %s is unary but request indicates %s",
+			req.GetMethod(), req.GetService(), req.StreamType)
+	}
+	timing, err := internal.GetCancelTiming(req.Cancel)
+	if err != nil {
+		return nil, err
+	}
+	if timing.AfterCloseSendMs >= 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		time.AfterFunc(time.Duration(timing.AfterCloseSendMs)*time.Millisecond, cancel)
+	}
+	ctx = i.withWireCapture(ctx)
+
+	client := connect.NewClient[healthv1.HealthCheckRequest, healthv1.HealthCheckResponse](
+		i.httpClient, i.baseURL+healthv1.Health_Check_FullMethodName, i.opts...,
+	)
+	result := &conformancev1.ClientResponseResult{}
+	resp, err := client.CallUnary(ctx, request)
+	if err != nil {
+		connectErr := internal.ConvertErrorToConnectError(err)
+		result.ResponseTrailers = internal.ConvertToProtoHeader(connectErr.Meta())
+		result.Error = internal.ConvertConnectToProtoError(connectErr)
+	} else {
+		result.ResponseHeaders = internal.ConvertToProtoHeader(resp.Header())
+		result.ResponseTrailers = internal.ConvertToProtoHeader(resp.Trailer())
+		respAny, err := anypb.New(resp.Msg)
+		if err != nil {
+			return nil, err
+		}
+		result.ResponseMessages = append(result.ResponseMessages, respAny)
+	}
+	result.HttpStatusCode, result.Feedback = i.examineWireDetails(ctx, result.ResponseHeaders, result.ResponseTrailers)
+	return result, nil
+}
+
+func (i *invoker) healthWatch(
+	ctx context.Context,
+	req *conformancev1.ClientCompatRequest,
+	request *connect.Request[healthv1.HealthCheckRequest],
+) (result *conformancev1.ClientResponseResult, _ error) {
+	if req.StreamType != conformancev1.StreamType_STREAM_TYPE_SERVER_STREAM {
+		return nil, fmt.Errorf("method %s of service %s is a server stream but request indicates %s",
+			req.GetMethod(), req.GetService(), req.StreamType)
+	}
+	timing, err := internal.GetCancelTiming(req.Cancel)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ctx = i.withWireCapture(ctx)
+
+	client := connect.NewClient[healthv1.HealthCheckRequest, healthv1.HealthCheckResponse](
+		i.httpClient, i.baseURL+healthv1.Health_Watch_FullMethodName, i.opts...,
+	)
+	stream, err := client.CallServerStream(ctx, request)
+	if err != nil {
+		connectErr := internal.ConvertErrorToConnectError(err)
+		return &conformancev1.ClientResponseResult{
+			ResponseHeaders: internal.ConvertToProtoHeader(connectErr.Meta()),
+			Error:           internal.ConvertConnectToProtoError(connectErr),
+		}, nil
+	}
+	result = &conformancev1.ClientResponseResult{}
+	defer func() {
+		// Always make sure stream is closed on exit.
+		closeErr := stream.Close()
+		if result == nil {
+			return
+		}
+		if result.Error == nil && closeErr != nil {
+			result.Error = internal.ConvertErrorToProtoError(closeErr)
+		}
+		result.ResponseHeaders = internal.ConvertToProtoHeader(stream.ResponseHeader())
+		result.ResponseTrailers = internal.ConvertToProtoHeader(stream.ResponseTrailer())
+		result.HttpStatusCode, result.Feedback = i.examineWireDetails(ctx, result.ResponseHeaders, result.ResponseTrailers)
+	}()
+
+	if timing.AfterCloseSendMs >= 0 {
+		time.Sleep(time.Duration(timing.AfterCloseSendMs) * time.Millisecond)
+		cancel()
+	}
+
+	for stream.Receive() {
+		respAny, err := anypb.New(stream.Msg())
+		if err != nil {
+			return nil, err
+		}
+		result.ResponseMessages = append(result.ResponseMessages, respAny)
+		// Since a watch does not end on its own, test cases will
+		// usually cancel after some number of responses.
+		if len(result.ResponseMessages) == timing.AfterNumResponses {
+			cancel()
+		}
+	}
+	if stream.Err() != nil {
+		result.Error = internal.ConvertErrorToProtoError(stream.Err())
+	}
+	return result, nil
+}
@@ -35,19 +35,28 @@ const clientName = "connectconformance-referenceclient"
 type invoker struct {
 	client        conformancev1connect.ConformanceServiceClient
 	referenceMode bool
+	// These are retained for creating clients for services
+	// other than the ConformanceService.
+	httpClient *http.Client
+	baseURL    string
+	opts       []connect.ClientOption
 }
 
 // Creates a new invoker around a ConformanceServiceClient.
 func newInvoker(transport http.RoundTripper, referenceMode bool, url *url.URL, opts []connect.ClientOption) *invoker {
 	opts = append(opts, connect.WithInterceptors(userAgentClientInterceptor{}))
+	httpClient := &http.Client{Transport: transport}
 	client := conformancev1connect.NewConformanceServiceClient(
-		&http.Client{Transport: transport},
+		httpClient,
 		url.String(),
 		opts...,
 	)
 	return &invoker{
 		client:        client,
 		referenceMode: referenceMode,
+		httpClient:    httpClient,
+		baseURL:       url.String(),
+		opts:          opts,
 	}
 }
 
@@ -273,7 +282,12 @@ func (i *invoker) serverStream(
 	}
 
 	totalRcvd := 0
-	for stream.Receive() {
+	for {
+		// Sleep for any specified delay
+		time.Sleep(time.Duration(req.ReceiveDelayMs) * time.Millisecond)
+		if !stream.Receive() {
+			break
+		}
 		totalRcvd++
 		// If the call was successful, get the returned payloads
 		// and the headers and trailers
@@ -434,6 +448,9 @@ func (i *invoker) bidiStream(
 			break
 		}
 		if fullDuplex {
+			// Sleep for any specified delay
+			time.Sleep(time.Duration(req.ReceiveDelayMs) * time.Millisecond)
+
 			// If this is a full duplex stream, receive a response for each request
 			msg, err := stream.Receive()
 			if err != nil {
@@ -478,6 +495,9 @@ func (i *invoker) bidiStream(
 
 	// Receive any remaining responses
 	for {
+		// Sleep for any specified delay
+		time.Sleep(time.Duration(req.ReceiveDelayMs) * time.Millisecond)
+
 		msg, err := stream.Receive()
 		if err != nil {
 			if !errors.Is(err, io.EOF) {
@@ -0,0 +1,273 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/connect"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	reflectionV1ServiceName      = "grpc.reflection.v1.ServerReflection"
+	reflectionV1AlphaServiceName = "grpc.reflection.v1alpha.ServerReflection"
+	reflectionMethodName         = "ServerReflectionInfo"
+)
+
+// invokeReflection invokes the server reflection service. Since the
+// responses do not contain a ConformancePayload, they are instead
+// normalized and recorded in the result's response messages.
+//
+// The request messages may be either version of ServerReflectionRequest,
+// regardless of the version of the service being invoked, since they
+// are wire compatible. Similarly, the normalized responses always use
+// the v1 message types.
+func (i *invoker) invokeReflection(
+	ctx context.Context,
+	req *conformancev1.ClientCompatRequest,
+) (*conformancev1.ClientResponseResult, error) {
+	if req.GetMethod() != reflectionMethodName {
+		return nil, fmt.Errorf("method name %s does not exist on service %s", req.GetMethod(), req.GetService())
+	}
+	switch req.StreamType {
+	case conformancev1.StreamType_STREAM_TYPE_HALF_DUPLEX_BIDI_STREAM,
+		conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM:
+	default:
+		return nil, fmt.Errorf("method %s of service %s is a bidi stream but request indicates %s",
+			req.GetMethod(), req.GetService(), req.StreamType)
+	}
+	if req.TimeoutMs != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*req.TimeoutMs)*time.Millisecond)
+		defer cancel()
+	}
+	procedure := "/" + req.GetService() + "/" + reflectionMethodName
+	if req.GetService() == reflectionV1AlphaServiceName {
+		return doReflection[reflectionv1alpha.ServerReflectionRequest, reflectionv1alpha.ServerReflectionResponse](ctx, req, i, procedure)
+	}
+	return doReflection[reflectionv1.ServerReflectionRequest, reflectionv1.ServerReflectionResponse](ctx, req, i, procedure)
+}
+
+func doReflection[ReqT, RespT any, Req pointerMessage[ReqT], Resp pointerMessage[RespT]](
+	ctx context.Context,
+	req *conformancev1.ClientCompatRequest,
+	inv *invoker,
+	procedure string,
+) (result *conformancev1.ClientResponseResult, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Convert all requests up front, to the v1 type for comparing to
+	// responses and to the actual type to send.
+	sentRequests := make([]*reflectionv1.ServerReflectionRequest, len(req.RequestMessages))
+	rpcRequests := make([]*ReqT, len(req.RequestMessages))
+	for i, msg := range req.RequestMessages {
+		if !strings.HasSuffix(string(msg.MessageName()), ".ServerReflectionRequest") {
+			return nil, fmt.Errorf("request message #%d: expecting ServerReflectionRequest, got %s", i+1, msg.MessageName())
+		}
+		sentRequests[i] = &reflectionv1.ServerReflectionRequest{}
+		if err := proto.Unmarshal(msg.Value, sentRequests[i]); err != nil {
+			return nil, fmt.Errorf("request message #%d: %w", i+1, err)
+		}
+		rpcRequests[i] = new(ReqT)
+		if err := proto.Unmarshal(msg.Value, Req(rpcRequests[i])); err != nil {
+			return nil, fmt.Errorf("request message #%d: %w", i+1, err)
+		}
+	}
+
+	result = &conformancev1.ClientResponseResult{}
+	ctx = inv.withWireCapture(ctx)
+
+	client := connect.NewClient[ReqT, RespT](inv.httpClient, inv.baseURL+procedure, inv.opts...)
+	stream := client.CallBidiStream(ctx)
+	defer func() {
+		// Always make sure stream is closed on exit.
+		closeErr := stream.CloseResponse()
+		if err != nil {
+			return
+		}
+		if result.Error == nil && closeErr != nil {
+			result.Error = internal.ConvertErrorToProtoError(closeErr)
+		}
+		result.ResponseHeaders = internal.ConvertToProtoHeader(stream.ResponseHeader())
+		result.ResponseTrailers = internal.ConvertToProtoHeader(stream.ResponseTrailer())
+		var feedback []string
+		result.HttpStatusCode, feedback = inv.examineWireDetails(ctx, result.ResponseHeaders, result.ResponseTrailers)
+		result.Feedback = append(result.Feedback, feedback...)
+	}()
+
+	// Add the specified request headers to the request
+	internal.AddHeaders(req.RequestHeaders, stream.RequestHeader())
+
+	fullDuplex := req.StreamType == conformancev1.StreamType_STREAM_TYPE_FULL_DUPLEX_BIDI_STREAM
+	receive := func() bool {
+		msg, err := stream.Receive()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				result.Error = internal.ConvertErrorToProtoError(err)
+			}
+			return false
+		}
+		var resp reflectionv1.ServerReflectionResponse
+		if err := convertReflectionMessage(Resp(msg), &resp); err != nil {
+			result.Feedback = append(result.Feedback, fmt.Sprintf("response #%d: %v", len(result.ResponseMessages)+1, err))
+			return false
+		}
+		var sent *reflectionv1.ServerReflectionRequest
+		if idx := len(result.ResponseMessages); idx < len(sentRequests) {
+			sent = sentRequests[idx]
+		}
+		normalized, feedback := normalizeReflectionResponse(sent, &resp)
+		for _, msg := range feedback {
+			result.Feedback = append(result.Feedback, fmt.Sprintf("response #%d: %s", len(result.ResponseMessages)+1, msg))
+		}
+		normalizedAny, err := anypb.New(normalized)
+		if err != nil {
+			result.Feedback = append(result.Feedback, fmt.Sprintf("response #%d: %v", len(result.ResponseMessages)+1, err))
+			return false
+		}
+		result.ResponseMessages = append(result.ResponseMessages, normalizedAny)
+		return true
+	}
+
+	for i, msg := range rpcRequests {
+		// Sleep for any specified delay
+		time.Sleep(time.Duration(req.RequestDelayMs) * time.Millisecond)
+
+		if err := stream.Send(msg); err != nil && errors.Is(err, io.EOF) {
+			// Receive to get the actual error
+			if _, recvErr := stream.Receive(); recvErr != nil {
+				result.Error = internal.ConvertErrorToProtoError(recvErr)
+			} else {
+				result.Error = internal.ConvertErrorToProtoError(err)
+			}
+			result.NumUnsentRequests = int32(len(rpcRequests) - i)
+			return result, nil
+		}
+		if fullDuplex && !receive() {
+			return result, nil
+		}
+	}
+
+	// Sends are done, close the send side of the stream
+	if err := stream.CloseRequest(); err != nil {
+		return nil, err
+	}
+
+	// Receive any remaining responses
+	for {
+		if !receive() {
+			break
+		}
+	}
+	return result, nil
+}
+
+// convertReflectionMessage converts between versions of the reflection
+// messages, which are wire compatible.
+func convertReflectionMessage(src, dest proto.Message) error {
+	data, err := proto.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(data, dest)
+}
+
+// normalizeReflectionResponse reduces the given response to just its
+// response message, stripping out details that may vary across correct
+// server implementations. So it returns a *ListServiceResponse,
+// *ExtensionNumberResponse, or *ErrorResponse. File descriptor responses
+// are instead returned as a *descriptorpb.FileDescriptorSet.
+//
+// Any problems with the response that would not be apparent in the
+// normalized message are returned as feedback.
+func normalizeReflectionResponse(
+	sent *reflectionv1.ServerReflectionRequest,
+	resp *reflectionv1.ServerReflectionResponse,
+) (proto.Message, []string) {
+	var feedback []string
+	if sent == nil {
+		feedback = append(feedback, "received more responses than requests sent")
+	} else if resp.OriginalRequest != nil && !proto.Equal(sent, resp.OriginalRequest) {
+		feedback = append(feedback, "original_request in response does not match request sent")
+	}
+
+	switch msg := resp.MessageResponse.(type) {
+	case *reflectionv1.ServerReflectionResponse_ListServicesResponse:
+		// Omit services in the "grpc" package, like reflection and
+		// health, since it will vary whether those are present.
+		services := make([]*reflectionv1.ServiceResponse, 0, len(msg.ListServicesResponse.GetService()))
+		for _, svc := range msg.ListServicesResponse.GetService() {
+			if strings.HasPrefix(svc.Name, "grpc.") {
+				continue
+			}
+			services = append(services, &reflectionv1.ServiceResponse{Name: svc.Name})
+		}
+		sort.Slice(services, func(i, j int) bool {
+			return services[i].Name < services[j].Name
+		})
+		return &reflectionv1.ListServiceResponse{Service: services}, feedback
+	case *reflectionv1.ServerReflectionResponse_FileDescriptorResponse:
+		// Servers may also include any dependencies, which may be elided
+		// if already sent on the stream. So only the first file, which
+		// is the one requested, is retained. And it is stripped down to
+		// just its name, package, and imports, since the full contents
+		// could vary slightly across compiler versions.
+		files := &descriptorpb.FileDescriptorSet{}
+		for i, data := range msg.FileDescriptorResponse.GetFileDescriptorProto() {
+			var file descriptorpb.FileDescriptorProto
+			if err := proto.Unmarshal(data, &file); err != nil {
+				feedback = append(feedback, fmt.Sprintf("file descriptor #%d could not be parsed: %v", i+1, err))
+				continue
+			}
+			if i == 0 {
+				files.File = append(files.File, &descriptorpb.FileDescriptorProto{
+					Name:       file.Name,
+					Package:    file.Package,
+					Dependency: file.Dependency,
+				})
+			}
+		}
+		return files, feedback
+	case *reflectionv1.ServerReflectionResponse_AllExtensionNumbersResponse:
+		numbers := append([]int32(nil), msg.AllExtensionNumbersResponse.GetExtensionNumber()...)
+		sort.Slice(numbers, func(i, j int) bool {
+			return numbers[i] < numbers[j]
+		})
+		return &reflectionv1.ExtensionNumberResponse{
+			BaseTypeName:    msg.AllExtensionNumbersResponse.GetBaseTypeName(),
+			ExtensionNumber: numbers,
+		}, feedback
+	case *reflectionv1.ServerReflectionResponse_ErrorResponse:
+		// The message is informational and will vary across servers.
+		return &reflectionv1.ErrorResponse{ErrorCode: msg.ErrorResponse.GetErrorCode()}, feedback
+	default:
+		feedback = append(feedback, "response has no message_response set")
+		return &reflectionv1.ServerReflectionResponse{}, feedback
+	}
+}
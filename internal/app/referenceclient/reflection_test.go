@@ -0,0 +1,142 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestNormalizeReflectionResponse(t *testing.T) {
+	t.Parallel()
+
+	listReq := &reflectionv1.ServerReflectionRequest{
+		MessageRequest: &reflectionv1.ServerReflectionRequest_ListServices{},
+	}
+	fileReq := &reflectionv1.ServerReflectionRequest{
+		MessageRequest: &reflectionv1.ServerReflectionRequest_FileContainingSymbol{
+			FileContainingSymbol: "google.protobuf.Any",
+		},
+	}
+	anyFile, err := proto.Marshal(protodesc.ToFileDescriptorProto((&anypb.Any{}).ProtoReflect().Descriptor().ParentFile()))
+	require.NoError(t, err)
+	emptyFile, err := proto.Marshal(protodesc.ToFileDescriptorProto((&emptypb.Empty{}).ProtoReflect().Descriptor().ParentFile()))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name             string
+		sent             *reflectionv1.ServerReflectionRequest
+		response         *reflectionv1.ServerReflectionResponse
+		expected         proto.Message
+		expectedFeedback []string
+	}{
+		{
+			name: "list services",
+			sent: listReq,
+			response: &reflectionv1.ServerReflectionResponse{
+				OriginalRequest: listReq,
+				MessageResponse: &reflectionv1.ServerReflectionResponse_ListServicesResponse{
+					ListServicesResponse: &reflectionv1.ListServiceResponse{
+						Service: []*reflectionv1.ServiceResponse{
+							{Name: "grpc.reflection.v1.ServerReflection"},
+							{Name: "foo.bar.Service"},
+							{Name: "abc.Service"},
+							{Name: "grpc.health.v1.Health"},
+						},
+					},
+				},
+			},
+			expected: &reflectionv1.ListServiceResponse{
+				Service: []*reflectionv1.ServiceResponse{
+					{Name: "abc.Service"},
+					{Name: "foo.bar.Service"},
+				},
+			},
+		},
+		{
+			name: "file descriptors",
+			sent: fileReq,
+			response: &reflectionv1.ServerReflectionResponse{
+				MessageResponse: &reflectionv1.ServerReflectionResponse_FileDescriptorResponse{
+					FileDescriptorResponse: &reflectionv1.FileDescriptorResponse{
+						FileDescriptorProto: [][]byte{anyFile, emptyFile},
+					},
+				},
+			},
+			expected: &descriptorpb.FileDescriptorSet{
+				File: []*descriptorpb.FileDescriptorProto{
+					{
+						Name:    proto.String("google/protobuf/any.proto"),
+						Package: proto.String("google.protobuf"),
+					},
+				},
+			},
+		},
+		{
+			name: "error",
+			sent: fileReq,
+			response: &reflectionv1.ServerReflectionResponse{
+				OriginalRequest: listReq,
+				MessageResponse: &reflectionv1.ServerReflectionResponse_ErrorResponse{
+					ErrorResponse: &reflectionv1.ErrorResponse{
+						ErrorCode:    5,
+						ErrorMessage: "not found",
+					},
+				},
+			},
+			expected: &reflectionv1.ErrorResponse{ErrorCode: 5},
+			expectedFeedback: []string{
+				"original_request in response does not match request sent",
+			},
+		},
+		{
+			name: "unexpected response",
+			response: &reflectionv1.ServerReflectionResponse{
+				MessageResponse: &reflectionv1.ServerReflectionResponse_FileDescriptorResponse{
+					FileDescriptorResponse: &reflectionv1.FileDescriptorResponse{
+						FileDescriptorProto: [][]byte{{0xff}},
+					},
+				},
+			},
+			expected: &descriptorpb.FileDescriptorSet{},
+			expectedFeedback: []string{
+				"received more responses than requests sent",
+				"file descriptor #1 could not be parsed",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			normalized, feedback := normalizeReflectionResponse(testCase.sent, testCase.response)
+			assert.Empty(t, cmp.Diff(testCase.expected, normalized, protocmp.Transform()))
+			require.Len(t, feedback, len(testCase.expectedFeedback))
+			for i := range feedback {
+				assert.Contains(t, feedback[i], testCase.expectedFeedback[i])
+			}
+		})
+	}
+}
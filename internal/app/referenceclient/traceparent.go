@@ -0,0 +1,40 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceclient
+
+import (
+	"net/http"
+
+	"connectrpc.com/conformance/internal/tracer"
+)
+
+// traceparentTransport propagates a W3C trace context with each request,
+// so that the telemetry of the server under test can be correlated with
+// the spans that the conformance runner exports for each test case. All
+// requests for a test case, including retries, use the same trace context.
+// A request that already has a "traceparent" header, such as from the
+// request headers in a test case, is sent as is.
+type traceparentTransport struct {
+	transport   http.RoundTripper
+	traceparent string
+}
+
+func (t *traceparentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get(tracer.TraceparentHeader) == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(tracer.TraceparentHeader, t.traceparent)
+	}
+	return t.transport.RoundTrip(req)
+}
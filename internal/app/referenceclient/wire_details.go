@@ -178,6 +178,8 @@ func examineWireDetails(ctx context.Context, printer internal.Printer) (statusCo
 		return 0, false
 	}
 
+	checkCORS(trace.Request, trace.Response, printer)
+
 	// Check end-stream and/or error JSON data in the response.
 	contentType := trace.Response.Header.Get("content-type")
 	switch {
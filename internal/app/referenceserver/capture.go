@@ -0,0 +1,380 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"connectrpc.com/conformance/internal"
+	"golang.org/x/net/http2"
+)
+
+const (
+	// The number of most recent bytes received from the client that
+	// are included when reporting malformed data.
+	captureReportLen = 1024
+	// The number of bytes of an HTTP/1.1 request that are examined to
+	// find the test case name when the request can't be parsed.
+	captureRequestLen = 64 * 1024
+)
+
+// captureConnKey is used to store the connection that carries a request
+// in the request context. The value type will be *captureConn.
+type captureConnKey struct{}
+
+// captureListener wraps accepted connections so that the raw bytes sent
+// by the client are captured. See captureConn.
+type captureListener struct {
+	net.Listener
+	errPrinter internal.Printer
+}
+
+func (l captureListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return newCaptureConn(conn, l.errPrinter), nil
+}
+
+// captureConn wraps a server connection and captures the raw bytes sent by
+// the client, before they are parsed by net/http. If the client sends data
+// that can't be parsed, the server typically responds with an error and
+// closes the connection without ever invoking a handler, so the client just
+// sees an error and the reference server can't otherwise provide feedback.
+//
+// To detect this, the server's response is also examined. For HTTP/1.1,
+// this looks for the error responses that net/http writes when it can't
+// parse a request. For HTTP/2, this looks for GOAWAY frames with an error
+// code and RST_STREAM frames with a PROTOCOL_ERROR code. When one is found,
+// the most recent bytes received are reported as feedback for the test
+// case, like other reference server checks. (Malformed request bodies in
+// HTTP/1.1, like bad chunked encoding, are instead detected when the handler
+// reads the body. See captureHandler.)
+//
+// The test case is identified from the "x-test-case-name" request header,
+// even if the request can't be parsed. For HTTP/1.1, the raw bytes of the
+// request are searched for the header. For HTTP/2, the frames are decoded
+// by the tracer, which provides them to observeHTTP2Frame along with the
+// test case name for their stream (see newHTTP2Conn). If the test case
+// can't be identified, the feedback is instead printed without a test case
+// name.
+type captureConn struct {
+	net.Conn
+	errPrinter internal.Printer
+
+	mu       sync.Mutex
+	reported bool
+	// The most recent bytes received, up to captureReportLen. For
+	// HTTP/1.1, this only includes bytes of the current request.
+	recent []byte
+	// Bytes received until it is known whether this is HTTP/2.
+	preface       []byte
+	protocolKnown bool
+	isHTTP2       bool
+
+	// For HTTP/1.1, the bytes of the current request, up to
+	// captureRequestLen, and the number of active handlers. When
+	// a handler finishes, the captured bytes are discarded on the
+	// next read. (If the client pipelines requests, the bytes of
+	// the next request may have already been read.)
+	request        []byte
+	activeHandlers int
+	requestDone    bool
+
+	// For HTTP/2, set when the reference server sends a GOAWAY
+	// frame because a test case asked for it.
+	sentGoAway bool
+}
+
+func newCaptureConn(conn net.Conn, errPrinter internal.Printer) *captureConn {
+	return &captureConn{Conn: conn, errPrinter: errPrinter}
+}
+
+func (c *captureConn) Read(data []byte) (int, error) {
+	n, err := c.Conn.Read(data)
+	if n > 0 {
+		c.mu.Lock()
+		c.trackReadLocked(data[:n])
+		c.mu.Unlock()
+	}
+	return n, err
+}
+
+func (c *captureConn) Write(data []byte) (int, error) {
+	c.mu.Lock()
+	c.trackWriteLocked(data)
+	c.mu.Unlock()
+	return c.Conn.Write(data)
+}
+
+// handlerStarted is called when a handler starts to process an HTTP/1.1
+// request that was received on this connection.
+func (c *captureConn) handlerStarted() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.activeHandlers++
+}
+
+// handlerFinished is called when a handler is done processing an HTTP/1.1
+// request. Any data received after this belongs to the next request.
+func (c *captureConn) handlerFinished() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.activeHandlers--
+	if c.activeHandlers == 0 {
+		c.requestDone = true
+	}
+}
+
+// goAwaySent is called when the reference server sends a GOAWAY frame
+// because a test case asked for it. After this, GOAWAY frames are not
+// reported as due to malformed data.
+func (c *captureConn) goAwaySent() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sentGoAway = true
+}
+
+// reportMalformed reports that the data received from the client, for the
+// given test case, could not be parsed for the given reason. Only the first
+// such problem on a connection is reported.
+func (c *captureConn) reportMalformed(testCaseName, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reportMalformedLocked(testCaseName, reason)
+}
+
+func (c *captureConn) reportMalformedLocked(testCaseName, reason string) {
+	if c.reported {
+		return
+	}
+	c.reported = true
+	msg := fmt.Sprintf("client sent malformed data: %s; last %d bytes received: %q", reason, len(c.recent), c.recent)
+	if testCaseName == "" {
+		c.errPrinter.Printf("%s (test case unknown; from %s)", msg, c.RemoteAddr())
+		return
+	}
+	c.errPrinter.PrefixPrintf(testCaseName, "%s", msg)
+}
+
+func (c *captureConn) trackReadLocked(data []byte) {
+	if c.requestDone {
+		c.requestDone = false
+		c.recent = c.recent[:0]
+		c.request = c.request[:0]
+	}
+	c.recent = appendRecent(c.recent, data)
+	if !c.protocolKnown {
+		need := len(http2.ClientPreface) - len(c.preface)
+		if need > len(data) {
+			need = len(data)
+		}
+		c.preface = append(c.preface, data[:need]...)
+		switch {
+		case string(c.preface) != http2.ClientPreface[:len(c.preface)]:
+			c.protocolKnown = true
+			// Not HTTP/2, so the preface bytes are part of the request.
+			c.request = append(c.request, c.preface...)
+			data = data[need:]
+		case len(c.preface) == len(http2.ClientPreface):
+			c.protocolKnown, c.isHTTP2 = true, true
+		default:
+			return
+		}
+	}
+	if c.isHTTP2 {
+		return
+	}
+	if room := captureRequestLen - len(c.request); room > 0 {
+		if len(data) > room {
+			data = data[:room]
+		}
+		c.request = append(c.request, data...)
+	}
+}
+
+func (c *captureConn) trackWriteLocked(data []byte) {
+	if !c.protocolKnown || c.isHTTP2 || c.activeHandlers > 0 {
+		return
+	}
+	if reason, ok := parseErrorResponse(data); ok {
+		c.reportMalformedLocked(findTestCaseName(c.request), reason)
+	}
+}
+
+// observeHTTP2Frame examines the frames sent by the server, to find those
+// that indicate the client sent malformed data. It is a
+// tracer.HTTP2FrameObserver.
+func (c *captureConn) observeHTTP2Frame(frame http2.Frame, isRequest bool, testCaseName string) {
+	if isRequest {
+		return
+	}
+	switch frame := frame.(type) {
+	case *http2.GoAwayFrame:
+		if frame.ErrCode == http2.ErrCodeNo {
+			return
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.sentGoAway {
+			return
+		}
+		reason := fmt.Sprintf("server sent GOAWAY with error code %v", frame.ErrCode)
+		if debug := frame.DebugData(); len(debug) > 0 {
+			reason += fmt.Sprintf(" (%q)", debug)
+		}
+		c.reportMalformedLocked(testCaseName, reason)
+	case *http2.RSTStreamFrame:
+		if frame.ErrCode != http2.ErrCodeProtocol {
+			return
+		}
+		c.reportMalformed(testCaseName, fmt.Sprintf("server reset stream %d with error code %v", frame.StreamID, frame.ErrCode))
+	}
+}
+
+// captureHandler tracks the requests handled on captured connections. For
+// HTTP/1.1, it also detects malformed request bodies, like those with bad
+// chunked encoding, which are only found when the handler reads the body.
+func captureHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		conn, ok := req.Context().Value(captureConnKey{}).(*captureConn)
+		if !ok || req.ProtoMajor != 1 {
+			handler.ServeHTTP(respWriter, req)
+			return
+		}
+		conn.handlerStarted()
+		defer conn.handlerFinished()
+		if req.Body != nil && req.Body != http.NoBody {
+			req.Body = &capturedBody{
+				ReadCloser:   req.Body,
+				conn:         conn,
+				testCaseName: req.Header.Get("x-test-case-name"),
+			}
+		}
+		handler.ServeHTTP(respWriter, req)
+	})
+}
+
+// capturedBody reports errors reading an HTTP/1.1 request body that are due
+// to malformed framing.
+type capturedBody struct {
+	io.ReadCloser
+	conn         *captureConn
+	testCaseName string
+}
+
+func (b *capturedBody) Read(data []byte) (int, error) {
+	n, err := b.ReadCloser.Read(data)
+	if err != nil && isMalformedBodyError(err) {
+		b.conn.reportMalformed(b.testCaseName, fmt.Sprintf("could not read request body: %v", err))
+	}
+	return n, err
+}
+
+// isMalformedBodyError returns true if the given error, from reading an
+// HTTP/1.1 request body, indicates that the body was not correctly framed.
+// The errors that net/http returns for bad chunked encoding are not
+// exported, so they are identified by their messages.
+func isMalformedBodyError(err error) bool {
+	return errors.Is(err, http.ErrLineTooLong) || strings.Contains(err.Error(), "chunk")
+}
+
+// parseErrorResponse checks whether the given data, written by an HTTP/1.1
+// server when no handler is active, is an error response that net/http
+// writes when it can't parse a request. If so, it returns the response's
+// status and body, which describes the problem.
+func parseErrorResponse(data []byte) (string, bool) {
+	// These responses are written all at once, like so:
+	//   HTTP/1.1 400 Bad Request
+	//   Content-Type: text/plain; charset=utf-8
+	//   Connection: close
+	//
+	//   400 Bad Request: malformed Host header
+	// Unlike responses written by handlers, they have no Date header.
+	const connectionClose = "\r\nConnection: close\r\n\r\n"
+	if !bytes.HasPrefix(data, []byte("HTTP/1.1 ")) || len(data) < 10 || (data[9] != '4' && data[9] != '5') {
+		return "", false
+	}
+	headers, body, ok := bytes.Cut(data, []byte("\r\n\r\n"))
+	if !ok || !bytes.HasSuffix(append(headers, "\r\n\r\n"...), []byte(connectionClose)) ||
+		bytes.Contains(bytes.ToLower(headers), []byte("\r\ndate:")) {
+		return "", false
+	}
+	if len(body) == 0 {
+		statusLine, _, _ := bytes.Cut(headers, []byte("\r\n"))
+		return string(statusLine[len("HTTP/1.1 "):]), true
+	}
+	return string(body), true
+}
+
+// findTestCaseName searches the given raw bytes of HTTP/1.1 requests for
+// the "x-test-case-name" header and returns its value. If there are several
+// such headers, as when the client pipelines requests, the last one is used.
+// It returns the empty string if there is no such header.
+func findTestCaseName(request []byte) string {
+	var testCaseName string
+	for _, line := range bytes.Split(request, []byte("\n")) {
+		name, value, ok := bytes.Cut(line, []byte(":"))
+		if ok && strings.EqualFold(string(name), "x-test-case-name") {
+			testCaseName = strings.TrimSpace(string(value))
+		}
+	}
+	return testCaseName
+}
+
+func appendRecent(recent, data []byte) []byte {
+	if len(data) >= captureReportLen {
+		return append(recent[:0], data[len(data)-captureReportLen:]...)
+	}
+	if excess := len(recent) + len(data) - captureReportLen; excess > 0 {
+		recent = append(recent[:0], recent[excess:]...)
+	}
+	return append(recent, data...)
+}
+
+// connWithCaptureContext stores the given connection in the given context
+// if it is a *captureConn. It is suitable for use as http.Server.ConnContext.
+func connWithCaptureContext(ctx context.Context, conn net.Conn) context.Context {
+	if captured, ok := conn.(*captureConn); ok {
+		return context.WithValue(ctx, captureConnKey{}, captured)
+	}
+	return ctx
+}
+
+// withTLSState sets the TLS connection state of requests received on
+// captured connections that use TLS. This is needed when TLS is applied
+// by the listener, beneath the capture, instead of by net/http, which then
+// can't tell that the connection uses TLS.
+func withTLSState(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		if conn, ok := req.Context().Value(captureConnKey{}).(*captureConn); ok && req.TLS == nil {
+			if tlsConn, ok := conn.Conn.(*tls.Conn); ok {
+				state := tlsConn.ConnectionState()
+				req.TLS = &state
+			}
+		}
+		handler.ServeHTTP(respWriter, req)
+	})
+}
@@ -0,0 +1,302 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+)
+
+func TestCaptureConn(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name       string
+		http2      bool
+		useTLS     bool
+		send       func(t *testing.T, conn net.Conn)
+		expectMsgs []string
+	}{
+		{
+			name: "http1 valid request",
+			send: func(t *testing.T, conn net.Conn) {
+				t.Helper()
+				sendHTTP1(t, conn, "GET / HTTP/1.1\r\nHost: test\r\nX-Test-Case-Name: foo\r\nConnection: close\r\n\r\n")
+			},
+		},
+		{
+			name: "http1 malformed header",
+			send: func(t *testing.T, conn net.Conn) {
+				t.Helper()
+				sendHTTP1(t, conn, "GET / HTTP/1.1\r\nHost: test\r\nX-Test-Case-Name: foo\r\nno colon here\r\n\r\n")
+			},
+			expectMsgs: []string{
+				`foo: client sent malformed data: 400 Bad Request; last 68 bytes received: "GET / HTTP/1.1\r\nHost: test\r\nX-Test-Case-Name: foo\r\nno colon here\r\n\r\n"`,
+			},
+		},
+		{
+			name: "http1 malformed header after valid request",
+			send: func(t *testing.T, conn net.Conn) {
+				t.Helper()
+				sendHTTP1(t, conn, "GET / HTTP/1.1\r\nHost: test\r\nX-Test-Case-Name: foo\r\n\r\n"+
+					"GET / HTTP/1.1\r\nHost: test\r\nX-Test-Case-Name: bar\r\nno colon here\r\n\r\n")
+			},
+			expectMsgs: []string{
+				`bar: client sent malformed data: 400 Bad Request; last 121 bytes received: "GET / HTTP/1.1\r\nHost: test\r\nX-Test-Case-Name: foo\r\n\r\nGET / HTTP/1.1\r\nHost: test\r\nX-Test-Case-Name: bar\r\nno colon here\r\n\r\n"`,
+			},
+		},
+		{
+			name: "http1 malformed chunked body",
+			send: func(t *testing.T, conn net.Conn) {
+				t.Helper()
+				sendHTTP1(t, conn, "POST / HTTP/1.1\r\nHost: test\r\nX-Test-Case-Name: foo\r\nTransfer-Encoding: chunked\r\n\r\nzz\r\n")
+			},
+			expectMsgs: []string{
+				`foo: client sent malformed data: could not read request body: invalid byte in chunk length; last 86 bytes received: "POST / HTTP/1.1\r\nHost: test\r\nX-Test-Case-Name: foo\r\nTransfer-Encoding: chunked\r\n\r\nzz\r\n"`,
+			},
+		},
+		{
+			name:   "http1 over tls valid request",
+			useTLS: true,
+			send: func(t *testing.T, conn net.Conn) {
+				t.Helper()
+				sendHTTP1(t, conn, "GET / HTTP/1.1\r\nHost: test\r\nX-Test-Case-Name: foo\r\nConnection: close\r\n\r\n")
+			},
+		},
+		{
+			name:   "http1 over tls malformed header",
+			useTLS: true,
+			send: func(t *testing.T, conn net.Conn) {
+				t.Helper()
+				sendHTTP1(t, conn, "GET / HTTP/1.1\r\nHost: test\r\nX-Test-Case-Name: foo\r\nno colon here\r\n\r\n")
+			},
+			expectMsgs: []string{
+				`foo: client sent malformed data: 400 Bad Request; last 68 bytes received: "GET / HTTP/1.1\r\nHost: test\r\nX-Test-Case-Name: foo\r\nno colon here\r\n\r\n"`,
+			},
+		},
+		{
+			name:  "http2 valid request",
+			http2: true,
+			send: func(t *testing.T, conn net.Conn) {
+				t.Helper()
+				framer := startHTTP2(t, conn)
+				readHTTP2Until(t, framer, func(frame http2.Frame) bool {
+					return frame.Header().StreamID == 1 && frame.Header().Flags.Has(http2.FlagDataEndStream)
+				})
+			},
+		},
+		{
+			name:  "http2 protocol error",
+			http2: true,
+			send: func(t *testing.T, conn net.Conn) {
+				t.Helper()
+				framer := startHTTP2(t, conn)
+				// DATA frames are not allowed on stream zero.
+				framer.AllowIllegalWrites = true
+				require.NoError(t, framer.WriteData(0, false, []byte("abc")))
+				readHTTP2Until(t, framer, func(frame http2.Frame) bool {
+					_, ok := frame.(*http2.GoAwayFrame)
+					return ok
+				})
+			},
+			expectMsgs: []string{
+				`foo: client sent malformed data: server sent GOAWAY with error code PROTOCOL_ERROR`,
+			},
+		},
+		{
+			name:  "http2 goaway requested by test case",
+			http2: true,
+			send: func(t *testing.T, conn net.Conn) {
+				t.Helper()
+				framer := startHTTP2(t, conn, hpack.HeaderField{Name: "x-goaway", Value: "1"})
+				readHTTP2Until(t, framer, func(frame http2.Frame) bool {
+					_, ok := frame.(*http2.GoAwayFrame)
+					return ok
+				})
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			printer := &syncPrinter{}
+			handler := captureHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if testCase.useTLS && r.TLS == nil {
+					printer.Printf("request has no TLS connection state")
+				}
+				if r.Header.Get("x-goaway") != "" {
+					_ = sendGoAway(r.Context(), &conformancev1.GoAway{ErrorCode: uint32(http2.ErrCodeProtocol)})
+					return
+				}
+				if _, err := io.Copy(io.Discard, r.Body); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				_, _ = io.WriteString(w, "ok")
+			}))
+			var tlsConf *tls.Config
+			if testCase.useTLS {
+				certBytes, keyBytes, err := internal.NewServerCert()
+				require.NoError(t, err)
+				cert, err := internal.ParseServerCert(certBytes, keyBytes)
+				require.NoError(t, err)
+				tlsConf, err = internal.NewServerTLSConfig(cert, tls.NoClientCert, nil)
+				require.NoError(t, err)
+			}
+			var server httpServer
+			var err error
+			if testCase.http2 {
+				server, err = newH2Server(handler, "127.0.0.1:0", tlsConf, nil, printer)
+			} else {
+				server, err = newH1Server(handler, "127.0.0.1:0", tlsConf, printer)
+			}
+			require.NoError(t, err)
+			go func() {
+				_ = server.Serve()
+			}()
+			t.Cleanup(func() {
+				_ = server.GracefulShutdown(time.Second)
+			})
+
+			var conn net.Conn
+			if testCase.useTLS {
+				conn, err = tls.Dial("tcp", server.Addr(), &tls.Config{
+					InsecureSkipVerify: true, //nolint:gosec // this is just a test
+					NextProtos:         []string{"http/1.1"},
+				})
+			} else {
+				conn, err = net.Dial("tcp", server.Addr())
+			}
+			require.NoError(t, err)
+			defer conn.Close()
+			require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+			testCase.send(t, conn)
+
+			msgs := printer.messages()
+			require.Len(t, msgs, len(testCase.expectMsgs))
+			for i, msg := range msgs {
+				assert.True(t, strings.HasPrefix(msg, testCase.expectMsgs[i]), "message %q should start with %q", msg, testCase.expectMsgs[i])
+			}
+		})
+	}
+}
+
+func TestParseErrorResponse(t *testing.T) {
+	t.Parallel()
+	reason, ok := parseErrorResponse([]byte("HTTP/1.1 431 Request Header Fields Too Large\r\nContent-Type: text/plain; charset=utf-8\r\nConnection: close\r\n\r\n431 Request Header Fields Too Large"))
+	assert.True(t, ok)
+	assert.Equal(t, "431 Request Header Fields Too Large", reason)
+	reason, ok = parseErrorResponse([]byte("HTTP/1.1 505 HTTP Version Not Supported\r\nConnection: close\r\n\r\n"))
+	assert.True(t, ok)
+	assert.Equal(t, "505 HTTP Version Not Supported", reason)
+	// Responses from handlers have a Date header.
+	_, ok = parseErrorResponse([]byte("HTTP/1.1 400 Bad Request\r\nDate: Mon, 19 Oct 2026 00:00:00 GMT\r\nConnection: close\r\n\r\nbad"))
+	assert.False(t, ok)
+	_, ok = parseErrorResponse([]byte("HTTP/1.1 200 OK\r\nConnection: close\r\n\r\n"))
+	assert.False(t, ok)
+}
+
+// sendHTTP1 sends the given raw request data on the given connection
+// and then reads responses until the server closes the connection.
+func sendHTTP1(t *testing.T, conn net.Conn, data string) {
+	t.Helper()
+	_, err := io.WriteString(conn, data)
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, conn)
+}
+
+// startHTTP2 sends the HTTP/2 connection preface and the headers
+// for a GET request on stream 1, which includes a test case name
+// and the given additional fields.
+func startHTTP2(t *testing.T, conn net.Conn, fields ...hpack.HeaderField) *http2.Framer {
+	t.Helper()
+	_, err := io.WriteString(conn, http2.ClientPreface)
+	require.NoError(t, err)
+	framer := http2.NewFramer(conn, bufio.NewReader(conn))
+	require.NoError(t, framer.WriteSettings())
+	var headers bytes.Buffer
+	encoder := hpack.NewEncoder(&headers)
+	for _, field := range append([]hpack.HeaderField{
+		{Name: ":method", Value: "GET"},
+		{Name: ":scheme", Value: "http"},
+		{Name: ":authority", Value: "test"},
+		{Name: ":path", Value: "/"},
+		{Name: "x-test-case-name", Value: "foo"},
+	}, fields...) {
+		require.NoError(t, encoder.WriteField(field))
+	}
+	require.NoError(t, framer.WriteHeaders(http2.HeadersFrameParam{
+		StreamID:      1,
+		BlockFragment: headers.Bytes(),
+		EndStream:     true,
+		EndHeaders:    true,
+	}))
+	return framer
+}
+
+// readHTTP2Until reads frames from the given framer until the
+// given function returns true.
+func readHTTP2Until(t *testing.T, framer *http2.Framer, done func(http2.Frame) bool) {
+	t.Helper()
+	for {
+		frame, err := framer.ReadFrame()
+		require.NoError(t, err)
+		if settings, ok := frame.(*http2.SettingsFrame); ok && !settings.IsAck() {
+			require.NoError(t, framer.WriteSettingsAck())
+		}
+		if done(frame) {
+			return
+		}
+	}
+}
+
+// syncPrinter is a thread-safe printer that stores the printed messages.
+type syncPrinter struct {
+	mu      sync.Mutex
+	printer internal.SimplePrinter
+}
+
+func (p *syncPrinter) Printf(msg string, args ...any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.printer.Printf(msg, args...)
+}
+
+func (p *syncPrinter) PrefixPrintf(prefix, msg string, args ...any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.printer.PrefixPrintf(prefix, msg, args...)
+}
+
+func (p *syncPrinter) messages() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.printer.Messages...)
+}
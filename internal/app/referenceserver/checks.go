@@ -38,6 +38,8 @@ const (
 	grpcContentTypePrefix          = grpcContentType + "+"
 	grpcWebContentType             = "application/grpc-web"
 	grpcWebContentTypePrefix       = grpcWebContentType + "+"
+	grpcWebTextContentType         = "application/grpc-web-text"
+	grpcWebTextContentTypePrefix   = grpcWebTextContentType + "+"
 	connectUnaryContentTypePrefix  = "application/"
 	connectStreamContentTypePrefix = "application/connect+"
 	connectContentTypePrefix       = connectUnaryContentTypePrefix
@@ -71,6 +73,9 @@ type timeoutContextKey struct{}
 func referenceServerChecks(handler http.Handler, errPrinter internal.Printer) http.HandlerFunc {
 	var callsMu sync.Mutex
 	calls := map[string]int{}
+	// The number of requests for each test case that were refused via a
+	// GOAWAY frame. The client is allowed to retry each of them.
+	refusals := map[string]int{}
 	return func(respWriter http.ResponseWriter, req *http.Request) {
 		testCaseName, ok := getTestCaseName(respWriter, req)
 		if !ok {
@@ -80,13 +85,25 @@ func referenceServerChecks(handler http.Handler, errPrinter internal.Printer) ht
 		}
 		feedback := &feedbackPrinter{p: errPrinter, testCaseName: testCaseName}
 
+		// The client is also allowed to send follow-up requests for
+		// the same test case, if the test case asks for them.
+		followUps, _ := strconv.Atoi(req.Header.Get("x-expect-follow-up-rpcs"))
 		callsMu.Lock()
 		count := calls[testCaseName]
 		calls[testCaseName] = count + 1
+		allowed := 1 + followUps + refusals[testCaseName]
 		callsMu.Unlock()
-		if count > 0 {
+		if count >= allowed {
 			feedback.Printf("client sent another request (#%d) for the same test case", count+1)
 		}
+		if conn, ok := req.Context().Value(http2ConnKey{}).(*http2Conn); ok && conn.startedAfterGoAway() {
+			feedback.Printf("client sent request on a connection after server sent GOAWAY")
+		}
+		req = req.WithContext(context.WithValue(req.Context(), goAwayRefusalKey{}, func() {
+			callsMu.Lock()
+			defer callsMu.Unlock()
+			refusals[testCaseName]++
+		}))
 
 		if httpVersion, ok := enumValue("x-expect-http-version", req.Header, conformancev1.HTTPVersion(0), feedback); ok {
 			checkHTTPVersion(httpVersion, req, feedback)
@@ -163,6 +180,8 @@ func checkProtocol(expected conformancev1.Protocol, req *http.Request, feedback
 	switch {
 	case contentType == grpcContentType || strings.HasPrefix(contentType, grpcContentTypePrefix):
 		actual = conformancev1.Protocol_PROTOCOL_GRPC
+	case contentType == grpcWebTextContentType || strings.HasPrefix(contentType, grpcWebTextContentTypePrefix):
+		actual = conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT
 	case contentType == grpcWebContentType || strings.HasPrefix(contentType, grpcWebContentTypePrefix):
 		actual = conformancev1.Protocol_PROTOCOL_GRPC_WEB
 	case strings.HasPrefix(contentType, connectContentTypePrefix) || req.Method == http.MethodGet:
@@ -209,10 +228,13 @@ func checkCodec(expected conformancev1.Codec, req *http.Request, feedback *feedb
 			feedback.Printf("encoding query parameter is missing")
 			return
 		}
-	case contentType == "application/grpc" || contentType == "application/grpc-web":
+	case contentType == "application/grpc" || contentType == "application/grpc-web" ||
+		contentType == "application/grpc-web-text":
 		actual = codecProto // these protocols default to proto if they have no "+codec" suffix
 	case strings.HasPrefix(contentType, "application/grpc+"):
 		actual = strings.TrimPrefix(contentType, "application/grpc+")
+	case strings.HasPrefix(contentType, "application/grpc-web-text+"):
+		actual = strings.TrimPrefix(contentType, "application/grpc-web-text+")
 	case strings.HasPrefix(contentType, "application/grpc-web+"):
 		actual = strings.TrimPrefix(contentType, "application/grpc-web+")
 	case strings.HasPrefix(contentType, "application/connect+"):
@@ -256,8 +278,10 @@ func checkCompression(expected conformancev1.Compression, req *http.Request, fee
 		var encodingHeader string
 		switch {
 		case contentType == grpcContentType || contentType == grpcWebContentType ||
+			contentType == grpcWebTextContentType ||
 			strings.HasPrefix(contentType, grpcContentTypePrefix) ||
-			strings.HasPrefix(contentType, grpcWebContentTypePrefix):
+			strings.HasPrefix(contentType, grpcWebContentTypePrefix) ||
+			strings.HasPrefix(contentType, grpcWebTextContentTypePrefix):
 			encodingHeader = "grpc-encoding"
 		case strings.HasPrefix(contentType, connectStreamContentTypePrefix):
 			encodingHeader = "connect-content-encoding"
@@ -360,7 +384,8 @@ func extractTimeout(headers http.Header, protocol conformancev1.Protocol, feedba
 			timeout = time.Duration(math.MaxInt64)
 		}
 		return timeout, true
-	case conformancev1.Protocol_PROTOCOL_GRPC, conformancev1.Protocol_PROTOCOL_GRPC_WEB:
+	case conformancev1.Protocol_PROTOCOL_GRPC, conformancev1.Protocol_PROTOCOL_GRPC_WEB,
+		conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT:
 		val, ok := getHeader(headers, grpcTimeoutHeader, feedback)
 		if !ok {
 			break
@@ -0,0 +1,377 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"connectrpc.com/conformance/internal"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/tracer"
+	"connectrpc.com/connect"
+	"golang.org/x/net/http2"
+)
+
+const frameHeaderLen = 9
+
+// http2ConnKey is used to store the connection that carries a request in
+// the request context. The value type will be *http2Conn.
+type http2ConnKey struct{}
+
+// goAwayRefusalKey is used to store a function in the request context that
+// is called when the request is refused via a GOAWAY frame. This allows a
+// retry of the refused request to be told apart from a duplicate request.
+// The value type will be func().
+type goAwayRefusalKey struct{}
+
+// sendGoAway sends a GOAWAY frame, as described by the given definition, on
+// the HTTP/2 connection that carries the RPC associated with the given context.
+//
+// If the definition excludes the RPC's stream, this function does not return.
+// Instead, it abandons the RPC by panicking with http.ErrAbortHandler. This
+// is done for every attempt, including retries, so the client must
+// eventually fail the RPC.
+func sendGoAway(ctx context.Context, def *conformancev1.GoAway) error {
+	conn, ok := ctx.Value(http2ConnKey{}).(*http2Conn)
+	if !ok {
+		return connect.NewError(connect.CodeInternal, errors.New("cannot send GOAWAY: connection does not support it"))
+	}
+	if def.ExcludeStream {
+		if refused, ok := ctx.Value(goAwayRefusalKey{}).(func()); ok {
+			refused()
+		}
+	}
+	if err := conn.goAway(http2.ErrCode(def.ErrorCode), def.ExcludeStream); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("cannot send GOAWAY: %w", err))
+	}
+	if def.ExcludeStream {
+		// We told the client this RPC was not processed, so we must not
+		// send a response for it.
+		panic(http.ErrAbortHandler) //nolint:forbidigo // this is how to abort an HTTP handler
+	}
+	return nil
+}
+
+// connWithHTTP2Context stores the given connection in the given context if it
+// is an *http2Conn. If the connection is captured, the *captureConn is also
+// stored. It is suitable for use as http.Server.ConnContext.
+func connWithHTTP2Context(ctx context.Context, conn net.Conn) context.Context {
+	if http2Conn, ok := conn.(*http2Conn); ok {
+		if http2Conn.captured != nil {
+			ctx = connWithCaptureContext(ctx, http2Conn.captured)
+		}
+		return context.WithValue(ctx, http2ConnKey{}, http2Conn)
+	}
+	return ctx
+}
+
+// http2Listener wraps accepted connections so that the reference server
+// can send GOAWAY frames on them. See newHTTP2Conn.
+type http2Listener struct {
+	net.Listener
+	trace       *tracer.Tracer
+	captureErrs internal.Printer
+}
+
+func (l http2Listener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return newHTTP2Conn(conn, l.trace, l.captureErrs), nil
+}
+
+// newHTTP2Conn wraps the given server connection, which must be clear-text,
+// so that the reference server can send GOAWAY frames on it. If trace is
+// non-nil, the connection is traced. If captureErrs is non-nil, the raw bytes
+// sent by the client are captured, and malformed data is reported to it. The
+// frames decoded by the tracer are used to identify malformed data, so the
+// connection is traced in that case, even if trace is nil.
+func newHTTP2Conn(conn net.Conn, trace *tracer.Tracer, captureErrs internal.Printer) *http2Conn {
+	var captured *captureConn
+	if captureErrs != nil {
+		captured = newCaptureConn(conn, captureErrs)
+		conn = tracer.TracingHTTP2ConnWithObserver(captured, true, trace, captured.observeHTTP2Frame)
+	} else if trace != nil {
+		conn = tracer.TracingHTTP2Conn(conn, true, trace)
+	}
+	return &http2Conn{Conn: conn, captured: captured}
+}
+
+// http2Conn wraps a server connection that uses HTTP/2, so that the
+// reference server can send GOAWAY frames on it, which is not otherwise
+// possible with net/http. It tracks frame boundaries in the data written,
+// so that frames can be injected without corrupting the data stream. It
+// also tracks the IDs of streams initiated by the client.
+type http2Conn struct {
+	net.Conn
+	// If non-nil, the connection that captures the bytes sent by
+	// the client, which is wrapped by Conn.
+	captured *captureConn
+
+	mu          sync.Mutex
+	readPreface []byte
+	notHTTP2    bool
+	readFrames  frameTracker
+	maxStreamID uint32
+
+	writeFrames       frameTracker
+	pending           []byte
+	closeAfterPending bool
+	goAwaySent        bool
+	lastStreamID      uint32
+}
+
+func (c *http2Conn) Read(data []byte) (int, error) {
+	n, err := c.Conn.Read(data)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.trackReadLocked(data[:n])
+	return n, err
+}
+
+func (c *http2Conn) Write(data []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.notHTTP2 {
+		return c.Conn.Write(data)
+	}
+	if c.pending == nil {
+		for remaining := data; len(remaining) > 0; {
+			n, _ := c.writeFrames.advance(remaining)
+			remaining = remaining[n:]
+		}
+		return c.Conn.Write(data)
+	}
+	// We have a frame to inject, so we must write up to the
+	// next frame boundary, then the frame, then the rest.
+	var total int
+	for len(data) > 0 {
+		n, _ := c.writeFrames.advance(data)
+		written, err := c.Conn.Write(data[:n])
+		total += written
+		if err != nil {
+			return total, err
+		}
+		data = data[n:]
+		if err := c.flushPendingLocked(); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// goAway sends a GOAWAY frame with the given error code. If excludeLatest
+// is true, the last stream ID in the frame is lower than the most recent
+// stream initiated by the client. If the error code is not NO_ERROR, the
+// connection is closed after the frame is sent.
+func (c *http2Conn) goAway(errCode http2.ErrCode, excludeLatest bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.notHTTP2 {
+		return errors.New("connection is not using HTTP/2 with prior knowledge")
+	}
+	if c.goAwaySent {
+		return errors.New("GOAWAY already sent")
+	}
+	lastStreamID := c.maxStreamID
+	if excludeLatest {
+		// Client-initiated stream IDs are odd, so the one
+		// before the latest is two less.
+		if lastStreamID >= 2 {
+			lastStreamID -= 2
+		} else {
+			lastStreamID = 0
+		}
+	}
+	var buf bytes.Buffer
+	if err := http2.NewFramer(&buf, nil).WriteGoAway(lastStreamID, errCode, nil); err != nil {
+		return err
+	}
+	if c.captured != nil {
+		// This GOAWAY is not due to malformed data.
+		c.captured.goAwaySent()
+	}
+	c.pending = buf.Bytes()
+	c.closeAfterPending = errCode != http2.ErrCodeNo
+	c.goAwaySent = true
+	c.lastStreamID = lastStreamID
+	return c.flushPendingLocked()
+}
+
+// startedAfterGoAway returns true if a GOAWAY frame has been sent and the
+// client has since initiated a stream that is not included in it.
+func (c *http2Conn) startedAfterGoAway() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.goAwaySent && c.maxStreamID > c.lastStreamID
+}
+
+func (c *http2Conn) flushPendingLocked() error {
+	if c.pending == nil || !c.writeFrames.atBoundary() {
+		return nil
+	}
+	_, err := c.Conn.Write(c.pending)
+	c.pending = nil
+	if err != nil {
+		return err
+	}
+	if c.closeAfterPending {
+		_ = c.Conn.Close()
+		return net.ErrClosed
+	}
+	return nil
+}
+
+func (c *http2Conn) trackReadLocked(data []byte) {
+	if c.notHTTP2 {
+		return
+	}
+	if len(c.readPreface) < len(http2.ClientPreface) {
+		need := len(http2.ClientPreface) - len(c.readPreface)
+		if len(data) < need {
+			need = len(data)
+		}
+		c.readPreface = append(c.readPreface, data[:need]...)
+		if string(c.readPreface) != http2.ClientPreface[:len(c.readPreface)] {
+			// Not a prior-knowledge HTTP/2 connection, so we can't
+			// inject frames into it.
+			c.notHTTP2 = true
+			return
+		}
+		data = data[need:]
+	}
+	for len(data) > 0 {
+		n, header := c.readFrames.advance(data)
+		data = data[n:]
+		if header != nil && header.Type == http2.FrameHeaders && header.StreamID > c.maxStreamID {
+			c.maxStreamID = header.StreamID
+		}
+	}
+}
+
+// frameTracker tracks the boundaries of HTTP/2 frames in a stream of data.
+type frameTracker struct {
+	header    [frameHeaderLen]byte
+	headerLen int
+	remaining uint32
+}
+
+// advance consumes data up to the end of the current frame header or
+// payload, returning the number of bytes consumed. If this completes a
+// frame header, the header is also returned.
+func (t *frameTracker) advance(data []byte) (int, *http2.FrameHeader) {
+	if t.headerLen < frameHeaderLen {
+		n := copy(t.header[t.headerLen:], data)
+		t.headerLen += n
+		if t.headerLen < frameHeaderLen {
+			return n, nil
+		}
+		header, err := http2.ReadFrameHeader(bytes.NewReader(t.header[:]))
+		if err != nil {
+			// Can't happen since we provide all the bytes.
+			return n, nil
+		}
+		t.remaining = header.Length
+		if t.remaining == 0 {
+			t.headerLen = 0
+		}
+		return n, &header
+	}
+	n := len(data)
+	if uint32(n) > t.remaining {
+		n = int(t.remaining)
+	}
+	t.remaining -= uint32(n)
+	if t.remaining == 0 {
+		t.headerLen = 0
+	}
+	return n, nil
+}
+
+func (t *frameTracker) atBoundary() bool {
+	return t.headerLen == 0
+}
+
+// tlsHTTP2Conn is an http2Conn that uses TLS. It provides the TLS connection
+// state to the HTTP/2 server, so that it's available to handlers.
+type tlsHTTP2Conn struct {
+	*http2Conn
+	tlsConn *tls.Conn
+}
+
+func (c tlsHTTP2Conn) ConnectionState() tls.ConnectionState {
+	return c.tlsConn.ConnectionState()
+}
+
+// configureHTTP2OverTLS configures the given server to support HTTP/2 over
+// TLS, like http2.ConfigureServer, but using http2Conn connections. The
+// trace and captureErrs are applied to the clear-text connections, as
+// described by newHTTP2Conn.
+func configureHTTP2OverTLS(server *http.Server, trace *tracer.Tracer, captureErrs internal.Printer) error {
+	h2Server := &http2.Server{}
+	if err := http2.ConfigureServer(server, h2Server); err != nil {
+		return err
+	}
+	server.TLSNextProto[http2.NextProtoTLS] = func(server *http.Server, tlsConn *tls.Conn, handler http.Handler) {
+		ctx := context.Background()
+		if baseContexter, ok := handler.(interface{ BaseContext() context.Context }); ok {
+			ctx = baseContexter.BaseContext()
+		}
+		conn := newHTTP2Conn(tlsConn, trace, captureErrs)
+		h2Server.ServeConn(tlsHTTP2Conn{http2Conn: conn, tlsConn: tlsConn}, &http2.ServeConnOpts{
+			Context:    connWithHTTP2Context(ctx, conn),
+			Handler:    handler,
+			BaseConfig: server,
+		})
+	}
+	return nil
+}
+
+// sendGoAwayIfDue sends a GOAWAY frame, as described by the given definition,
+// if it is due after numResponses messages have been sent on a stream. The
+// done flag indicates that no more messages will be sent, in which case a
+// GOAWAY that is configured to follow more messages than were sent is due.
+//
+// Definitions that exclude the RPC's stream are never due, since they must
+// be sent before any part of the response. They are instead handled by
+// refuseWithGoAway.
+func sendGoAwayIfDue(ctx context.Context, def *conformancev1.GoAway, numResponses int, done bool) error {
+	if def == nil || def.ExcludeStream {
+		return nil
+	}
+	after := int(def.AfterNumResponses)
+	if numResponses == after || (done && numResponses < after) {
+		return sendGoAway(ctx, def)
+	}
+	return nil
+}
+
+// refuseWithGoAway sends a GOAWAY frame, as described by the given definition,
+// if it excludes the RPC's stream. This must be called before anything is sent
+// for the RPC.
+func refuseWithGoAway(ctx context.Context, def *conformancev1.GoAway) error {
+	if !def.GetExcludeStream() {
+		return nil
+	}
+	return sendGoAway(ctx, def)
+}
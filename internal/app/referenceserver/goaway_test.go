@@ -0,0 +1,156 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"connectrpc.com/conformance/internal/tracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+)
+
+func TestSendGoAway(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name          string
+		goAway        *conformancev1.GoAway
+		expectErr     bool
+		expectedCalls int32
+		expectedDials int32
+	}{
+		{
+			name:          "graceful",
+			goAway:        &conformancev1.GoAway{},
+			expectedCalls: 2,
+			expectedDials: 2,
+		},
+		{
+			name:          "error-code",
+			goAway:        &conformancev1.GoAway{ErrorCode: uint32(http2.ErrCodeInternal)},
+			expectErr:     true,
+			expectedCalls: 1,
+			expectedDials: 1,
+		},
+		{
+			name:      "exclude-stream",
+			goAway:    &conformancev1.GoAway{ExcludeStream: true},
+			expectErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			var calls, refusals atomic.Int32
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				ctx := context.WithValue(r.Context(), goAwayRefusalKey{}, func() {
+					refusals.Add(1)
+				})
+				if r.URL.Path == "/goaway" {
+					if err := sendGoAway(ctx, testCase.goAway); err != nil {
+						http.Error(w, err.Error(), http.StatusInternalServerError)
+						return
+					}
+				}
+				_, _ = io.WriteString(w, "ok")
+			})
+			// Trace the connections, to make sure that tracing does not
+			// interfere with injecting GOAWAY frames and that they can be
+			// seen in traces.
+			trace := &tracer.Tracer{RecordFrames: true}
+			trace.Init(t.Name())
+			server, err := newH2Server(handler, "127.0.0.1:0", nil, trace, nil)
+			require.NoError(t, err)
+			go func() {
+				_ = server.Serve()
+			}()
+			t.Cleanup(func() {
+				_ = server.GracefulShutdown(time.Second)
+			})
+
+			var dials atomic.Int32
+			client := &http.Client{
+				Transport: &http2.Transport{
+					AllowHTTP: true,
+					DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+						dials.Add(1)
+						var dialer net.Dialer
+						return dialer.DialContext(ctx, network, addr)
+					},
+				},
+				Timeout: 2 * time.Second,
+			}
+			get := func(path string) (string, error) {
+				req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://"+server.Addr()+path, nil)
+				if err != nil {
+					return "", err
+				}
+				req.Header.Set("X-Test-Case-Name", t.Name())
+				resp, err := client.Do(req)
+				if err != nil {
+					return "", err
+				}
+				defer resp.Body.Close()
+				body, err := io.ReadAll(resp.Body)
+				return string(body), err
+			}
+
+			body, err := get("/goaway")
+			if testCase.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "ok", body)
+				// A subsequent request must use a new connection.
+				body, err = get("/")
+				require.NoError(t, err)
+				assert.Equal(t, "ok", body)
+			}
+			if testCase.goAway.ExcludeStream {
+				// The client transparently retries on a new connection,
+				// with backoff, until it times out. Every attempt is refused.
+				assert.GreaterOrEqual(t, calls.Load(), int32(2))
+				assert.Equal(t, calls.Load(), refusals.Load())
+				assert.Equal(t, calls.Load(), dials.Load())
+			} else {
+				assert.Equal(t, testCase.expectedCalls, calls.Load())
+				assert.Equal(t, testCase.expectedDials, dials.Load())
+				assert.Zero(t, refusals.Load())
+			}
+			if !testCase.goAway.ExcludeStream {
+				ctx, cancel := context.WithTimeout(context.Background(), tracer.TraceTimeout)
+				defer cancel()
+				result, err := trace.Await(ctx, t.Name())
+				require.NoError(t, err)
+				var frameTypes []string
+				for _, frame := range result.Frames {
+					frameTypes = append(frameTypes, frame.Type)
+				}
+				assert.Contains(t, frameTypes, "GOAWAY")
+			}
+		})
+	}
+}
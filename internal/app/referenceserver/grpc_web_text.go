@@ -0,0 +1,95 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"net/http"
+
+	"connectrpc.com/conformance/internal"
+)
+
+// grpcWebTextTranslator is HTTP middleware that adds support for the
+// "grpc-web-text" variant of gRPC-Web, which connect-go does not support.
+// Requests that use it are presented to the handler as binary gRPC-Web
+// requests, with the body decoded from base64. The handler's binary
+// gRPC-Web response is then base64-encoded on the way out, one chunk
+// per write, the same way a streaming server would encode it.
+func grpcWebTextTranslator(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		contentType := req.Header.Get("Content-Type")
+		if !internal.IsGRPCWebTextContentType(contentType) {
+			handler.ServeHTTP(respWriter, req)
+			return
+		}
+		req.Header.Set("Content-Type", internal.GRPCWebTextToBinaryContentType(contentType))
+		req.Body = internal.NewGRPCWebTextDecodingReader(req.Body)
+		req.ContentLength = -1
+		req.Header.Del("Content-Length")
+		handler.ServeHTTP(&grpcWebTextResponseWriter{respWriter: respWriter}, req)
+	})
+}
+
+type grpcWebTextResponseWriter struct {
+	respWriter  http.ResponseWriter
+	wroteHeader bool
+	encode      bool
+}
+
+func (w *grpcWebTextResponseWriter) Header() http.Header {
+	return w.respWriter.Header()
+}
+
+func (w *grpcWebTextResponseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	contentType := w.respWriter.Header().Get("Content-Type")
+	if internal.IsGRPCWebBinaryContentType(contentType) {
+		// Only binary gRPC-Web responses are encoded. Others, like
+		// error responses from a misbehaving handler, pass through.
+		w.encode = true
+		w.respWriter.Header().Set("Content-Type", internal.GRPCWebBinaryToTextContentType(contentType))
+		w.respWriter.Header().Del("Content-Length")
+	}
+	w.respWriter.WriteHeader(statusCode)
+}
+
+func (w *grpcWebTextResponseWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.encode || len(data) == 0 {
+		return w.respWriter.Write(data)
+	}
+	if _, err := w.respWriter.Write(internal.GRPCWebTextEncode(data)); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (w *grpcWebTextResponseWriter) Flush() {
+	if !w.wroteHeader {
+		// Flushing sends the headers, so we must fix the content-type first.
+		w.WriteHeader(http.StatusOK)
+	}
+	if flusher, ok := w.respWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *grpcWebTextResponseWriter) Unwrap() http.ResponseWriter {
+	return w.respWriter
+}
@@ -0,0 +1,81 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1/conformancev1connect"
+	"connectrpc.com/connect"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	healthCheckProcedure = healthv1.Health_Check_FullMethodName
+	healthWatchProcedure = healthv1.Health_Watch_FullMethodName
+)
+
+// healthStatuses are the statuses reported by the health service. They
+// never change, except for scripted transitions on a single Watch call.
+//
+//nolint:gochecknoglobals
+var healthStatuses = map[string]healthv1.HealthCheckResponse_ServingStatus{
+	"": healthv1.HealthCheckResponse_SERVING,
+	conformancev1connect.ConformanceServiceName: healthv1.HealthCheckResponse_SERVING,
+}
+
+// registerHealth adds handlers for the gRPC health checking service
+// to the given mux.
+func registerHealth(mux *http.ServeMux, opts ...connect.HandlerOption) {
+	mux.Handle(healthCheckProcedure, connect.NewUnaryHandler(healthCheckProcedure, healthCheck, opts...))
+	mux.Handle(healthWatchProcedure, connect.NewServerStreamHandler(healthWatchProcedure, healthWatch, opts...))
+}
+
+func healthCheck(
+	_ context.Context,
+	req *connect.Request[healthv1.HealthCheckRequest],
+) (*connect.Response[healthv1.HealthCheckResponse], error) {
+	status, ok := healthStatuses[req.Msg.Service]
+	if !ok {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("unknown service %q", req.Msg.Service))
+	}
+	return connect.NewResponse(&healthv1.HealthCheckResponse{Status: status}), nil
+}
+
+func healthWatch(
+	ctx context.Context,
+	req *connect.Request[healthv1.HealthCheckRequest],
+	stream *connect.ServerStream[healthv1.HealthCheckResponse],
+) error {
+	transitions, err := internal.ParseHealthTransitions(req.Header().Get(internal.HealthTransitionsHeader))
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	status, ok := healthStatuses[req.Msg.Service]
+	if !ok {
+		status = healthv1.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+	for _, sendStatus := range append([]healthv1.HealthCheckResponse_ServingStatus{status}, transitions...) {
+		if err := stream.Send(&healthv1.HealthCheckResponse{Status: sendStatus}); err != nil {
+			return err
+		}
+	}
+	// A watch never completes on its own; the client must cancel it.
+	<-ctx.Done()
+	return ctx.Err()
+}
@@ -108,10 +108,17 @@ func doUnary[ReqT, RespT any, Req hasUnaryResponseDefinition[ReqT]](
 	if msg.GetResponseDefinition() != nil {
 		internal.AddHeaders(msg.GetResponseDefinition().ResponseHeaders, resp.Header())
 		internal.AddHeaders(msg.GetResponseDefinition().ResponseTrailers, resp.Trailer())
+		setWriteChunking(ctx, msg.GetResponseDefinition().WriteChunking)
 
 		// If a response delay was specified, sleep for that amount of ms before responding
 		responseDelay := time.Duration(msg.GetResponseDefinition().ResponseDelayMs) * time.Millisecond
 		time.Sleep(responseDelay)
+
+		if goAway := msg.GetResponseDefinition().Goaway; goAway != nil {
+			if err := sendGoAway(ctx, goAway); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return resp, nil
@@ -122,6 +129,7 @@ func (s *conformanceServer) ClientStream(
 	stream *connect.ClientStream[conformancev1.ClientStreamRequest],
 ) (*connect.Response[conformancev1.ClientStreamResponse], error) {
 	var responseDefinition *conformancev1.UnaryResponseDefinition
+	var receiveDelay time.Duration
 	firstRecv := true
 	var reqs []*anypb.Any
 	for stream.Receive() {
@@ -132,6 +140,7 @@ func (s *conformanceServer) ClientStream(
 		// If this is the first message received on the stream, save off the response definition we need to send
 		if firstRecv {
 			responseDefinition = msg.ResponseDefinition
+			receiveDelay = time.Duration(msg.ReceiveDelayMs) * time.Millisecond
 			firstRecv = false
 		}
 		// Record all the requests received
@@ -140,6 +149,9 @@ func (s *conformanceServer) ClientStream(
 			return nil, err
 		}
 		reqs = append(reqs, msgAsAny)
+
+		// If a receive delay was specified, sleep for that amount of ms before receiving the next message
+		time.Sleep(receiveDelay)
 	}
 	if err := stream.Err(); err != nil {
 		return nil, err
@@ -167,10 +179,17 @@ func (s *conformanceServer) ClientStream(
 	if responseDefinition != nil {
 		internal.AddHeaders(responseDefinition.ResponseHeaders, resp.Header())
 		internal.AddHeaders(responseDefinition.ResponseTrailers, resp.Trailer())
+		setWriteChunking(ctx, responseDefinition.WriteChunking)
 
 		// If a response delay was specified, sleep for that amount of ms before responding
 		responseDelay := time.Duration(responseDefinition.ResponseDelayMs) * time.Millisecond
 		time.Sleep(responseDelay)
+
+		if responseDefinition.Goaway != nil {
+			if err := sendGoAway(ctx, responseDefinition.Goaway); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return resp, nil
@@ -191,20 +210,25 @@ func (s *conformanceServer) ServerStream(
 
 	responseDefinition := req.Msg.ResponseDefinition
 	if responseDefinition != nil { //nolint:nestif
+		responseData, err := internal.StreamResponseData(responseDefinition)
+		if err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if err := refuseWithGoAway(ctx, responseDefinition.Goaway); err != nil {
+			return err
+		}
 		internal.AddHeaders(responseDefinition.ResponseHeaders, stream.ResponseHeader())
 		internal.AddHeaders(responseDefinition.ResponseTrailers, stream.ResponseTrailer())
+		setWriteChunking(ctx, responseDefinition.WriteChunking)
 
-		if len(responseDefinition.ResponseData) > 0 {
+		if len(responseData) > 0 {
 			// Immediately send the headers/trailers on the stream so that they can be read by the client
 			if err := stream.Send(nil); err != nil {
 				return connect.NewError(connect.CodeInternal, fmt.Errorf("error sending on stream: %w", err))
 			}
 		}
 
-		// Calculate the response delay if specified
-		responseDelay := time.Duration(responseDefinition.ResponseDelayMs) * time.Millisecond
-
-		for _, data := range responseDefinition.ResponseData {
+		for _, data := range responseData {
 			resp := &conformancev1.ServerStreamResponse{
 				Payload: &conformancev1.ConformancePayload{
 					Data: data,
@@ -219,14 +243,21 @@ func (s *conformanceServer) ServerStream(
 			}
 
 			// If a response delay was specified, sleep for that amount of ms before responding
-			time.Sleep(responseDelay)
+			time.Sleep(streamResponseDelay(responseDefinition, respNum))
 
+			if err := sendGoAwayIfDue(ctx, responseDefinition.Goaway, respNum, false); err != nil {
+				return err
+			}
 			if err := stream.Send(resp); err != nil {
 				return connect.NewError(connect.CodeInternal, fmt.Errorf("error sending on stream: %w", err))
 			}
 			respNum++
 		}
 
+		if err := sendGoAwayIfDue(ctx, responseDefinition.Goaway, respNum, true); err != nil {
+			return err
+		}
+
 		if responseDefinition.Error != nil {
 			if respNum == 0 {
 				// We've sent no responses and are returning an error, so build a
@@ -250,7 +281,7 @@ func (s *conformanceServer) BidiStream(
 	stream *connect.BidiStream[conformancev1.BidiStreamRequest, conformancev1.BidiStreamResponse],
 ) error {
 	var responseDefinition *conformancev1.StreamResponseDefinition
-	var responseDelay time.Duration
+	var responseData [][]byte
 	fullDuplex := false
 	firstRecv := true
 	respNum := 0
@@ -284,10 +315,18 @@ func (s *conformanceServer) BidiStream(
 
 			// If a response definition was provided, add the headers and trailers
 			if responseDefinition != nil {
+				responseData, err = internal.StreamResponseData(responseDefinition)
+				if err != nil {
+					return connect.NewError(connect.CodeInvalidArgument, err)
+				}
+				if err := refuseWithGoAway(ctx, responseDefinition.Goaway); err != nil {
+					return err
+				}
 				internal.AddHeaders(responseDefinition.ResponseHeaders, stream.ResponseHeader())
 				internal.AddHeaders(responseDefinition.ResponseTrailers, stream.ResponseTrailer())
+				setWriteChunking(ctx, responseDefinition.WriteChunking)
 
-				if fullDuplex && len(responseDefinition.ResponseData) > 0 {
+				if fullDuplex && len(responseData) > 0 {
 					// Immediately send the headers on the stream so that they can be read by the client.
 					// We can only do this for full-duplex. For half-duplex operation, we must let client
 					// complete its upload before trying to send anything.
@@ -295,15 +334,12 @@ func (s *conformanceServer) BidiStream(
 						return connect.NewError(connect.CodeInternal, fmt.Errorf("error sending on stream: %w", err))
 					}
 				}
-
-				// Calculate a response delay if specified
-				responseDelay = time.Duration(responseDefinition.ResponseDelayMs) * time.Millisecond
 			}
 		}
 
 		// If fullDuplex, then send one of the desired responses each time we get a message on the stream
 		if fullDuplex {
-			if respNum >= len(responseDefinition.GetResponseData()) {
+			if respNum >= len(responseData) {
 				// If there are no responses to send, then break the receive loop
 				// and throw the error specified
 				break
@@ -311,7 +347,7 @@ func (s *conformanceServer) BidiStream(
 
 			resp := &conformancev1.BidiStreamResponse{
 				Payload: &conformancev1.ConformancePayload{
-					Data: responseDefinition.ResponseData[respNum],
+					Data: responseData[respNum],
 				},
 			}
 			var requestInfo *conformancev1.ConformancePayload_RequestInfo
@@ -330,17 +366,23 @@ func (s *conformanceServer) BidiStream(
 			resp.Payload.RequestInfo = requestInfo
 
 			// If a response delay was specified, sleep for that amount of ms before responding
-			time.Sleep(responseDelay)
+			time.Sleep(streamResponseDelay(responseDefinition, respNum))
 
+			if err := sendGoAwayIfDue(ctx, responseDefinition.Goaway, respNum, false); err != nil {
+				return err
+			}
 			if err := stream.Send(resp); err != nil {
 				return connect.NewError(connect.CodeInternal, fmt.Errorf("error sending on stream: %w", err))
 			}
 			respNum++
 			reqs = nil
+			// The client may wait for this response before sending the next
+			// request, so don't hold any of it back for the rest of a chunk.
+			endWriteChunk(ctx)
 		}
 	}
 
-	if !fullDuplex && len(responseDefinition.GetResponseData()) > 0 {
+	if !fullDuplex && len(responseData) > 0 {
 		// Now that upload is complete, we can immediately send headers for half-duplex calls.
 		if err := stream.Send(nil); err != nil {
 			return connect.NewError(connect.CodeInternal, fmt.Errorf("error sending on stream: %w", err))
@@ -351,13 +393,13 @@ func (s *conformanceServer) BidiStream(
 	// both scenarios of half duplex (we haven't sent any responses yet) or full duplex
 	// where the requested responses are greater than the total requests.
 	if responseDefinition != nil { //nolint:nestif
-		for ; respNum < len(responseDefinition.ResponseData); respNum++ {
+		for ; respNum < len(responseData); respNum++ {
 			if err := ctx.Err(); err != nil {
 				return err
 			}
 			resp := &conformancev1.BidiStreamResponse{
 				Payload: &conformancev1.ConformancePayload{
-					Data: responseDefinition.ResponseData[respNum],
+					Data: responseData[respNum],
 				},
 			}
 			// Only set the request info if this is the first response being sent back
@@ -373,13 +415,20 @@ func (s *conformanceServer) BidiStream(
 			}
 
 			// If a response delay was specified, sleep for that amount of ms before responding
-			time.Sleep(responseDelay)
+			time.Sleep(streamResponseDelay(responseDefinition, respNum))
 
+			if err := sendGoAwayIfDue(ctx, responseDefinition.Goaway, respNum, false); err != nil {
+				return err
+			}
 			if err := stream.Send(resp); err != nil {
 				return connect.NewError(connect.CodeInternal, fmt.Errorf("error sending on stream: %w", err))
 			}
 		}
 
+		if err := sendGoAwayIfDue(ctx, responseDefinition.Goaway, respNum, true); err != nil {
+			return err
+		}
+
 		if responseDefinition.Error != nil {
 			if respNum == 0 {
 				// We've sent no responses and are returning an error, so build a
@@ -517,6 +566,15 @@ func parseUnaryResponseDefinition(
 			payload.Data = respType.ResponseData
 		}
 		return payload, nil
+	case *conformancev1.UnaryResponseDefinition_GeneratedResponseData:
+		data, err := internal.GenerateData(respType.GeneratedResponseData)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		return &conformancev1.ConformancePayload{
+			RequestInfo: reqInfo,
+			Data:        data,
+		}, nil
 	default:
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("provided UnaryRequest.Response has an unexpected type %T", respType))
 	}
@@ -566,6 +624,15 @@ func asAny(msg proto.Message) (*anypb.Any, error) {
 	return msgAsAny, nil
 }
 
+// Returns how long to wait before sending the response message with the given
+// index, which may be specified per message or for all messages of the stream.
+func streamResponseDelay(responseDefinition *conformancev1.StreamResponseDefinition, respNum int) time.Duration {
+	if delays := responseDefinition.GetResponseDelaysMs(); respNum < len(delays) {
+		return time.Duration(delays[respNum]) * time.Millisecond
+	}
+	return time.Duration(responseDefinition.GetResponseDelayMs()) * time.Millisecond
+}
+
 // serverNameHandlerInterceptor adds a "server" header on outgoing responses.
 type serverNameHandlerInterceptor struct{}
 
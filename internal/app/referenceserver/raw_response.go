@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"sync"
 
@@ -57,6 +58,7 @@ type rawResponseWriter struct {
 	mu              sync.Mutex
 	rawResp         *conformancev1.RawHTTPResponse
 	startedResponse bool
+	chunkWriter     *chunkWriter
 }
 
 // canSendResponse returns true if the server handler can use the
@@ -94,13 +96,30 @@ func (r *rawResponseWriter) setRawResponse(resp *conformancev1.RawHTTPResponse)
 	return true
 }
 
+func (r *rawResponseWriter) setWriteChunking(chunking *conformancev1.WriteChunking) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.chunkWriter = newChunkWriter(r.respWriter, chunking)
+}
+
+// bodyWriter returns the writer to which the response body should be
+// written, which splits the body into chunks if so configured.
+func (r *rawResponseWriter) bodyWriter() io.Writer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.chunkWriter != nil {
+		return r.chunkWriter
+	}
+	return r.respWriter
+}
+
 func (r *rawResponseWriter) Header() http.Header {
 	return r.respWriter.Header()
 }
 
 func (r *rawResponseWriter) Write(bytes []byte) (int, error) {
 	if r.canSendResponse() {
-		return r.respWriter.Write(bytes)
+		return r.bodyWriter().Write(bytes)
 	}
 	return len(bytes), nil
 }
@@ -113,7 +132,9 @@ func (r *rawResponseWriter) WriteHeader(statusCode int) {
 
 func (r *rawResponseWriter) Flush() {
 	if r.canSendResponse() {
-		if flusher, ok := r.respWriter.(http.Flusher); ok {
+		// When chunking, this goes to the chunk writer, which
+		// defers the flush to the end of the current chunk.
+		if flusher, ok := r.bodyWriter().(http.Flusher); ok {
 			flusher.Flush()
 		}
 	}
@@ -126,6 +147,7 @@ func (r *rawResponseWriter) Unwrap() http.ResponseWriter {
 func (r *rawResponseWriter) finish(snapshotHeaders http.Header) {
 	resp := r.rawResponse()
 	if resp == nil {
+		r.finishChunks()
 		return
 	}
 
@@ -152,15 +174,29 @@ func (r *rawResponseWriter) finish(snapshotHeaders http.Header) {
 		statusCode = 200
 	}
 	r.respWriter.WriteHeader(statusCode)
+	r.setWriteChunking(resp.WriteChunking)
+	bodyWriter := r.bodyWriter()
 	switch contents := resp.Body.(type) {
 	case *conformancev1.RawHTTPResponse_Unary:
-		_ = internal.WriteRawMessageContents(contents.Unary, r.respWriter)
+		_ = internal.WriteRawMessageContents(contents.Unary, bodyWriter)
 	case *conformancev1.RawHTTPResponse_Stream:
-		_ = internal.WriteRawStreamContents(contents.Stream, r.respWriter)
+		_ = internal.WriteRawStreamContents(contents.Stream, bodyWriter)
 	}
+	r.finishChunks()
 	internal.AddTrailers(resp.Trailers, r.respWriter.Header())
 }
 
+// finishChunks flushes the partially written current chunk of the response
+// body, if the body is split into chunks.
+func (r *rawResponseWriter) finishChunks() {
+	r.mu.Lock()
+	chunkWriter := r.chunkWriter
+	r.mu.Unlock()
+	if chunkWriter != nil {
+		chunkWriter.finish()
+	}
+}
+
 type rawResponseRecorder struct{}
 
 func (r rawResponseRecorder) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
@@ -268,6 +304,27 @@ func (str *firstReqCachingStream) Receive(dest any) error {
 	return str.StreamingHandlerConn.Receive(dest)
 }
 
+// setWriteChunking configures the response writer to split the response body
+// into chunks. This must be called before the handler writes the response body.
+func setWriteChunking(ctx context.Context, chunking *conformancev1.WriteChunking) {
+	if chunking == nil {
+		return
+	}
+	if respWriter, ok := ctx.Value(rawResponseKey{}).(*rawResponseWriter); ok {
+		respWriter.setWriteChunking(chunking)
+	}
+}
+
+// endWriteChunk flushes the part of the current chunk that has been written,
+// if the response body is split into chunks. The handler must call this
+// before waiting on the client, which may itself be waiting for the rest of
+// the response. The next write starts a new chunk.
+func endWriteChunk(ctx context.Context) {
+	if respWriter, ok := ctx.Value(rawResponseKey{}).(*rawResponseWriter); ok {
+		respWriter.finishChunks()
+	}
+}
+
 func setRawResponse(ctx context.Context, resp *conformancev1.RawHTTPResponse) error {
 	respWriter, ok := ctx.Value(rawResponseKey{}).(*rawResponseWriter)
 	if !ok {
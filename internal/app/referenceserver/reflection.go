@@ -0,0 +1,160 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1/conformancev1connect"
+	"connectrpc.com/connect"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	reflectionV1Procedure      = reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName
+	reflectionV1AlphaProcedure = reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName
+)
+
+// reflectionServices is the set of services advertised by the
+// server reflection service.
+type reflectionServices struct{}
+
+func (reflectionServices) GetServiceInfo() map[string]grpc.ServiceInfo {
+	return map[string]grpc.ServiceInfo{
+		conformancev1connect.ConformanceServiceName:                {},
+		reflectionv1.ServerReflection_ServiceDesc.ServiceName:      {},
+		reflectionv1alpha.ServerReflection_ServiceDesc.ServiceName: {},
+	}
+}
+
+// registerReflection adds handlers for both the v1 and v1alpha versions
+// of the server reflection service to the given mux. These re-use the
+// implementation from grpc-go, adapted to work with Connect streams.
+func registerReflection(mux *http.ServeMux, opts ...connect.HandlerOption) {
+	reflectOpts := reflection.ServerOptions{Services: reflectionServices{}}
+	v1Server := reflection.NewServerV1(reflectOpts)
+	mux.Handle(reflectionV1Procedure, connect.NewBidiStreamHandler(
+		reflectionV1Procedure,
+		func(ctx context.Context, stream *connect.BidiStream[reflectionv1.ServerReflectionRequest, reflectionv1.ServerReflectionResponse]) error {
+			return grpcToConnectError(v1Server.ServerReflectionInfo(&reflectionStream[reflectionv1.ServerReflectionRequest, reflectionv1.ServerReflectionResponse]{ctx: ctx, stream: stream}))
+		},
+		opts...,
+	))
+	v1AlphaServer := reflection.NewServer(reflectOpts)
+	mux.Handle(reflectionV1AlphaProcedure, connect.NewBidiStreamHandler(
+		reflectionV1AlphaProcedure,
+		func(ctx context.Context, stream *connect.BidiStream[reflectionv1alpha.ServerReflectionRequest, reflectionv1alpha.ServerReflectionResponse]) error {
+			return grpcToConnectError(v1AlphaServer.ServerReflectionInfo(&reflectionStream[reflectionv1alpha.ServerReflectionRequest, reflectionv1alpha.ServerReflectionResponse]{ctx: ctx, stream: stream}))
+		},
+		opts...,
+	))
+}
+
+// reflectionStream adapts a Connect bidi stream to the gRPC server
+// stream interface expected by the grpc-go reflection implementation.
+type reflectionStream[Req, Res any] struct {
+	ctx    context.Context //nolint:containedctx // needed to implement grpc.ServerStream
+	stream *connect.BidiStream[Req, Res]
+}
+
+func (s *reflectionStream[Req, Res]) Send(msg *Res) error {
+	return s.stream.Send(msg)
+}
+
+func (s *reflectionStream[Req, Res]) Recv() (*Req, error) {
+	msg, err := s.stream.Receive()
+	if errors.Is(err, io.EOF) {
+		// The reflection implementation tests for io.EOF with ==.
+		return nil, io.EOF
+	}
+	return msg, err
+}
+
+func (s *reflectionStream[Req, Res]) SetHeader(md metadata.MD) error {
+	addMetadata(s.stream.ResponseHeader(), md)
+	return nil
+}
+
+func (s *reflectionStream[Req, Res]) SendHeader(md metadata.MD) error {
+	addMetadata(s.stream.ResponseHeader(), md)
+	return nil
+}
+
+func (s *reflectionStream[Req, Res]) SetTrailer(md metadata.MD) {
+	addMetadata(s.stream.ResponseTrailer(), md)
+}
+
+func (s *reflectionStream[Req, Res]) Context() context.Context {
+	return s.ctx
+}
+
+func (s *reflectionStream[Req, Res]) SendMsg(msg any) error {
+	res, ok := msg.(*Res)
+	if !ok {
+		return connect.NewError(connect.CodeInternal, errors.New("unexpected response message type"))
+	}
+	return s.Send(res)
+}
+
+func (s *reflectionStream[Req, Res]) RecvMsg(msg any) error {
+	dest, ok := msg.(proto.Message)
+	if !ok {
+		return connect.NewError(connect.CodeInternal, errors.New("unexpected request message type"))
+	}
+	received, err := s.Recv()
+	if err != nil {
+		return err
+	}
+	src, ok := any(received).(proto.Message)
+	if !ok || src.ProtoReflect().Descriptor() != dest.ProtoReflect().Descriptor() {
+		return connect.NewError(connect.CodeInternal, errors.New("unexpected request message type"))
+	}
+	proto.Reset(dest)
+	proto.Merge(dest, src)
+	return nil
+}
+
+func addMetadata(dest http.Header, md metadata.MD) {
+	for key, vals := range md {
+		for _, val := range vals {
+			dest.Add(key, val)
+		}
+	}
+}
+
+// grpcToConnectError converts a gRPC status error, returned from the
+// grpc-go reflection implementation, into a Connect error.
+func grpcToConnectError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		return err
+	}
+	if stat, ok := status.FromError(err); ok {
+		return connect.NewError(connect.Code(stat.Code()), errors.New(stat.Message()))
+	}
+	return err
+}
@@ -62,6 +62,7 @@ func run(ctx context.Context, referenceMode bool, args []string, inReader io.Rea
 	port := flags.Int("port", internal.DefaultPort, "the port for the conformance server")
 	tlsCert := flags.String("cert", "", "the path to a PEM-encoded TLS certificate file to use instead of generating self-signed")
 	tlsKey := flags.String("key", "", "the path to a PEM-encoded TLS key file to use instead of generating self-signed")
+	captureMalformed := flags.Bool("capture-malformed", false, "whether to capture the raw bytes sent by clients, to report malformed requests; only used by the test runner")
 	showVersion := flags.Bool("version", false, "show version and exit")
 
 	if err := flags.Parse(args[1:]); err != nil {
@@ -79,16 +80,27 @@ func run(ctx context.Context, referenceMode bool, args []string, inReader io.Rea
 	}
 
 	codec := internal.NewCodec(*json)
+	decoder := codec.NewDecoder(inReader)
+	handshake := !referenceMode && internal.HandshakeRequested()
+	if handshake {
+		if err := internal.RespondToHandshake(decoder, codec.NewEncoder(outWriter), internal.ReferenceHandshakeResponse(args[0])); err != nil {
+			return err
+		}
+	}
 
 	// Read the server config from the in reader
 	req := &conformancev1.ServerCompatRequest{}
-	if err := codec.NewDecoder(inReader).DecodeNext(req); err != nil {
+	if err := decoder.DecodeNext(req); err != nil {
+		if handshake && errors.Is(err, io.EOF) {
+			// The test runner only wanted the handshake.
+			return nil
+		}
 		return err
 	}
 
 	// Create an HTTP server based on the request
 	errPrinter := internal.NewPrinter(errWriter)
-	server, certBytes, err := createServer(req, net.JoinHostPort(*host, strconv.Itoa(*port)), *tlsCert, *tlsKey, referenceMode, errPrinter, tracer)
+	server, certBytes, err := createServer(req, net.JoinHostPort(*host, strconv.Itoa(*port)), *tlsCert, *tlsKey, referenceMode, referenceMode && *captureMalformed, errPrinter, tracer)
 	if err != nil {
 		return err
 	}
@@ -166,8 +178,10 @@ func (s *stdHTTPServer) Addr() string {
 	return s.lis.Addr().String()
 }
 
-// Creates an HTTP server using the provided ServerCompatRequest.
-func createServer(req *conformancev1.ServerCompatRequest, listenAddr, tlsCertFile, tlsKeyFile string, referenceMode bool, errPrinter internal.Printer, trace *tracer.Tracer) (httpServer, []byte, error) {
+// Creates an HTTP server using the provided ServerCompatRequest. If
+// captureMalformed is true, the raw bytes sent by clients are captured,
+// so that data that the server can't parse is reported to errPrinter.
+func createServer(req *conformancev1.ServerCompatRequest, listenAddr, tlsCertFile, tlsKeyFile string, referenceMode, captureMalformed bool, errPrinter internal.Printer, trace *tracer.Tracer) (httpServer, []byte, error) {
 	mux := http.NewServeMux()
 	interceptors := []connect.Interceptor{serverNameHandlerInterceptor{}}
 	if referenceMode {
@@ -189,16 +203,24 @@ func createServer(req *conformancev1.ServerCompatRequest, listenAddr, tlsCertFil
 		&conformanceServer{referenceMode: referenceMode},
 		opts...,
 	))
+	registerReflection(mux, opts...)
+	registerHealth(mux, opts...)
 	handler := http.Handler(http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
-		if strings.HasSuffix(req.URL.Path, conformancev1connect.ConformanceServiceBidiStreamProcedure) &&
-			req.ProtoMajor == 1 {
+		if isBidiProcedure(req.URL.Path) && req.ProtoMajor == 1 {
 			// To force support for bidirectional RPC over HTTP 1.1 (for half-duplex testing),
 			// we "trick" the handler into thinking this is HTTP/2. We have to do this because
 			// otherwise, connect-go refuses to handle bidi streams over HTTP 1.1.
 			req.ProtoMajor, req.ProtoMinor = 2, 0
+			if isReflectionProcedure(req.URL.Path) {
+				// Unlike the ConformanceService, the reflection implementation sends
+				// each response before reading the next request. By default, the
+				// HTTP 1.1 server closes the request body once the response is started.
+				_ = http.NewResponseController(respWriter).EnableFullDuplex()
+			}
 		}
 		mux.ServeHTTP(respWriter, req)
 	}))
+	handler = grpcWebTextTranslator(handler)
 	if referenceMode {
 		handler = referenceServerChecks(handler, errPrinter)
 		handler = rawResponder(handler)
@@ -225,7 +247,23 @@ func createServer(req *conformancev1.ServerCompatRequest, listenAddr, tlsCertFil
 			orig.ServeHTTP(respWriter, req)
 		})
 	}
-	if trace != nil {
+	if captureMalformed {
+		handler = captureHandler(handler)
+	}
+	if trace != nil && trace.PropagateTraceContext {
+		handler = traceresponseHandler(handler)
+	}
+	// HTTP/3 is instead traced at the connection level, in newH3Server. So is
+	// HTTP/2 when recording frames, capturing bytes, or capturing malformed
+	// data (which uses the tracer's decoding of the frames), in newH2Server.
+	traceConns := req.HttpVersion == conformancev1.HTTPVersion_HTTP_VERSION_3 ||
+		(req.HttpVersion == conformancev1.HTTPVersion_HTTP_VERSION_2 && (trace.TracesHTTP2Conns() || captureMalformed))
+	switch {
+	case trace != nil && req.HttpVersion == conformancev1.HTTPVersion_HTTP_VERSION_3:
+		// Requests whose headers can't be decoded from the QUIC streams
+		// are traced by middleware instead.
+		handler = tracer.TracingHTTP3FallbackHandler(handler, trace)
+	case trace != nil && !traceConns:
 		handler = tracer.TracingHandler(handler, trace)
 	}
 	// The server needs a lenient cors setup so that it can handle testing
@@ -293,16 +331,27 @@ func createServer(req *conformancev1.ServerCompatRequest, listenAddr, tlsCertFil
 		if err != nil {
 			return nil, nil, fmt.Errorf("could not create TLS configuration: %w", err)
 		}
+		if trace != nil {
+			tlsConf.KeyLogWriter = trace.KeyLog
+		}
+	}
+	var captureErrs internal.Printer
+	if captureMalformed {
+		captureErrs = errPrinter
 	}
 	var server httpServer
 	var err error
 	switch req.HttpVersion {
 	case conformancev1.HTTPVersion_HTTP_VERSION_1:
-		server, err = newH1Server(handler, listenAddr, tlsConf)
+		server, err = newH1Server(handler, listenAddr, tlsConf, captureErrs)
 	case conformancev1.HTTPVersion_HTTP_VERSION_2:
-		server, err = newH2Server(handler, listenAddr, tlsConf)
+		var connTrace *tracer.Tracer
+		if traceConns {
+			connTrace = trace
+		}
+		server, err = newH2Server(handler, listenAddr, tlsConf, connTrace, captureErrs)
 	case conformancev1.HTTPVersion_HTTP_VERSION_3:
-		server, err = newH3Server(handler, listenAddr, tlsConf)
+		server, err = newH3Server(handler, listenAddr, tlsConf, trace)
 	case conformancev1.HTTPVersion_HTTP_VERSION_UNSPECIFIED:
 		err = errors.New("an HTTP version must be specified")
 	}
@@ -313,8 +362,10 @@ func createServer(req *conformancev1.ServerCompatRequest, listenAddr, tlsCertFil
 	return server, certBytes, nil
 }
 
-// newH1Server creates a new HTTP/1.1 server.
-func newH1Server(handler http.Handler, listenAddr string, tlsConf *tls.Config) (httpServer, error) {
+// newH1Server creates a new HTTP/1.1 server. If captureErrs is non-nil,
+// the raw bytes sent by clients are captured, and malformed requests are
+// reported to it.
+func newH1Server(handler http.Handler, listenAddr string, tlsConf *tls.Config, captureErrs internal.Printer) (httpServer, error) {
 	h1Server := &http.Server{
 		Addr:              listenAddr,
 		Handler:           handler,
@@ -328,11 +379,27 @@ func newH1Server(handler http.Handler, listenAddr string, tlsConf *tls.Config) (
 	if err != nil {
 		return nil, err
 	}
+	if captureErrs != nil {
+		if tlsConf != nil {
+			// net/http only applies TLS to the connections it accepts,
+			// so the bytes it reads are already decrypted. So we apply
+			// TLS ourselves, beneath the capture, like http.Server.ServeTLS.
+			tlsConf = tlsConf.Clone()
+			tlsConf.NextProtos = []string{"http/1.1"}
+			lis = tls.NewListener(lis, tlsConf)
+			h1Server.TLSConfig = nil
+			h1Server.Handler = withTLSState(handler)
+		}
+		h1Server.ConnContext = connWithCaptureContext
+		lis = captureListener{Listener: lis, errPrinter: captureErrs}
+	}
 	return &stdHTTPServer{svr: h1Server, lis: lis}, nil
 }
 
-// newH2Server creates a new HTTP/2 server.
-func newH2Server(handler http.Handler, listenAddr string, tlsConf *tls.Config) (httpServer, error) {
+// newH2Server creates a new HTTP/2 server. If trace is non-nil, connections
+// are traced at the HTTP/2 frame level. If captureErrs is non-nil, the raw
+// bytes sent by clients are captured, and malformed data is reported to it.
+func newH2Server(handler http.Handler, listenAddr string, tlsConf *tls.Config, trace *tracer.Tracer, captureErrs internal.Printer) (httpServer, error) {
 	if tlsConf == nil {
 		handler = h2c.NewHandler(handler, &http2.Server{})
 	}
@@ -346,15 +413,27 @@ func newH2Server(handler http.Handler, listenAddr string, tlsConf *tls.Config) (
 		// if the client says it supports HTTP/2, we rely on it negotiating
 		// that during ALPN of TLS handshake instead of HTTP 1.1. ¯\_(ツ)_/¯
 	}
+	if tlsConf != nil {
+		// We configure HTTP/2 ourselves so that handlers can access
+		// the underlying connection, to send GOAWAY frames.
+		if err := configureHTTP2OverTLS(h2Server, trace, captureErrs); err != nil {
+			return nil, err
+		}
+	} else {
+		h2Server.ConnContext = connWithHTTP2Context
+	}
 	lis, err := net.Listen("tcp", listenAddr)
 	if err != nil {
 		return nil, err
 	}
+	if tlsConf == nil {
+		lis = http2Listener{Listener: lis, trace: trace, captureErrs: captureErrs}
+	}
 	return &stdHTTPServer{svr: h2Server, lis: lis}, nil
 }
 
 // Create a new HTTP/3 server.
-func newH3Server(handler http.Handler, listenAddr string, tlsConf *tls.Config) (httpServer, error) {
+func newH3Server(handler http.Handler, listenAddr string, tlsConf *tls.Config, trace *tracer.Tracer) (httpServer, error) {
 	if tlsConf == nil {
 		return nil, errors.New("request indicated HTTP/3 without TLS, which is not possible")
 	}
@@ -364,10 +443,17 @@ func newH3Server(handler http.Handler, listenAddr string, tlsConf *tls.Config) (
 		Handler:   handler,
 		TLSConfig: tlsConf,
 	}
+	var lis http3.QUICEarlyListener
 	lis, err := quic.ListenAddrEarly(listenAddr, tlsConf, &quic.Config{MaxIdleTimeout: 20 * time.Second, KeepAlivePeriod: 5 * time.Second})
 	if err != nil {
 		return nil, err
 	}
+	if trace != nil {
+		// Tracing the QUIC connections, instead of using tracing middleware,
+		// shows the actual HTTP/3 headers and stream resets.
+		lis = tracer.TracingHTTP3Listener(lis, trace)
+		h3Server.ConnContext = tracer.HTTP3ConnContext
+	}
 	return &http3Server{svr: h3Server, lis: lis}, nil
 }
 
@@ -396,3 +482,13 @@ func nopLogger() *log.Logger {
 	// TODO: enable logging via -v option or env variable?
 	return log.New(io.Discard, "", 0)
 }
+
+func isBidiProcedure(path string) bool {
+	return strings.HasSuffix(path, conformancev1connect.ConformanceServiceBidiStreamProcedure) ||
+		isReflectionProcedure(path)
+}
+
+func isReflectionProcedure(path string) bool {
+	return strings.HasSuffix(path, reflectionV1Procedure) ||
+		strings.HasSuffix(path, reflectionV1AlphaProcedure)
+}
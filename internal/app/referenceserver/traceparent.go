@@ -0,0 +1,40 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"net/http"
+
+	"connectrpc.com/conformance/internal/tracer"
+)
+
+// traceresponseHandler propagates a W3C trace context for each request, so
+// that the telemetry of the client under test can be correlated with the
+// spans that the conformance runner exports for each test case. The span
+// for the request continues the trace context in the request's "traceparent"
+// header, or starts a new trace if there is none. Its trace context is
+// returned to the client in a "traceresponse" header.
+func traceresponseHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		traceCtx, ok := tracer.TraceContextFromHeaders(req.Header)
+		if ok {
+			traceCtx = traceCtx.Child()
+		} else {
+			traceCtx = tracer.NewTraceContext()
+		}
+		respWriter.Header().Set(tracer.TraceresponseHeader, traceCtx.Traceparent())
+		handler.ServeHTTP(respWriter, req)
+	})
+}
@@ -0,0 +1,53 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/conformance/internal/tracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceresponseHandler(t *testing.T) {
+	t.Parallel()
+	handler := traceresponseHandler(http.HandlerFunc(func(respWriter http.ResponseWriter, _ *http.Request) {
+		respWriter.WriteHeader(http.StatusOK)
+	}))
+	serve := func(traceparent string) tracer.TraceContext {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		if traceparent != "" {
+			req.Header.Set(tracer.TraceparentHeader, traceparent)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		traceCtx, ok := tracer.ParseTraceparent(recorder.Header().Get(tracer.TraceresponseHeader))
+		require.True(t, ok)
+		return traceCtx
+	}
+
+	// The server's span continues the client's trace.
+	clientCtx := tracer.NewTraceContext()
+	serverCtx := serve(clientCtx.Traceparent())
+	assert.Equal(t, clientCtx.TraceID, serverCtx.TraceID)
+	assert.NotEqual(t, clientCtx.SpanID, serverCtx.SpanID)
+
+	// Without a trace context from the client, the server starts a new trace.
+	otherCtx := serve("")
+	assert.NotEqual(t, clientCtx.TraceID, otherCtx.TraceID)
+}
@@ -0,0 +1,111 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"net/http"
+	"time"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+// chunkWriter splits the bytes written to it into chunks, as described by a
+// WriteChunking message, flushing each chunk to the network as soon as it is
+// complete.
+type chunkWriter struct {
+	respWriter http.ResponseWriter
+	sizes      []uint32
+	delay      time.Duration
+	// index into sizes of the current chunk
+	index int
+	// number of bytes still to be written for the current chunk
+	remaining int
+	// true once at least one chunk has been started
+	started bool
+}
+
+func newChunkWriter(respWriter http.ResponseWriter, chunking *conformancev1.WriteChunking) *chunkWriter {
+	var sizes []uint32
+	for _, size := range chunking.GetChunkSizes() {
+		if size > 0 {
+			sizes = append(sizes, size)
+		}
+	}
+	if len(sizes) == 0 {
+		return nil
+	}
+	return &chunkWriter{
+		respWriter: respWriter,
+		sizes:      sizes,
+		delay:      time.Duration(chunking.DelayMs) * time.Millisecond,
+	}
+}
+
+func (c *chunkWriter) Write(data []byte) (int, error) {
+	var total int
+	for len(data) > 0 {
+		if c.remaining == 0 {
+			c.nextChunk()
+		}
+		chunk := data
+		if len(chunk) > c.remaining {
+			chunk = chunk[:c.remaining]
+		}
+		n, err := c.respWriter.Write(chunk)
+		total += n
+		c.remaining -= n
+		if err != nil {
+			return total, err
+		}
+		data = data[n:]
+		if c.remaining == 0 {
+			c.flush()
+		}
+	}
+	return total, nil
+}
+
+func (c *chunkWriter) nextChunk() {
+	if c.started {
+		c.index = (c.index + 1) % len(c.sizes)
+		time.Sleep(c.delay)
+	}
+	c.started = true
+	c.remaining = int(c.sizes[c.index])
+}
+
+// Flush flushes the response, but only at a chunk boundary. The handler
+// may flush at other points, like after each message, which must not
+// split a chunk.
+func (c *chunkWriter) Flush() {
+	if c.remaining == 0 {
+		c.flush()
+	}
+}
+
+// finish flushes the final chunk, which may be shorter than its size
+// because the response body ended.
+func (c *chunkWriter) finish() {
+	if c.remaining > 0 {
+		c.remaining = 0
+		c.flush()
+	}
+}
+
+func (c *chunkWriter) flush() {
+	if flusher, ok := c.respWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
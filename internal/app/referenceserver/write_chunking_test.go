@@ -0,0 +1,129 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package referenceserver
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkWriter(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name           string
+		chunking       *conformancev1.WriteChunking
+		writes         []string
+		expectedChunks []string
+	}{
+		{
+			name:     "no sizes",
+			chunking: &conformancev1.WriteChunking{ChunkSizes: []uint32{0}},
+		},
+		{
+			name:           "single size repeated",
+			chunking:       &conformancev1.WriteChunking{ChunkSizes: []uint32{3}},
+			writes:         []string{"abcdefgh"},
+			expectedChunks: []string{"abc", "def", "gh"},
+		},
+		{
+			name:           "sizes cycled",
+			chunking:       &conformancev1.WriteChunking{ChunkSizes: []uint32{1, 0, 2}},
+			writes:         []string{"abcdefg"},
+			expectedChunks: []string{"a", "bc", "d", "ef", "g"},
+		},
+		{
+			name:           "chunks span writes",
+			chunking:       &conformancev1.WriteChunking{ChunkSizes: []uint32{4}},
+			writes:         []string{"ab", "cdef", "g", "h"},
+			expectedChunks: []string{"abcd", "efgh"},
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			recorder := &chunkRecorder{header: http.Header{}}
+			writer := newChunkWriter(recorder, testCase.chunking)
+			if testCase.expectedChunks == nil {
+				assert.Nil(t, writer)
+				return
+			}
+			require.NotNil(t, writer)
+			for _, data := range testCase.writes {
+				n, err := writer.Write([]byte(data))
+				require.NoError(t, err)
+				assert.Equal(t, len(data), n)
+			}
+			recorder.Flush()
+			assert.Equal(t, testCase.expectedChunks, recorder.chunks)
+		})
+	}
+}
+
+func TestRawResponderWriteChunking(t *testing.T) {
+	t.Parallel()
+	// Like connect-go, the handler flushes after each message of a stream.
+	// The chunks must not line up with the message boundaries.
+	messages := []string{"abcde", "fghij", "klmno"}
+	handler := rawResponder(http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		setWriteChunking(req.Context(), &conformancev1.WriteChunking{ChunkSizes: []uint32{3, 4}})
+		respWriter.WriteHeader(http.StatusOK)
+		respWriter.(http.Flusher).Flush()
+		for _, msg := range messages {
+			_, err := respWriter.Write([]byte(msg))
+			assert.NoError(t, err)
+			respWriter.(http.Flusher).Flush()
+		}
+	}))
+	recorder := &chunkRecorder{header: http.Header{}}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/test", http.NoBody)
+	require.NoError(t, err)
+	handler.ServeHTTP(recorder, req)
+	// The last chunk is flushed when the handler returns, even though it is
+	// shorter than its size.
+	assert.Equal(t, []string{"abc", "defg", "hij", "klmn", "o"}, recorder.chunks)
+	assert.Empty(t, recorder.pending.String())
+}
+
+// chunkRecorder is an http.ResponseWriter that records the data
+// written between each flush.
+type chunkRecorder struct {
+	header  http.Header
+	pending bytes.Buffer
+	chunks  []string
+}
+
+func (r *chunkRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *chunkRecorder) Write(data []byte) (int, error) {
+	return r.pending.Write(data)
+}
+
+func (r *chunkRecorder) WriteHeader(int) {}
+
+func (r *chunkRecorder) Flush() {
+	if r.pending.Len() > 0 {
+		r.chunks = append(r.chunks, r.pending.String())
+		r.pending.Reset()
+	}
+}
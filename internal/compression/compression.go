@@ -33,6 +33,26 @@ const (
 	Zstd     = "zstd"
 )
 
+// Name returns the IANA name of the given compression algorithm.
+func Name(compression conformancev1.Compression) (string, error) {
+	switch compression {
+	case conformancev1.Compression_COMPRESSION_UNSPECIFIED, conformancev1.Compression_COMPRESSION_IDENTITY:
+		return Identity, nil
+	case conformancev1.Compression_COMPRESSION_GZIP:
+		return Gzip, nil
+	case conformancev1.Compression_COMPRESSION_BR:
+		return Brotli, nil
+	case conformancev1.Compression_COMPRESSION_ZSTD:
+		return Zstd, nil
+	case conformancev1.Compression_COMPRESSION_DEFLATE:
+		return Deflate, nil
+	case conformancev1.Compression_COMPRESSION_SNAPPY:
+		return Snappy, nil
+	default:
+		return "", fmt.Errorf("unsupported compression scheme %v", compression)
+	}
+}
+
 // GetCompressor returns a compressor for the given compression algorithm.
 func GetCompressor(compression conformancev1.Compression) (connect.Compressor, error) {
 	switch compression {
@@ -21,6 +21,16 @@ import (
 	"github.com/klauspost/compress/zstd"
 )
 
+//nolint:gochecknoglobals
+var zstdDecoderOptions = []zstd.DOption{
+	// By default, the decoder fully decodes small in-memory inputs (such as
+	// a *bytes.Buffer holding a compressed message) all at once. That means
+	// a tiny message that decompresses to something huge is allocated in
+	// full, even though it will be abandoned once it exceeds the receive
+	// limit. So we always decode incrementally.
+	zstd.WithDecodeBuffersBelow(0),
+}
+
 // zstdDecompressor is a thin wrapper around a zstd Decoder.
 type zstdDecompressor struct {
 	decoder *zstd.Decoder
@@ -35,7 +45,7 @@ func (c *zstdDecompressor) Read(bytes []byte) (int, error) {
 func (c *zstdDecompressor) Reset(rdr io.Reader) error {
 	if c.decoder == nil {
 		var err error
-		c.decoder, err = zstd.NewReader(rdr)
+		c.decoder, err = zstd.NewReader(rdr, zstdDecoderOptions...)
 		return err
 	}
 	return c.decoder.Reset(rdr)
@@ -52,7 +62,7 @@ func (c *zstdDecompressor) Close() error {
 
 // NewZstdDecompressor returns a new Zstd Decompressor.
 func NewZstdDecompressor() connect.Decompressor {
-	d, err := zstd.NewReader(nil)
+	d, err := zstd.NewReader(nil, zstdDecoderOptions...)
 	if err != nil {
 		return &errorDecompressor{err: err}
 	}
@@ -35,6 +35,73 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type GeneratedData_Pattern int32
+
+const (
+	GeneratedData_PATTERN_UNSPECIFIED GeneratedData_Pattern = 0
+	// All bytes are zero. This data is highly compressible.
+	GeneratedData_PATTERN_ZEROS GeneratedData_Pattern = 1
+	// Pseudo-random bytes, which are effectively incompressible. They are
+	// produced by the SplitMix64 generator, initialized with the given seed.
+	// Each call to the generator adds the constant 0x9e3779b97f4a7c15 to its
+	// state, and then computes the output from the new state as follows:
+	//
+	//	z := state
+	//	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	//	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	//	output := z ^ (z >> 31)
+	//
+	// Each output contributes eight bytes, in little-endian order. The final
+	// output is truncated if size is not a multiple of eight.
+	GeneratedData_PATTERN_RANDOM GeneratedData_Pattern = 2
+	// The given text, repeated as many times as necessary. The final
+	// repetition is truncated if size is not a multiple of the text length.
+	GeneratedData_PATTERN_TEXT GeneratedData_Pattern = 3
+)
+
+// Enum value maps for GeneratedData_Pattern.
+var (
+	GeneratedData_Pattern_name = map[int32]string{
+		0: "PATTERN_UNSPECIFIED",
+		1: "PATTERN_ZEROS",
+		2: "PATTERN_RANDOM",
+		3: "PATTERN_TEXT",
+	}
+	GeneratedData_Pattern_value = map[string]int32{
+		"PATTERN_UNSPECIFIED": 0,
+		"PATTERN_ZEROS":       1,
+		"PATTERN_RANDOM":      2,
+		"PATTERN_TEXT":        3,
+	}
+)
+
+func (x GeneratedData_Pattern) Enum() *GeneratedData_Pattern {
+	p := new(GeneratedData_Pattern)
+	*p = x
+	return p
+}
+
+func (x GeneratedData_Pattern) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GeneratedData_Pattern) Descriptor() protoreflect.EnumDescriptor {
+	return file_connectrpc_conformance_v1_service_proto_enumTypes[0].Descriptor()
+}
+
+func (GeneratedData_Pattern) Type() protoreflect.EnumType {
+	return &file_connectrpc_conformance_v1_service_proto_enumTypes[0]
+}
+
+func (x GeneratedData_Pattern) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GeneratedData_Pattern.Descriptor instead.
+func (GeneratedData_Pattern) EnumDescriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{2, 0}
+}
+
 // A definition of a response to be sent from a single-response endpoint.
 // Can be used to define a response for unary or client-streaming calls.
 type UnaryResponseDefinition struct {
@@ -48,6 +115,7 @@ type UnaryResponseDefinition struct {
 	//
 	//	*UnaryResponseDefinition_ResponseData
 	//	*UnaryResponseDefinition_Error
+	//	*UnaryResponseDefinition_GeneratedResponseData
 	Response isUnaryResponseDefinition_Response `protobuf_oneof:"response"`
 	// Response trailers to send - together with the error if present
 	ResponseTrailers []*Header `protobuf:"bytes,4,rep,name=response_trailers,json=responseTrailers,proto3" json:"response_trailers,omitempty"`
@@ -59,6 +127,19 @@ type UnaryResponseDefinition struct {
 	//
 	// For test definitions, this field should be used instead of the above fields.
 	RawResponse *RawHTTPResponse `protobuf:"bytes,5,opt,name=raw_response,json=rawResponse,proto3" json:"raw_response,omitempty"`
+	// This field is only used by the reference server. If you are implementing a
+	// server under test, you can ignore this field or respond with an error if the
+	// server receives a request where it is set.
+	//
+	// If present, the server sends an HTTP/2 GOAWAY frame on the connection
+	// right before sending the response.
+	Goaway *GoAway `protobuf:"bytes,7,opt,name=goaway,proto3" json:"goaway,omitempty"`
+	// This field is only used by the reference server. If you are implementing a
+	// server under test, you can ignore this field or respond with an error if the
+	// server receives a request where it is set.
+	//
+	// If present, the server splits the response body into multiple writes.
+	WriteChunking *WriteChunking `protobuf:"bytes,8,opt,name=write_chunking,json=writeChunking,proto3" json:"write_chunking,omitempty"`
 }
 
 func (x *UnaryResponseDefinition) Reset() {
@@ -121,6 +202,13 @@ func (x *UnaryResponseDefinition) GetError() *Error {
 	return nil
 }
 
+func (x *UnaryResponseDefinition) GetGeneratedResponseData() *GeneratedData {
+	if x, ok := x.GetResponse().(*UnaryResponseDefinition_GeneratedResponseData); ok {
+		return x.GeneratedResponseData
+	}
+	return nil
+}
+
 func (x *UnaryResponseDefinition) GetResponseTrailers() []*Header {
 	if x != nil {
 		return x.ResponseTrailers
@@ -142,6 +230,20 @@ func (x *UnaryResponseDefinition) GetRawResponse() *RawHTTPResponse {
 	return nil
 }
 
+func (x *UnaryResponseDefinition) GetGoaway() *GoAway {
+	if x != nil {
+		return x.Goaway
+	}
+	return nil
+}
+
+func (x *UnaryResponseDefinition) GetWriteChunking() *WriteChunking {
+	if x != nil {
+		return x.WriteChunking
+	}
+	return nil
+}
+
 type isUnaryResponseDefinition_Response interface {
 	isUnaryResponseDefinition_Response()
 }
@@ -158,10 +260,19 @@ type UnaryResponseDefinition_Error struct {
 	Error *Error `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
 }
 
+type UnaryResponseDefinition_GeneratedResponseData struct {
+	// Response data for the server to generate and send. This is
+	// like response_data above, except the bytes are generated by
+	// the server instead of being included in the request.
+	GeneratedResponseData *GeneratedData `protobuf:"bytes,9,opt,name=generated_response_data,json=generatedResponseData,proto3,oneof"`
+}
+
 func (*UnaryResponseDefinition_ResponseData) isUnaryResponseDefinition_Response() {}
 
 func (*UnaryResponseDefinition_Error) isUnaryResponseDefinition_Response() {}
 
+func (*UnaryResponseDefinition_GeneratedResponseData) isUnaryResponseDefinition_Response() {}
+
 // A definition of responses to be sent from a streaming endpoint.
 // Can be used to define responses for server-streaming or bidi-streaming calls.
 type StreamResponseDefinition struct {
@@ -173,6 +284,10 @@ type StreamResponseDefinition struct {
 	ResponseHeaders []*Header `protobuf:"bytes,1,rep,name=response_headers,json=responseHeaders,proto3" json:"response_headers,omitempty"`
 	// Response data to send
 	ResponseData [][]byte `protobuf:"bytes,2,rep,name=response_data,json=responseData,proto3" json:"response_data,omitempty"`
+	// Response data for the server to generate and send. The server sends
+	// one response message for each entry here, after first sending one for
+	// each entry in response_data above.
+	GeneratedResponseData []*GeneratedData `protobuf:"bytes,10,rep,name=generated_response_data,json=generatedResponseData,proto3" json:"generated_response_data,omitempty"`
 	// Wait this many milliseconds before sending each response message
 	ResponseDelayMs uint32 `protobuf:"varint,3,opt,name=response_delay_ms,json=responseDelayMs,proto3" json:"response_delay_ms,omitempty"`
 	// Optional error to raise, but only after sending any response messages.
@@ -189,6 +304,30 @@ type StreamResponseDefinition struct {
 	//
 	// For test definitions, this field should be used instead of the above fields.
 	RawResponse *RawHTTPResponse `protobuf:"bytes,6,opt,name=raw_response,json=rawResponse,proto3" json:"raw_response,omitempty"`
+	// This field is only used by the reference server. If you are implementing a
+	// server under test, you can ignore this field or respond with an error if the
+	// server receives a request where it is set.
+	//
+	// If present, the server sends an HTTP/2 GOAWAY frame on the connection
+	// after sending the response headers and the configured number of response
+	// messages.
+	Goaway *GoAway `protobuf:"bytes,7,opt,name=goaway,proto3" json:"goaway,omitempty"`
+	// This field is only used by the reference server. If you are implementing a
+	// server under test, you can ignore this field or respond with an error if the
+	// server receives a request where it is set.
+	//
+	// Wait the given number of milliseconds before sending each response message.
+	// The first entry applies to the first message, the second entry to the second
+	// message, and so on. Messages without a corresponding entry use the above
+	// response_delay_ms.
+	ResponseDelaysMs []uint32 `protobuf:"varint,8,rep,packed,name=response_delays_ms,json=responseDelaysMs,proto3" json:"response_delays_ms,omitempty"`
+	// This field is only used by the reference server. If you are implementing a
+	// server under test, you can ignore this field or respond with an error if the
+	// server receives a request where it is set.
+	//
+	// If present, the server splits the response body into multiple writes,
+	// which may split individual messages, including their five-byte prefixes.
+	WriteChunking *WriteChunking `protobuf:"bytes,9,opt,name=write_chunking,json=writeChunking,proto3" json:"write_chunking,omitempty"`
 }
 
 func (x *StreamResponseDefinition) Reset() {
@@ -237,6 +376,13 @@ func (x *StreamResponseDefinition) GetResponseData() [][]byte {
 	return nil
 }
 
+func (x *StreamResponseDefinition) GetGeneratedResponseData() []*GeneratedData {
+	if x != nil {
+		return x.GeneratedResponseData
+	}
+	return nil
+}
+
 func (x *StreamResponseDefinition) GetResponseDelayMs() uint32 {
 	if x != nil {
 		return x.ResponseDelayMs
@@ -265,6 +411,251 @@ func (x *StreamResponseDefinition) GetRawResponse() *RawHTTPResponse {
 	return nil
 }
 
+func (x *StreamResponseDefinition) GetGoaway() *GoAway {
+	if x != nil {
+		return x.Goaway
+	}
+	return nil
+}
+
+func (x *StreamResponseDefinition) GetResponseDelaysMs() []uint32 {
+	if x != nil {
+		return x.ResponseDelaysMs
+	}
+	return nil
+}
+
+func (x *StreamResponseDefinition) GetWriteChunking() *WriteChunking {
+	if x != nil {
+		return x.WriteChunking
+	}
+	return nil
+}
+
+// GeneratedData describes response data that a server generates, so that
+// large responses can be requested without including them in the request.
+type GeneratedData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of bytes to generate.
+	Size uint32 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	// The pattern of the generated bytes. Required.
+	Pattern GeneratedData_Pattern `protobuf:"varint,2,opt,name=pattern,proto3,enum=connectrpc.conformance.v1.GeneratedData_Pattern" json:"pattern,omitempty"`
+	// The initial state of the generator, for PATTERN_RANDOM.
+	Seed uint64 `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	// The text to repeat, for PATTERN_TEXT. It must not be empty.
+	Text string `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *GeneratedData) Reset() {
+	*x = GeneratedData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GeneratedData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratedData) ProtoMessage() {}
+
+func (x *GeneratedData) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratedData.ProtoReflect.Descriptor instead.
+func (*GeneratedData) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GeneratedData) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *GeneratedData) GetPattern() GeneratedData_Pattern {
+	if x != nil {
+		return x.Pattern
+	}
+	return GeneratedData_PATTERN_UNSPECIFIED
+}
+
+func (x *GeneratedData) GetSeed() uint64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *GeneratedData) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// WriteChunking describes how the reference server splits a response body
+// into separate writes. Each chunk is flushed to the network as soon as it
+// is written, so that the client observes it separately from subsequent
+// chunks (as a separate HTTP/2 DATA frame or HTTP/1.1 chunk).
+type WriteChunking struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The sizes, in bytes, of consecutive chunks of the response body. If the
+	// body is longer than the sum of these sizes, the sizes are repeated. For
+	// example, a single size of 3 splits every five-byte message prefix. Sizes
+	// of zero are ignored.
+	//
+	// Chunks may be shorter than indicated when the server flushes the body
+	// for other reasons, such as after writing the end of a message.
+	ChunkSizes []uint32 `protobuf:"varint,1,rep,packed,name=chunk_sizes,json=chunkSizes,proto3" json:"chunk_sizes,omitempty"`
+	// The number of milliseconds to wait between writing consecutive chunks.
+	DelayMs uint32 `protobuf:"varint,2,opt,name=delay_ms,json=delayMs,proto3" json:"delay_ms,omitempty"`
+}
+
+func (x *WriteChunking) Reset() {
+	*x = WriteChunking{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteChunking) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteChunking) ProtoMessage() {}
+
+func (x *WriteChunking) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteChunking.ProtoReflect.Descriptor instead.
+func (*WriteChunking) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WriteChunking) GetChunkSizes() []uint32 {
+	if x != nil {
+		return x.ChunkSizes
+	}
+	return nil
+}
+
+func (x *WriteChunking) GetDelayMs() uint32 {
+	if x != nil {
+		return x.DelayMs
+	}
+	return 0
+}
+
+// GoAway describes an HTTP/2 GOAWAY frame for the reference server to send,
+// on the connection that carries the RPC, while the RPC is in progress.
+type GoAway struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The HTTP/2 error code to send in the frame. When zero (NO_ERROR), the
+	// shutdown is graceful: the server continues to serve any streams up to
+	// the last stream ID in the frame, but the client must use a new connection
+	// for subsequent RPCs. Otherwise, the server closes the connection
+	// immediately after sending the frame.
+	ErrorCode uint32 `protobuf:"varint,1,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	// If true, the last stream ID in the frame is lower than the ID of the
+	// RPC's stream, which tells the client that the RPC was not processed.
+	// The frame is then sent before any part of the response, and the server
+	// abandons the RPC, so the client must fail it with an UNAVAILABLE error.
+	// The client may transparently retry such an RPC on a new connection, but
+	// the server refuses every attempt. If false, the last stream ID includes
+	// the RPC's stream.
+	ExcludeStream bool `protobuf:"varint,2,opt,name=exclude_stream,json=excludeStream,proto3" json:"exclude_stream,omitempty"`
+	// For streaming responses, the number of response messages to send before
+	// sending the frame. This is ignored for unary responses and when
+	// exclude_stream is true.
+	AfterNumResponses uint32 `protobuf:"varint,3,opt,name=after_num_responses,json=afterNumResponses,proto3" json:"after_num_responses,omitempty"`
+}
+
+func (x *GoAway) Reset() {
+	*x = GoAway{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GoAway) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GoAway) ProtoMessage() {}
+
+func (x *GoAway) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GoAway.ProtoReflect.Descriptor instead.
+func (*GoAway) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GoAway) GetErrorCode() uint32 {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return 0
+}
+
+func (x *GoAway) GetExcludeStream() bool {
+	if x != nil {
+		return x.ExcludeStream
+	}
+	return false
+}
+
+func (x *GoAway) GetAfterNumResponses() uint32 {
+	if x != nil {
+		return x.AfterNumResponses
+	}
+	return 0
+}
+
 type UnaryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -279,7 +670,7 @@ type UnaryRequest struct {
 func (x *UnaryRequest) Reset() {
 	*x = UnaryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[2]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -292,7 +683,7 @@ func (x *UnaryRequest) String() string {
 func (*UnaryRequest) ProtoMessage() {}
 
 func (x *UnaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[2]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -305,7 +696,7 @@ func (x *UnaryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnaryRequest.ProtoReflect.Descriptor instead.
 func (*UnaryRequest) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{2}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UnaryRequest) GetResponseDefinition() *UnaryResponseDefinition {
@@ -334,7 +725,7 @@ type UnaryResponse struct {
 func (x *UnaryResponse) Reset() {
 	*x = UnaryResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[3]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -347,7 +738,7 @@ func (x *UnaryResponse) String() string {
 func (*UnaryResponse) ProtoMessage() {}
 
 func (x *UnaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[3]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -360,7 +751,7 @@ func (x *UnaryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnaryResponse.ProtoReflect.Descriptor instead.
 func (*UnaryResponse) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{3}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *UnaryResponse) GetPayload() *ConformancePayload {
@@ -384,7 +775,7 @@ type IdempotentUnaryRequest struct {
 func (x *IdempotentUnaryRequest) Reset() {
 	*x = IdempotentUnaryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[4]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -397,7 +788,7 @@ func (x *IdempotentUnaryRequest) String() string {
 func (*IdempotentUnaryRequest) ProtoMessage() {}
 
 func (x *IdempotentUnaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[4]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -410,7 +801,7 @@ func (x *IdempotentUnaryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IdempotentUnaryRequest.ProtoReflect.Descriptor instead.
 func (*IdempotentUnaryRequest) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{4}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *IdempotentUnaryRequest) GetResponseDefinition() *UnaryResponseDefinition {
@@ -439,7 +830,7 @@ type IdempotentUnaryResponse struct {
 func (x *IdempotentUnaryResponse) Reset() {
 	*x = IdempotentUnaryResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[5]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -452,7 +843,7 @@ func (x *IdempotentUnaryResponse) String() string {
 func (*IdempotentUnaryResponse) ProtoMessage() {}
 
 func (x *IdempotentUnaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[5]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -465,7 +856,7 @@ func (x *IdempotentUnaryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IdempotentUnaryResponse.ProtoReflect.Descriptor instead.
 func (*IdempotentUnaryResponse) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{5}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *IdempotentUnaryResponse) GetPayload() *ConformancePayload {
@@ -489,7 +880,7 @@ type ServerStreamRequest struct {
 func (x *ServerStreamRequest) Reset() {
 	*x = ServerStreamRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[6]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -502,7 +893,7 @@ func (x *ServerStreamRequest) String() string {
 func (*ServerStreamRequest) ProtoMessage() {}
 
 func (x *ServerStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[6]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -515,7 +906,7 @@ func (x *ServerStreamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerStreamRequest.ProtoReflect.Descriptor instead.
 func (*ServerStreamRequest) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{6}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ServerStreamRequest) GetResponseDefinition() *StreamResponseDefinition {
@@ -544,7 +935,7 @@ type ServerStreamResponse struct {
 func (x *ServerStreamResponse) Reset() {
 	*x = ServerStreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[7]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -557,7 +948,7 @@ func (x *ServerStreamResponse) String() string {
 func (*ServerStreamResponse) ProtoMessage() {}
 
 func (x *ServerStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[7]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,7 +961,7 @@ func (x *ServerStreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerStreamResponse.ProtoReflect.Descriptor instead.
 func (*ServerStreamResponse) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{7}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ServerStreamResponse) GetPayload() *ConformancePayload {
@@ -592,12 +983,20 @@ type ClientStreamRequest struct {
 	// Additional data for subsequent messages in the stream. Also
 	// used to pad the request size to test large request messages.
 	RequestData []byte `protobuf:"bytes,2,opt,name=request_data,json=requestData,proto3" json:"request_data,omitempty"`
+	// This field is only used by the reference server. If you are implementing a
+	// server under test, you can ignore this field.
+	//
+	// If non-zero, the server waits this many milliseconds before receiving each
+	// subsequent request message in the stream. It simulates a slow consumer,
+	// which forces the client to wait for flow control. This is only relevant in
+	// the first message in the stream and should be ignored in subsequent messages.
+	ReceiveDelayMs uint32 `protobuf:"varint,3,opt,name=receive_delay_ms,json=receiveDelayMs,proto3" json:"receive_delay_ms,omitempty"`
 }
 
 func (x *ClientStreamRequest) Reset() {
 	*x = ClientStreamRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[8]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -610,7 +1009,7 @@ func (x *ClientStreamRequest) String() string {
 func (*ClientStreamRequest) ProtoMessage() {}
 
 func (x *ClientStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[8]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -623,7 +1022,7 @@ func (x *ClientStreamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientStreamRequest.ProtoReflect.Descriptor instead.
 func (*ClientStreamRequest) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{8}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ClientStreamRequest) GetResponseDefinition() *UnaryResponseDefinition {
@@ -640,6 +1039,13 @@ func (x *ClientStreamRequest) GetRequestData() []byte {
 	return nil
 }
 
+func (x *ClientStreamRequest) GetReceiveDelayMs() uint32 {
+	if x != nil {
+		return x.ReceiveDelayMs
+	}
+	return 0
+}
+
 type ClientStreamResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -652,7 +1058,7 @@ type ClientStreamResponse struct {
 func (x *ClientStreamResponse) Reset() {
 	*x = ClientStreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[9]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -665,7 +1071,7 @@ func (x *ClientStreamResponse) String() string {
 func (*ClientStreamResponse) ProtoMessage() {}
 
 func (x *ClientStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[9]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -678,7 +1084,7 @@ func (x *ClientStreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientStreamResponse.ProtoReflect.Descriptor instead.
 func (*ClientStreamResponse) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{9}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ClientStreamResponse) GetPayload() *ConformancePayload {
@@ -718,7 +1124,7 @@ type BidiStreamRequest struct {
 func (x *BidiStreamRequest) Reset() {
 	*x = BidiStreamRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[10]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -731,7 +1137,7 @@ func (x *BidiStreamRequest) String() string {
 func (*BidiStreamRequest) ProtoMessage() {}
 
 func (x *BidiStreamRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[10]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -744,7 +1150,7 @@ func (x *BidiStreamRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BidiStreamRequest.ProtoReflect.Descriptor instead.
 func (*BidiStreamRequest) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{10}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *BidiStreamRequest) GetResponseDefinition() *StreamResponseDefinition {
@@ -780,7 +1186,7 @@ type BidiStreamResponse struct {
 func (x *BidiStreamResponse) Reset() {
 	*x = BidiStreamResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[11]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -793,7 +1199,7 @@ func (x *BidiStreamResponse) String() string {
 func (*BidiStreamResponse) ProtoMessage() {}
 
 func (x *BidiStreamResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[11]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -806,7 +1212,7 @@ func (x *BidiStreamResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BidiStreamResponse.ProtoReflect.Descriptor instead.
 func (*BidiStreamResponse) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{11}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *BidiStreamResponse) GetPayload() *ConformancePayload {
@@ -825,7 +1231,7 @@ type UnimplementedRequest struct {
 func (x *UnimplementedRequest) Reset() {
 	*x = UnimplementedRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[12]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -838,7 +1244,7 @@ func (x *UnimplementedRequest) String() string {
 func (*UnimplementedRequest) ProtoMessage() {}
 
 func (x *UnimplementedRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[12]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -851,7 +1257,7 @@ func (x *UnimplementedRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnimplementedRequest.ProtoReflect.Descriptor instead.
 func (*UnimplementedRequest) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{12}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{15}
 }
 
 type UnimplementedResponse struct {
@@ -863,7 +1269,7 @@ type UnimplementedResponse struct {
 func (x *UnimplementedResponse) Reset() {
 	*x = UnimplementedResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[13]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -876,7 +1282,7 @@ func (x *UnimplementedResponse) String() string {
 func (*UnimplementedResponse) ProtoMessage() {}
 
 func (x *UnimplementedResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[13]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -889,7 +1295,7 @@ func (x *UnimplementedResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnimplementedResponse.ProtoReflect.Descriptor instead.
 func (*UnimplementedResponse) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{13}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{16}
 }
 
 type ConformancePayload struct {
@@ -907,7 +1313,7 @@ type ConformancePayload struct {
 func (x *ConformancePayload) Reset() {
 	*x = ConformancePayload{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[14]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -920,7 +1326,7 @@ func (x *ConformancePayload) String() string {
 func (*ConformancePayload) ProtoMessage() {}
 
 func (x *ConformancePayload) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[14]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -933,7 +1339,7 @@ func (x *ConformancePayload) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConformancePayload.ProtoReflect.Descriptor instead.
 func (*ConformancePayload) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{14}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ConformancePayload) GetData() []byte {
@@ -972,7 +1378,7 @@ type Error struct {
 func (x *Error) Reset() {
 	*x = Error{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[15]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -985,7 +1391,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[15]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -998,7 +1404,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{15}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *Error) GetCode() Code {
@@ -1039,7 +1445,7 @@ type Header struct {
 func (x *Header) Reset() {
 	*x = Header{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[16]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1052,7 +1458,7 @@ func (x *Header) String() string {
 func (*Header) ProtoMessage() {}
 
 func (x *Header) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[16]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1065,7 +1471,7 @@ func (x *Header) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Header.ProtoReflect.Descriptor instead.
 func (*Header) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{16}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *Header) GetName() string {
@@ -1112,7 +1518,7 @@ type RawHTTPRequest struct {
 func (x *RawHTTPRequest) Reset() {
 	*x = RawHTTPRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[17]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1125,7 +1531,7 @@ func (x *RawHTTPRequest) String() string {
 func (*RawHTTPRequest) ProtoMessage() {}
 
 func (x *RawHTTPRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[17]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1138,7 +1544,7 @@ func (x *RawHTTPRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RawHTTPRequest.ProtoReflect.Descriptor instead.
 func (*RawHTTPRequest) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{17}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *RawHTTPRequest) GetVerb() string {
@@ -1229,6 +1635,7 @@ type MessageContents struct {
 	//	*MessageContents_Binary
 	//	*MessageContents_Text
 	//	*MessageContents_BinaryMessage
+	//	*MessageContents_Generated
 	Data isMessageContents_Data `protobuf_oneof:"data"`
 	// If specified and not identity, the above data will be
 	// compressed using the given algorithm.
@@ -1238,7 +1645,7 @@ type MessageContents struct {
 func (x *MessageContents) Reset() {
 	*x = MessageContents{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[18]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1251,7 +1658,7 @@ func (x *MessageContents) String() string {
 func (*MessageContents) ProtoMessage() {}
 
 func (x *MessageContents) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[18]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1264,7 +1671,7 @@ func (x *MessageContents) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageContents.ProtoReflect.Descriptor instead.
 func (*MessageContents) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{18}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{21}
 }
 
 func (m *MessageContents) GetData() isMessageContents_Data {
@@ -1295,6 +1702,13 @@ func (x *MessageContents) GetBinaryMessage() *anypb.Any {
 	return nil
 }
 
+func (x *MessageContents) GetGenerated() *GeneratedData {
+	if x, ok := x.GetData().(*MessageContents_Generated); ok {
+		return x.Generated
+	}
+	return nil
+}
+
 func (x *MessageContents) GetCompression() Compression {
 	if x != nil {
 		return x.Compression
@@ -1323,12 +1737,21 @@ type MessageContents_BinaryMessage struct {
 	BinaryMessage *anypb.Any `protobuf:"bytes,3,opt,name=binary_message,json=binaryMessage,proto3,oneof"`
 }
 
+type MessageContents_Generated struct {
+	// Generated bytes. This allows for large contents, such as
+	// data that compresses extremely well, without having to
+	// include all of the bytes.
+	Generated *GeneratedData `protobuf:"bytes,5,opt,name=generated,proto3,oneof"`
+}
+
 func (*MessageContents_Binary) isMessageContents_Data() {}
 
 func (*MessageContents_Text) isMessageContents_Data() {}
 
 func (*MessageContents_BinaryMessage) isMessageContents_Data() {}
 
+func (*MessageContents_Generated) isMessageContents_Data() {}
+
 // StreamContents represents a sequence of messages in a request body.
 type StreamContents struct {
 	state         protoimpl.MessageState
@@ -1342,7 +1765,7 @@ type StreamContents struct {
 func (x *StreamContents) Reset() {
 	*x = StreamContents{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[19]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1355,7 +1778,7 @@ func (x *StreamContents) String() string {
 func (*StreamContents) ProtoMessage() {}
 
 func (x *StreamContents) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[19]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1368,7 +1791,7 @@ func (x *StreamContents) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamContents.ProtoReflect.Descriptor instead.
 func (*StreamContents) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{19}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *StreamContents) GetItems() []*StreamContents_StreamItem {
@@ -1397,12 +1820,14 @@ type RawHTTPResponse struct {
 	Body isRawHTTPResponse_Body `protobuf_oneof:"body"`
 	// Trailers to be set on the response.
 	Trailers []*Header `protobuf:"bytes,5,rep,name=trailers,proto3" json:"trailers,omitempty"`
+	// If present, the body is split into multiple writes.
+	WriteChunking *WriteChunking `protobuf:"bytes,6,opt,name=write_chunking,json=writeChunking,proto3" json:"write_chunking,omitempty"`
 }
 
 func (x *RawHTTPResponse) Reset() {
 	*x = RawHTTPResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[20]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1415,7 +1840,7 @@ func (x *RawHTTPResponse) String() string {
 func (*RawHTTPResponse) ProtoMessage() {}
 
 func (x *RawHTTPResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[20]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1428,7 +1853,7 @@ func (x *RawHTTPResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RawHTTPResponse.ProtoReflect.Descriptor instead.
 func (*RawHTTPResponse) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{20}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *RawHTTPResponse) GetStatusCode() uint32 {
@@ -1473,6 +1898,13 @@ func (x *RawHTTPResponse) GetTrailers() []*Header {
 	return nil
 }
 
+func (x *RawHTTPResponse) GetWriteChunking() *WriteChunking {
+	if x != nil {
+		return x.WriteChunking
+	}
+	return nil
+}
+
 type isRawHTTPResponse_Body interface {
 	isRawHTTPResponse_Body()
 }
@@ -1522,7 +1954,7 @@ type ConformancePayload_RequestInfo struct {
 func (x *ConformancePayload_RequestInfo) Reset() {
 	*x = ConformancePayload_RequestInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[21]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1535,7 +1967,7 @@ func (x *ConformancePayload_RequestInfo) String() string {
 func (*ConformancePayload_RequestInfo) ProtoMessage() {}
 
 func (x *ConformancePayload_RequestInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[21]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1548,7 +1980,7 @@ func (x *ConformancePayload_RequestInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConformancePayload_RequestInfo.ProtoReflect.Descriptor instead.
 func (*ConformancePayload_RequestInfo) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{14, 0}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{17, 0}
 }
 
 func (x *ConformancePayload_RequestInfo) GetRequestHeaders() []*Header {
@@ -1591,7 +2023,7 @@ type ConformancePayload_ConnectGetInfo struct {
 func (x *ConformancePayload_ConnectGetInfo) Reset() {
 	*x = ConformancePayload_ConnectGetInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[22]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1604,7 +2036,7 @@ func (x *ConformancePayload_ConnectGetInfo) String() string {
 func (*ConformancePayload_ConnectGetInfo) ProtoMessage() {}
 
 func (x *ConformancePayload_ConnectGetInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[22]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1617,7 +2049,7 @@ func (x *ConformancePayload_ConnectGetInfo) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use ConformancePayload_ConnectGetInfo.ProtoReflect.Descriptor instead.
 func (*ConformancePayload_ConnectGetInfo) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{14, 1}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{17, 1}
 }
 
 func (x *ConformancePayload_ConnectGetInfo) GetQueryParams() []*Header {
@@ -1644,7 +2076,7 @@ type RawHTTPRequest_EncodedQueryParam struct {
 func (x *RawHTTPRequest_EncodedQueryParam) Reset() {
 	*x = RawHTTPRequest_EncodedQueryParam{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[23]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1657,7 +2089,7 @@ func (x *RawHTTPRequest_EncodedQueryParam) String() string {
 func (*RawHTTPRequest_EncodedQueryParam) ProtoMessage() {}
 
 func (x *RawHTTPRequest_EncodedQueryParam) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[23]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1670,7 +2102,7 @@ func (x *RawHTTPRequest_EncodedQueryParam) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RawHTTPRequest_EncodedQueryParam.ProtoReflect.Descriptor instead.
 func (*RawHTTPRequest_EncodedQueryParam) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{17, 0}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{20, 0}
 }
 
 func (x *RawHTTPRequest_EncodedQueryParam) GetName() string {
@@ -1707,7 +2139,7 @@ type StreamContents_StreamItem struct {
 func (x *StreamContents_StreamItem) Reset() {
 	*x = StreamContents_StreamItem{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[24]
+		mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1720,7 +2152,7 @@ func (x *StreamContents_StreamItem) String() string {
 func (*StreamContents_StreamItem) ProtoMessage() {}
 
 func (x *StreamContents_StreamItem) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[24]
+	mi := &file_connectrpc_conformance_v1_service_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1733,7 +2165,7 @@ func (x *StreamContents_StreamItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamContents_StreamItem.ProtoReflect.Descriptor instead.
 func (*StreamContents_StreamItem) Descriptor() ([]byte, []int) {
-	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{19, 0}
+	return file_connectrpc_conformance_v1_service_proto_rawDescGZIP(), []int{22, 0}
 }
 
 func (x *StreamContents_StreamItem) GetFlags() uint32 {
@@ -1768,7 +2200,7 @@ var file_connectrpc_conformance_v1_service_proto_rawDesc = []byte{
 	0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x2f,
 	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x67, 0x6f,
 	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e,
-	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9f, 0x03, 0x0a, 0x17, 0x55, 0x6e, 0x61, 0x72,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8f, 0x05, 0x0a, 0x17, 0x55, 0x6e, 0x61, 0x72,
 	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
 	0x69, 0x6f, 0x6e, 0x12, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f,
 	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e,
@@ -1781,20 +2213,35 @@ var file_connectrpc_conformance_v1_service_proto_rawDesc = []byte{
 	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
 	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
 	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72,
-	0x6f, 0x72, 0x12, 0x4e, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74,
-	0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x52, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65,
-	0x72, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64,
-	0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12, 0x4d,
-	0x0a, 0x0c, 0x72, 0x61, 0x77, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
+	0x6f, 0x72, 0x12, 0x62, 0x0a, 0x17, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52,
+	0x15, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x4e, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x52, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72,
+	0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79,
+	0x4d, 0x73, 0x12, 0x4d, 0x0a, 0x0c, 0x72, 0x61, 0x77, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0b, 0x72, 0x61, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x39, 0x0a, 0x06, 0x67, 0x6f, 0x61, 0x77, 0x61, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f,
+	0x41, 0x77, 0x61, 0x79, 0x52, 0x06, 0x67, 0x6f, 0x61, 0x77, 0x61, 0x79, 0x12, 0x4f, 0x0a, 0x0e,
+	0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
 	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x52, 0x0b, 0x72, 0x61, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0a, 0x0a,
-	0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x90, 0x03, 0x0a, 0x18, 0x53, 0x74,
+	0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x0d,
+	0x77, 0x72, 0x69, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x42, 0x0a, 0x0a,
+	0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xac, 0x05, 0x0a, 0x18, 0x53, 0x74,
 	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69,
 	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4c, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
 	0x73, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
@@ -1803,23 +2250,69 @@ var file_connectrpc_conformance_v1_service_proto_rawDesc = []byte{
 	0x64, 0x65, 0x72, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61,
 	0x64, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
 	0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x72, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65,
-	0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12, 0x36, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
-	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x4e, 0x0a,
-	0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65,
-	0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
-	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x10, 0x72, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x4d, 0x0a,
-	0x0c, 0x72, 0x61, 0x77, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
-	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52,
-	0x0b, 0x72, 0x61, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x96, 0x01, 0x0a,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x60, 0x0a, 0x17, 0x67, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x15, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2a, 0x0a, 0x11, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12, 0x36, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x4e, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x69,
+	0x6c, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x10, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x12,
+	0x4d, 0x0a, 0x0c, 0x72, 0x61, 0x77, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x52, 0x0b, 0x72, 0x61, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39,
+	0x0a, 0x06, 0x67, 0x6f, 0x61, 0x77, 0x61, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x41, 0x77, 0x61,
+	0x79, 0x52, 0x06, 0x67, 0x6f, 0x61, 0x77, 0x61, 0x79, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x73, 0x5f, 0x6d, 0x73, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x10, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44,
+	0x65, 0x6c, 0x61, 0x79, 0x73, 0x4d, 0x73, 0x12, 0x4f, 0x0a, 0x0e, 0x77, 0x72, 0x69, 0x74, 0x65,
+	0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x28, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x0d, 0x77, 0x72, 0x69, 0x74, 0x65,
+	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x22, 0xf4, 0x01, 0x0a, 0x0d, 0x47, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x4a,
+	0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x30, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65,
+	0x78, 0x74, 0x22, 0x5b, 0x0a, 0x07, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x17, 0x0a,
+	0x13, 0x50, 0x41, 0x54, 0x54, 0x45, 0x52, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x41, 0x54, 0x54, 0x45, 0x52,
+	0x4e, 0x5f, 0x5a, 0x45, 0x52, 0x4f, 0x53, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x41, 0x54,
+	0x54, 0x45, 0x52, 0x4e, 0x5f, 0x52, 0x41, 0x4e, 0x44, 0x4f, 0x4d, 0x10, 0x02, 0x12, 0x10, 0x0a,
+	0x0c, 0x50, 0x41, 0x54, 0x54, 0x45, 0x52, 0x4e, 0x5f, 0x54, 0x45, 0x58, 0x54, 0x10, 0x03, 0x22,
+	0x4b, 0x0a, 0x0d, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67,
+	0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x53, 0x69, 0x7a, 0x65,
+	0x73, 0x12, 0x19, 0x0a, 0x08, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x22, 0x7e, 0x0a, 0x06,
+	0x47, 0x6f, 0x41, 0x77, 0x61, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
+	0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x65,
+	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x2e, 0x0a, 0x13,
+	0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x75, 0x6d, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x61, 0x66, 0x74, 0x65, 0x72,
+	0x4e, 0x75, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x22, 0x96, 0x01, 0x0a,
 	0x0c, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x63, 0x0a,
 	0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69,
 	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x63, 0x6f, 0x6e,
@@ -1867,7 +2360,7 @@ var file_connectrpc_conformance_v1_service_proto_rawDesc = []byte{
 	0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
 	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
 	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52,
-	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x9d, 0x01, 0x0a, 0x13, 0x43, 0x6c, 0x69,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xc7, 0x01, 0x0a, 0x13, 0x43, 0x6c, 0x69,
 	0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x63, 0x0a, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x66,
 	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e,
@@ -1877,227 +2370,239 @@ var file_connectrpc_conformance_v1_service_proto_rawDesc = []byte{
 	0x6e, 0x52, 0x12, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e,
 	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x5f, 0x0a, 0x14, 0x43, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x47, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
-	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
-	0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0xbd, 0x01, 0x0a, 0x11, 0x42, 0x69,
-	0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x64, 0x0a, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x69,
-	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x63,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
-	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x12, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x64, 0x75,
-	0x70, 0x6c, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x66, 0x75, 0x6c, 0x6c,
-	0x44, 0x75, 0x70, 0x6c, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x22, 0x5d, 0x0a, 0x12, 0x42, 0x69, 0x64,
-	0x69, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x47, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e,
-	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52,
-	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x55, 0x6e, 0x69, 0x6d,
-	0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0x17, 0x0a, 0x15, 0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x65,
-	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x87, 0x04, 0x0a, 0x12, 0x43, 0x6f,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
-	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
-	0x64, 0x61, 0x74, 0x61, 0x12, 0x5c, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f,
-	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x63, 0x6f, 0x6e,
+	0x75, 0x65, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x44, 0x65, 0x6c, 0x61, 0x79,
+	0x4d, 0x73, 0x22, 0x5f, 0x0a, 0x14, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x22, 0xbd, 0x01, 0x0a, 0x11, 0x42, 0x69, 0x64, 0x69, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x64, 0x0a, 0x13, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x12, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1f, 0x0a, 0x0b, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x65, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x66, 0x75, 0x6c, 0x6c, 0x44, 0x75, 0x70, 0x6c, 0x65, 0x78,
+	0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44,
+	0x61, 0x74, 0x61, 0x22, 0x5d, 0x0a, 0x12, 0x42, 0x69, 0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x07, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e,
 	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
 	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
-	0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e,
-	0x66, 0x6f, 0x1a, 0xa6, 0x02, 0x0a, 0x0b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e,
-	0x66, 0x6f, 0x12, 0x4a, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x68, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0e,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x22,
-	0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x48, 0x00, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x88,
-	0x01, 0x01, 0x12, 0x30, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x73, 0x12, 0x66, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x5f,
-	0x67, 0x65, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c,
-	0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
-	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0e, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x0d, 0x0a, 0x0b,
-	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x1a, 0x56, 0x0a, 0x0e, 0x43,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x44, 0x0a,
-	0x0c, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
-	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72,
-	0x61, 0x6d, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x33, 0x0a,
-	0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f,
-	0x64, 0x65, 0x12, 0x1d, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x88, 0x01,
-	0x01, 0x12, 0x2e, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
-	0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x32, 0x0a,
-	0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x22, 0xd1, 0x04, 0x0a, 0x0e, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x65, 0x72, 0x62, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x76, 0x65, 0x72, 0x62, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x12, 0x3b, 0x0a, 0x07, 0x68, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07,
-	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x4b, 0x0a, 0x10, 0x72, 0x61, 0x77, 0x5f, 0x71,
-	0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
-	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x52, 0x0e, 0x72, 0x61, 0x77, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61,
-	0x72, 0x61, 0x6d, 0x73, 0x12, 0x6d, 0x0a, 0x14, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x5f,
-	0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
-	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52,
-	0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x6e,
-	0x63, 0x6f, 0x64, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52,
-	0x12, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72,
-	0x61, 0x6d, 0x73, 0x12, 0x42, 0x0a, 0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
-	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x48, 0x00,
-	0x52, 0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x43, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x1a, 0x8e, 0x01, 0x0a,
-	0x11, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72,
-	0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x40, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x17, 0x0a, 0x15, 0x55, 0x6e,
+	0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x87, 0x04, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x5c,
+	0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
+	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0xa6, 0x02, 0x0a,
+	0x0b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x4a, 0x0a, 0x0f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
 	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x61, 0x73, 0x65,
-	0x36, 0x34, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0c, 0x62, 0x61, 0x73, 0x65, 0x36, 0x34, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x06, 0x0a,
-	0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0xd2, 0x01, 0x0a, 0x0f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x06, 0x62, 0x69, 0x6e,
-	0x61, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x06, 0x62, 0x69, 0x6e,
-	0x61, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x3d, 0x0a, 0x0e, 0x62, 0x69, 0x6e,
-	0x61, 0x72, 0x79, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x48, 0x00, 0x52, 0x0d, 0x62, 0x69, 0x6e, 0x61, 0x72,
-	0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70,
-	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e,
+	0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x22, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x08,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x41, 0x6e, 0x79, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x66,
+	0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x5f, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x6e,
+	0x66, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
+	0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x47,
+	0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x47,
+	0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x5f, 0x6d, 0x73, 0x1a, 0x56, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x47, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x44, 0x0a, 0x0c, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e,
 	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xef, 0x01, 0x0a, 0x0e, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x4a, 0x0a,
-	0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x63,
+	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x52, 0x0b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x97, 0x01,
+	0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x07, 0x64,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41,
+	0x6e, 0x79, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x32, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xd1, 0x04, 0x0a, 0x0e,
+	0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x76, 0x65, 0x72, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x76, 0x65,
+	0x72, 0x62, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x75, 0x72, 0x69, 0x12, 0x3b, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x12, 0x4b, 0x0a, 0x10, 0x72, 0x61, 0x77, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0e,
+	0x72, 0x61, 0x77, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x6d,
+	0x0a, 0x14, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f,
+	0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x63,
 	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
-	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43,
-	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x74,
-	0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x1a, 0x90, 0x01, 0x0a, 0x0a, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x1b,
-	0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00,
-	0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x88, 0x01, 0x01, 0x12, 0x44, 0x0a, 0x07, 0x70,
-	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63,
+	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x12, 0x65, 0x6e, 0x63, 0x6f, 0x64,
+	0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x42, 0x0a,
+	0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63,
 	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
 	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
-	0x64, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0xbf, 0x02, 0x0a,
-	0x0f, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64,
-	0x65, 0x12, 0x3b, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
-	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x42,
-	0x0a, 0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x48, 0x00, 0x52, 0x05, 0x75, 0x6e, 0x61,
-	0x72, 0x79, 0x12, 0x43, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
-	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x48, 0x00, 0x52,
-	0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x3d, 0x0a, 0x08, 0x74, 0x72, 0x61, 0x69, 0x6c,
-	0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
-	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x08, 0x74, 0x72,
-	0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x42, 0x06, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x32, 0xb8,
-	0x05, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5a, 0x0a, 0x05, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x27,
-	0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
-	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x71, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x12, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
-	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
-	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
-	0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x30, 0x01, 0x12, 0x71, 0x0a, 0x0c, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x12, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
-	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
-	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x6d, 0x0a, 0x0a, 0x42, 0x69, 0x64, 0x69, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x2c, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x48, 0x00, 0x52, 0x05, 0x75, 0x6e, 0x61, 0x72,
+	0x79, 0x12, 0x43, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x48, 0x00, 0x52, 0x06,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x1a, 0x8e, 0x01, 0x0a, 0x11, 0x45, 0x6e, 0x63, 0x6f, 0x64,
+	0x65, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x40, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x61, 0x73, 0x65, 0x36, 0x34, 0x5f, 0x65, 0x6e, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x62, 0x61, 0x73, 0x65, 0x36,
+	0x34, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22,
+	0x9c, 0x02, 0x0a, 0x0f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x14, 0x0a,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x74,
+	0x65, 0x78, 0x74, 0x12, 0x3d, 0x0a, 0x0e, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e,
+	0x79, 0x48, 0x00, 0x52, 0x0d, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x48, 0x0a, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
 	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x42, 0x69, 0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
+	0x31, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x48,
+	0x00, 0x52, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x12, 0x48, 0x0a, 0x0b,
+	0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0xef,
+	0x01, 0x0a, 0x0e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x4a, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x34, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x1a, 0x90, 0x01,
+	0x0a, 0x0a, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x14, 0x0a, 0x05,
+	0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61,
+	0x67, 0x73, 0x12, 0x1b, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x48, 0x00, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x88, 0x01, 0x01, 0x12,
+	0x44, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x22, 0x90, 0x03, 0x0a, 0x0f, 0x52, 0x61, 0x77, 0x48, 0x54, 0x54, 0x50, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x73, 0x12, 0x42, 0x0a, 0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x48, 0x00, 0x52,
+	0x05, 0x75, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x43, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x3d, 0x0a, 0x08, 0x74,
+	0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x52, 0x08, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x65, 0x72, 0x73, 0x12, 0x4f, 0x0a, 0x0e, 0x77, 0x72,
+	0x69, 0x74, 0x65, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57,
+	0x72, 0x69, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x0d, 0x77, 0x72,
+	0x69, 0x74, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x42, 0x06, 0x0a, 0x04, 0x62,
+	0x6f, 0x64, 0x79, 0x32, 0xb8, 0x05, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5a, 0x0a, 0x05, 0x55, 0x6e,
+	0x61, 0x72, 0x79, 0x12, 0x27, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
 	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
-	0x42, 0x69, 0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x72, 0x0a, 0x0d, 0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c,
-	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x12, 0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x65,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x71, 0x0a, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x71, 0x0a, 0x0c, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x6d, 0x0a, 0x0a,
+	0x42, 0x69, 0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x2c, 0x2e, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
 	0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
-	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x7d, 0x0a, 0x0f, 0x49, 0x64,
-	0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x31, 0x2e,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x64, 0x69, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x72, 0x0a, 0x0d, 0x55,
+	0x6e, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x12, 0x2f, 0x2e, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e,
 	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f,
-	0x74, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x32, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
-	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x64, 0x65,
-	0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x42, 0x8d, 0x02, 0x0a, 0x1d, 0x63, 0x6f,
-	0x6d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
-	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0c, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x58, 0x63, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x6e, 0x66,
-	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x6e, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
-	0x6e, 0x63, 0x65, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x43, 0x58, 0xaa, 0x02, 0x19, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x19, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x72, 0x70, 0x63, 0x5c, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
-	0x5c, 0x56, 0x31, 0xe2, 0x02, 0x25, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
-	0x5c, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5c, 0x56, 0x31, 0x5c,
-	0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x1b, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x3a, 0x3a, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
-	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x69, 0x6d, 0x70, 0x6c,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x7d, 0x0a, 0x0f, 0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x61,
+	0x72, 0x79, 0x12, 0x31, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49,
+	0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x49, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x55, 0x6e, 0x61, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x03, 0x90, 0x02, 0x01, 0x42, 0x8d,
+	0x02, 0x0a, 0x1d, 0x63, 0x6f, 0x6d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70,
+	0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x42, 0x0c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01,
+	0x5a, 0x58, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x67, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x6f,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x63, 0x6f, 0x6e,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x43, 0x58,
+	0xaa, 0x02, 0x19, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x19, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x5c, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x25, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x72, 0x70, 0x63, 0x5c, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63,
+	0x65, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0xea, 0x02, 0x1b, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x3a, 0x3a, 0x43,
+	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2112,94 +2617,108 @@ func file_connectrpc_conformance_v1_service_proto_rawDescGZIP() []byte {
 	return file_connectrpc_conformance_v1_service_proto_rawDescData
 }
 
-var file_connectrpc_conformance_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_connectrpc_conformance_v1_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_connectrpc_conformance_v1_service_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_connectrpc_conformance_v1_service_proto_goTypes = []interface{}{
-	(*UnaryResponseDefinition)(nil),           // 0: connectrpc.conformance.v1.UnaryResponseDefinition
-	(*StreamResponseDefinition)(nil),          // 1: connectrpc.conformance.v1.StreamResponseDefinition
-	(*UnaryRequest)(nil),                      // 2: connectrpc.conformance.v1.UnaryRequest
-	(*UnaryResponse)(nil),                     // 3: connectrpc.conformance.v1.UnaryResponse
-	(*IdempotentUnaryRequest)(nil),            // 4: connectrpc.conformance.v1.IdempotentUnaryRequest
-	(*IdempotentUnaryResponse)(nil),           // 5: connectrpc.conformance.v1.IdempotentUnaryResponse
-	(*ServerStreamRequest)(nil),               // 6: connectrpc.conformance.v1.ServerStreamRequest
-	(*ServerStreamResponse)(nil),              // 7: connectrpc.conformance.v1.ServerStreamResponse
-	(*ClientStreamRequest)(nil),               // 8: connectrpc.conformance.v1.ClientStreamRequest
-	(*ClientStreamResponse)(nil),              // 9: connectrpc.conformance.v1.ClientStreamResponse
-	(*BidiStreamRequest)(nil),                 // 10: connectrpc.conformance.v1.BidiStreamRequest
-	(*BidiStreamResponse)(nil),                // 11: connectrpc.conformance.v1.BidiStreamResponse
-	(*UnimplementedRequest)(nil),              // 12: connectrpc.conformance.v1.UnimplementedRequest
-	(*UnimplementedResponse)(nil),             // 13: connectrpc.conformance.v1.UnimplementedResponse
-	(*ConformancePayload)(nil),                // 14: connectrpc.conformance.v1.ConformancePayload
-	(*Error)(nil),                             // 15: connectrpc.conformance.v1.Error
-	(*Header)(nil),                            // 16: connectrpc.conformance.v1.Header
-	(*RawHTTPRequest)(nil),                    // 17: connectrpc.conformance.v1.RawHTTPRequest
-	(*MessageContents)(nil),                   // 18: connectrpc.conformance.v1.MessageContents
-	(*StreamContents)(nil),                    // 19: connectrpc.conformance.v1.StreamContents
-	(*RawHTTPResponse)(nil),                   // 20: connectrpc.conformance.v1.RawHTTPResponse
-	(*ConformancePayload_RequestInfo)(nil),    // 21: connectrpc.conformance.v1.ConformancePayload.RequestInfo
-	(*ConformancePayload_ConnectGetInfo)(nil), // 22: connectrpc.conformance.v1.ConformancePayload.ConnectGetInfo
-	(*RawHTTPRequest_EncodedQueryParam)(nil),  // 23: connectrpc.conformance.v1.RawHTTPRequest.EncodedQueryParam
-	(*StreamContents_StreamItem)(nil),         // 24: connectrpc.conformance.v1.StreamContents.StreamItem
-	(Code)(0),                                 // 25: connectrpc.conformance.v1.Code
-	(*anypb.Any)(nil),                         // 26: google.protobuf.Any
-	(Compression)(0),                          // 27: connectrpc.conformance.v1.Compression
+	(GeneratedData_Pattern)(0),                // 0: connectrpc.conformance.v1.GeneratedData.Pattern
+	(*UnaryResponseDefinition)(nil),           // 1: connectrpc.conformance.v1.UnaryResponseDefinition
+	(*StreamResponseDefinition)(nil),          // 2: connectrpc.conformance.v1.StreamResponseDefinition
+	(*GeneratedData)(nil),                     // 3: connectrpc.conformance.v1.GeneratedData
+	(*WriteChunking)(nil),                     // 4: connectrpc.conformance.v1.WriteChunking
+	(*GoAway)(nil),                            // 5: connectrpc.conformance.v1.GoAway
+	(*UnaryRequest)(nil),                      // 6: connectrpc.conformance.v1.UnaryRequest
+	(*UnaryResponse)(nil),                     // 7: connectrpc.conformance.v1.UnaryResponse
+	(*IdempotentUnaryRequest)(nil),            // 8: connectrpc.conformance.v1.IdempotentUnaryRequest
+	(*IdempotentUnaryResponse)(nil),           // 9: connectrpc.conformance.v1.IdempotentUnaryResponse
+	(*ServerStreamRequest)(nil),               // 10: connectrpc.conformance.v1.ServerStreamRequest
+	(*ServerStreamResponse)(nil),              // 11: connectrpc.conformance.v1.ServerStreamResponse
+	(*ClientStreamRequest)(nil),               // 12: connectrpc.conformance.v1.ClientStreamRequest
+	(*ClientStreamResponse)(nil),              // 13: connectrpc.conformance.v1.ClientStreamResponse
+	(*BidiStreamRequest)(nil),                 // 14: connectrpc.conformance.v1.BidiStreamRequest
+	(*BidiStreamResponse)(nil),                // 15: connectrpc.conformance.v1.BidiStreamResponse
+	(*UnimplementedRequest)(nil),              // 16: connectrpc.conformance.v1.UnimplementedRequest
+	(*UnimplementedResponse)(nil),             // 17: connectrpc.conformance.v1.UnimplementedResponse
+	(*ConformancePayload)(nil),                // 18: connectrpc.conformance.v1.ConformancePayload
+	(*Error)(nil),                             // 19: connectrpc.conformance.v1.Error
+	(*Header)(nil),                            // 20: connectrpc.conformance.v1.Header
+	(*RawHTTPRequest)(nil),                    // 21: connectrpc.conformance.v1.RawHTTPRequest
+	(*MessageContents)(nil),                   // 22: connectrpc.conformance.v1.MessageContents
+	(*StreamContents)(nil),                    // 23: connectrpc.conformance.v1.StreamContents
+	(*RawHTTPResponse)(nil),                   // 24: connectrpc.conformance.v1.RawHTTPResponse
+	(*ConformancePayload_RequestInfo)(nil),    // 25: connectrpc.conformance.v1.ConformancePayload.RequestInfo
+	(*ConformancePayload_ConnectGetInfo)(nil), // 26: connectrpc.conformance.v1.ConformancePayload.ConnectGetInfo
+	(*RawHTTPRequest_EncodedQueryParam)(nil),  // 27: connectrpc.conformance.v1.RawHTTPRequest.EncodedQueryParam
+	(*StreamContents_StreamItem)(nil),         // 28: connectrpc.conformance.v1.StreamContents.StreamItem
+	(Code)(0),                                 // 29: connectrpc.conformance.v1.Code
+	(*anypb.Any)(nil),                         // 30: google.protobuf.Any
+	(Compression)(0),                          // 31: connectrpc.conformance.v1.Compression
 }
 var file_connectrpc_conformance_v1_service_proto_depIdxs = []int32{
-	16, // 0: connectrpc.conformance.v1.UnaryResponseDefinition.response_headers:type_name -> connectrpc.conformance.v1.Header
-	15, // 1: connectrpc.conformance.v1.UnaryResponseDefinition.error:type_name -> connectrpc.conformance.v1.Error
-	16, // 2: connectrpc.conformance.v1.UnaryResponseDefinition.response_trailers:type_name -> connectrpc.conformance.v1.Header
-	20, // 3: connectrpc.conformance.v1.UnaryResponseDefinition.raw_response:type_name -> connectrpc.conformance.v1.RawHTTPResponse
-	16, // 4: connectrpc.conformance.v1.StreamResponseDefinition.response_headers:type_name -> connectrpc.conformance.v1.Header
-	15, // 5: connectrpc.conformance.v1.StreamResponseDefinition.error:type_name -> connectrpc.conformance.v1.Error
-	16, // 6: connectrpc.conformance.v1.StreamResponseDefinition.response_trailers:type_name -> connectrpc.conformance.v1.Header
-	20, // 7: connectrpc.conformance.v1.StreamResponseDefinition.raw_response:type_name -> connectrpc.conformance.v1.RawHTTPResponse
-	0,  // 8: connectrpc.conformance.v1.UnaryRequest.response_definition:type_name -> connectrpc.conformance.v1.UnaryResponseDefinition
-	14, // 9: connectrpc.conformance.v1.UnaryResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
-	0,  // 10: connectrpc.conformance.v1.IdempotentUnaryRequest.response_definition:type_name -> connectrpc.conformance.v1.UnaryResponseDefinition
-	14, // 11: connectrpc.conformance.v1.IdempotentUnaryResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
-	1,  // 12: connectrpc.conformance.v1.ServerStreamRequest.response_definition:type_name -> connectrpc.conformance.v1.StreamResponseDefinition
-	14, // 13: connectrpc.conformance.v1.ServerStreamResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
-	0,  // 14: connectrpc.conformance.v1.ClientStreamRequest.response_definition:type_name -> connectrpc.conformance.v1.UnaryResponseDefinition
-	14, // 15: connectrpc.conformance.v1.ClientStreamResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
-	1,  // 16: connectrpc.conformance.v1.BidiStreamRequest.response_definition:type_name -> connectrpc.conformance.v1.StreamResponseDefinition
-	14, // 17: connectrpc.conformance.v1.BidiStreamResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
-	21, // 18: connectrpc.conformance.v1.ConformancePayload.request_info:type_name -> connectrpc.conformance.v1.ConformancePayload.RequestInfo
-	25, // 19: connectrpc.conformance.v1.Error.code:type_name -> connectrpc.conformance.v1.Code
-	26, // 20: connectrpc.conformance.v1.Error.details:type_name -> google.protobuf.Any
-	16, // 21: connectrpc.conformance.v1.RawHTTPRequest.headers:type_name -> connectrpc.conformance.v1.Header
-	16, // 22: connectrpc.conformance.v1.RawHTTPRequest.raw_query_params:type_name -> connectrpc.conformance.v1.Header
-	23, // 23: connectrpc.conformance.v1.RawHTTPRequest.encoded_query_params:type_name -> connectrpc.conformance.v1.RawHTTPRequest.EncodedQueryParam
-	18, // 24: connectrpc.conformance.v1.RawHTTPRequest.unary:type_name -> connectrpc.conformance.v1.MessageContents
-	19, // 25: connectrpc.conformance.v1.RawHTTPRequest.stream:type_name -> connectrpc.conformance.v1.StreamContents
-	26, // 26: connectrpc.conformance.v1.MessageContents.binary_message:type_name -> google.protobuf.Any
-	27, // 27: connectrpc.conformance.v1.MessageContents.compression:type_name -> connectrpc.conformance.v1.Compression
-	24, // 28: connectrpc.conformance.v1.StreamContents.items:type_name -> connectrpc.conformance.v1.StreamContents.StreamItem
-	16, // 29: connectrpc.conformance.v1.RawHTTPResponse.headers:type_name -> connectrpc.conformance.v1.Header
-	18, // 30: connectrpc.conformance.v1.RawHTTPResponse.unary:type_name -> connectrpc.conformance.v1.MessageContents
-	19, // 31: connectrpc.conformance.v1.RawHTTPResponse.stream:type_name -> connectrpc.conformance.v1.StreamContents
-	16, // 32: connectrpc.conformance.v1.RawHTTPResponse.trailers:type_name -> connectrpc.conformance.v1.Header
-	16, // 33: connectrpc.conformance.v1.ConformancePayload.RequestInfo.request_headers:type_name -> connectrpc.conformance.v1.Header
-	26, // 34: connectrpc.conformance.v1.ConformancePayload.RequestInfo.requests:type_name -> google.protobuf.Any
-	22, // 35: connectrpc.conformance.v1.ConformancePayload.RequestInfo.connect_get_info:type_name -> connectrpc.conformance.v1.ConformancePayload.ConnectGetInfo
-	16, // 36: connectrpc.conformance.v1.ConformancePayload.ConnectGetInfo.query_params:type_name -> connectrpc.conformance.v1.Header
-	18, // 37: connectrpc.conformance.v1.RawHTTPRequest.EncodedQueryParam.value:type_name -> connectrpc.conformance.v1.MessageContents
-	18, // 38: connectrpc.conformance.v1.StreamContents.StreamItem.payload:type_name -> connectrpc.conformance.v1.MessageContents
-	2,  // 39: connectrpc.conformance.v1.ConformanceService.Unary:input_type -> connectrpc.conformance.v1.UnaryRequest
-	6,  // 40: connectrpc.conformance.v1.ConformanceService.ServerStream:input_type -> connectrpc.conformance.v1.ServerStreamRequest
-	8,  // 41: connectrpc.conformance.v1.ConformanceService.ClientStream:input_type -> connectrpc.conformance.v1.ClientStreamRequest
-	10, // 42: connectrpc.conformance.v1.ConformanceService.BidiStream:input_type -> connectrpc.conformance.v1.BidiStreamRequest
-	12, // 43: connectrpc.conformance.v1.ConformanceService.Unimplemented:input_type -> connectrpc.conformance.v1.UnimplementedRequest
-	4,  // 44: connectrpc.conformance.v1.ConformanceService.IdempotentUnary:input_type -> connectrpc.conformance.v1.IdempotentUnaryRequest
-	3,  // 45: connectrpc.conformance.v1.ConformanceService.Unary:output_type -> connectrpc.conformance.v1.UnaryResponse
-	7,  // 46: connectrpc.conformance.v1.ConformanceService.ServerStream:output_type -> connectrpc.conformance.v1.ServerStreamResponse
-	9,  // 47: connectrpc.conformance.v1.ConformanceService.ClientStream:output_type -> connectrpc.conformance.v1.ClientStreamResponse
-	11, // 48: connectrpc.conformance.v1.ConformanceService.BidiStream:output_type -> connectrpc.conformance.v1.BidiStreamResponse
-	13, // 49: connectrpc.conformance.v1.ConformanceService.Unimplemented:output_type -> connectrpc.conformance.v1.UnimplementedResponse
-	5,  // 50: connectrpc.conformance.v1.ConformanceService.IdempotentUnary:output_type -> connectrpc.conformance.v1.IdempotentUnaryResponse
-	45, // [45:51] is the sub-list for method output_type
-	39, // [39:45] is the sub-list for method input_type
-	39, // [39:39] is the sub-list for extension type_name
-	39, // [39:39] is the sub-list for extension extendee
-	0,  // [0:39] is the sub-list for field type_name
+	20, // 0: connectrpc.conformance.v1.UnaryResponseDefinition.response_headers:type_name -> connectrpc.conformance.v1.Header
+	19, // 1: connectrpc.conformance.v1.UnaryResponseDefinition.error:type_name -> connectrpc.conformance.v1.Error
+	3,  // 2: connectrpc.conformance.v1.UnaryResponseDefinition.generated_response_data:type_name -> connectrpc.conformance.v1.GeneratedData
+	20, // 3: connectrpc.conformance.v1.UnaryResponseDefinition.response_trailers:type_name -> connectrpc.conformance.v1.Header
+	24, // 4: connectrpc.conformance.v1.UnaryResponseDefinition.raw_response:type_name -> connectrpc.conformance.v1.RawHTTPResponse
+	5,  // 5: connectrpc.conformance.v1.UnaryResponseDefinition.goaway:type_name -> connectrpc.conformance.v1.GoAway
+	4,  // 6: connectrpc.conformance.v1.UnaryResponseDefinition.write_chunking:type_name -> connectrpc.conformance.v1.WriteChunking
+	20, // 7: connectrpc.conformance.v1.StreamResponseDefinition.response_headers:type_name -> connectrpc.conformance.v1.Header
+	3,  // 8: connectrpc.conformance.v1.StreamResponseDefinition.generated_response_data:type_name -> connectrpc.conformance.v1.GeneratedData
+	19, // 9: connectrpc.conformance.v1.StreamResponseDefinition.error:type_name -> connectrpc.conformance.v1.Error
+	20, // 10: connectrpc.conformance.v1.StreamResponseDefinition.response_trailers:type_name -> connectrpc.conformance.v1.Header
+	24, // 11: connectrpc.conformance.v1.StreamResponseDefinition.raw_response:type_name -> connectrpc.conformance.v1.RawHTTPResponse
+	5,  // 12: connectrpc.conformance.v1.StreamResponseDefinition.goaway:type_name -> connectrpc.conformance.v1.GoAway
+	4,  // 13: connectrpc.conformance.v1.StreamResponseDefinition.write_chunking:type_name -> connectrpc.conformance.v1.WriteChunking
+	0,  // 14: connectrpc.conformance.v1.GeneratedData.pattern:type_name -> connectrpc.conformance.v1.GeneratedData.Pattern
+	1,  // 15: connectrpc.conformance.v1.UnaryRequest.response_definition:type_name -> connectrpc.conformance.v1.UnaryResponseDefinition
+	18, // 16: connectrpc.conformance.v1.UnaryResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
+	1,  // 17: connectrpc.conformance.v1.IdempotentUnaryRequest.response_definition:type_name -> connectrpc.conformance.v1.UnaryResponseDefinition
+	18, // 18: connectrpc.conformance.v1.IdempotentUnaryResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
+	2,  // 19: connectrpc.conformance.v1.ServerStreamRequest.response_definition:type_name -> connectrpc.conformance.v1.StreamResponseDefinition
+	18, // 20: connectrpc.conformance.v1.ServerStreamResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
+	1,  // 21: connectrpc.conformance.v1.ClientStreamRequest.response_definition:type_name -> connectrpc.conformance.v1.UnaryResponseDefinition
+	18, // 22: connectrpc.conformance.v1.ClientStreamResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
+	2,  // 23: connectrpc.conformance.v1.BidiStreamRequest.response_definition:type_name -> connectrpc.conformance.v1.StreamResponseDefinition
+	18, // 24: connectrpc.conformance.v1.BidiStreamResponse.payload:type_name -> connectrpc.conformance.v1.ConformancePayload
+	25, // 25: connectrpc.conformance.v1.ConformancePayload.request_info:type_name -> connectrpc.conformance.v1.ConformancePayload.RequestInfo
+	29, // 26: connectrpc.conformance.v1.Error.code:type_name -> connectrpc.conformance.v1.Code
+	30, // 27: connectrpc.conformance.v1.Error.details:type_name -> google.protobuf.Any
+	20, // 28: connectrpc.conformance.v1.RawHTTPRequest.headers:type_name -> connectrpc.conformance.v1.Header
+	20, // 29: connectrpc.conformance.v1.RawHTTPRequest.raw_query_params:type_name -> connectrpc.conformance.v1.Header
+	27, // 30: connectrpc.conformance.v1.RawHTTPRequest.encoded_query_params:type_name -> connectrpc.conformance.v1.RawHTTPRequest.EncodedQueryParam
+	22, // 31: connectrpc.conformance.v1.RawHTTPRequest.unary:type_name -> connectrpc.conformance.v1.MessageContents
+	23, // 32: connectrpc.conformance.v1.RawHTTPRequest.stream:type_name -> connectrpc.conformance.v1.StreamContents
+	30, // 33: connectrpc.conformance.v1.MessageContents.binary_message:type_name -> google.protobuf.Any
+	3,  // 34: connectrpc.conformance.v1.MessageContents.generated:type_name -> connectrpc.conformance.v1.GeneratedData
+	31, // 35: connectrpc.conformance.v1.MessageContents.compression:type_name -> connectrpc.conformance.v1.Compression
+	28, // 36: connectrpc.conformance.v1.StreamContents.items:type_name -> connectrpc.conformance.v1.StreamContents.StreamItem
+	20, // 37: connectrpc.conformance.v1.RawHTTPResponse.headers:type_name -> connectrpc.conformance.v1.Header
+	22, // 38: connectrpc.conformance.v1.RawHTTPResponse.unary:type_name -> connectrpc.conformance.v1.MessageContents
+	23, // 39: connectrpc.conformance.v1.RawHTTPResponse.stream:type_name -> connectrpc.conformance.v1.StreamContents
+	20, // 40: connectrpc.conformance.v1.RawHTTPResponse.trailers:type_name -> connectrpc.conformance.v1.Header
+	4,  // 41: connectrpc.conformance.v1.RawHTTPResponse.write_chunking:type_name -> connectrpc.conformance.v1.WriteChunking
+	20, // 42: connectrpc.conformance.v1.ConformancePayload.RequestInfo.request_headers:type_name -> connectrpc.conformance.v1.Header
+	30, // 43: connectrpc.conformance.v1.ConformancePayload.RequestInfo.requests:type_name -> google.protobuf.Any
+	26, // 44: connectrpc.conformance.v1.ConformancePayload.RequestInfo.connect_get_info:type_name -> connectrpc.conformance.v1.ConformancePayload.ConnectGetInfo
+	20, // 45: connectrpc.conformance.v1.ConformancePayload.ConnectGetInfo.query_params:type_name -> connectrpc.conformance.v1.Header
+	22, // 46: connectrpc.conformance.v1.RawHTTPRequest.EncodedQueryParam.value:type_name -> connectrpc.conformance.v1.MessageContents
+	22, // 47: connectrpc.conformance.v1.StreamContents.StreamItem.payload:type_name -> connectrpc.conformance.v1.MessageContents
+	6,  // 48: connectrpc.conformance.v1.ConformanceService.Unary:input_type -> connectrpc.conformance.v1.UnaryRequest
+	10, // 49: connectrpc.conformance.v1.ConformanceService.ServerStream:input_type -> connectrpc.conformance.v1.ServerStreamRequest
+	12, // 50: connectrpc.conformance.v1.ConformanceService.ClientStream:input_type -> connectrpc.conformance.v1.ClientStreamRequest
+	14, // 51: connectrpc.conformance.v1.ConformanceService.BidiStream:input_type -> connectrpc.conformance.v1.BidiStreamRequest
+	16, // 52: connectrpc.conformance.v1.ConformanceService.Unimplemented:input_type -> connectrpc.conformance.v1.UnimplementedRequest
+	8,  // 53: connectrpc.conformance.v1.ConformanceService.IdempotentUnary:input_type -> connectrpc.conformance.v1.IdempotentUnaryRequest
+	7,  // 54: connectrpc.conformance.v1.ConformanceService.Unary:output_type -> connectrpc.conformance.v1.UnaryResponse
+	11, // 55: connectrpc.conformance.v1.ConformanceService.ServerStream:output_type -> connectrpc.conformance.v1.ServerStreamResponse
+	13, // 56: connectrpc.conformance.v1.ConformanceService.ClientStream:output_type -> connectrpc.conformance.v1.ClientStreamResponse
+	15, // 57: connectrpc.conformance.v1.ConformanceService.BidiStream:output_type -> connectrpc.conformance.v1.BidiStreamResponse
+	17, // 58: connectrpc.conformance.v1.ConformanceService.Unimplemented:output_type -> connectrpc.conformance.v1.UnimplementedResponse
+	9,  // 59: connectrpc.conformance.v1.ConformanceService.IdempotentUnary:output_type -> connectrpc.conformance.v1.IdempotentUnaryResponse
+	54, // [54:60] is the sub-list for method output_type
+	48, // [48:54] is the sub-list for method input_type
+	48, // [48:48] is the sub-list for extension type_name
+	48, // [48:48] is the sub-list for extension extendee
+	0,  // [0:48] is the sub-list for field type_name
 }
 
 func init() { file_connectrpc_conformance_v1_service_proto_init() }
@@ -2234,7 +2753,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnaryRequest); i {
+			switch v := v.(*GeneratedData); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2246,7 +2765,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnaryResponse); i {
+			switch v := v.(*WriteChunking); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2258,7 +2777,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IdempotentUnaryRequest); i {
+			switch v := v.(*GoAway); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2270,7 +2789,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IdempotentUnaryResponse); i {
+			switch v := v.(*UnaryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2282,7 +2801,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServerStreamRequest); i {
+			switch v := v.(*UnaryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2294,7 +2813,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServerStreamResponse); i {
+			switch v := v.(*IdempotentUnaryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2306,7 +2825,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientStreamRequest); i {
+			switch v := v.(*IdempotentUnaryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2318,7 +2837,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientStreamResponse); i {
+			switch v := v.(*ServerStreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2330,7 +2849,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BidiStreamRequest); i {
+			switch v := v.(*ServerStreamResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2342,7 +2861,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BidiStreamResponse); i {
+			switch v := v.(*ClientStreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2354,7 +2873,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnimplementedRequest); i {
+			switch v := v.(*ClientStreamResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2366,7 +2885,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnimplementedResponse); i {
+			switch v := v.(*BidiStreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2378,7 +2897,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConformancePayload); i {
+			switch v := v.(*BidiStreamResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2390,7 +2909,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Error); i {
+			switch v := v.(*UnimplementedRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2402,7 +2921,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Header); i {
+			switch v := v.(*UnimplementedResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2414,7 +2933,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RawHTTPRequest); i {
+			switch v := v.(*ConformancePayload); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2426,7 +2945,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MessageContents); i {
+			switch v := v.(*Error); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2438,7 +2957,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StreamContents); i {
+			switch v := v.(*Header); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2450,7 +2969,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RawHTTPResponse); i {
+			switch v := v.(*RawHTTPRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2462,7 +2981,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConformancePayload_RequestInfo); i {
+			switch v := v.(*MessageContents); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2474,7 +2993,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ConformancePayload_ConnectGetInfo); i {
+			switch v := v.(*StreamContents); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2486,7 +3005,7 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RawHTTPRequest_EncodedQueryParam); i {
+			switch v := v.(*RawHTTPResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2498,6 +3017,42 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConformancePayload_RequestInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConformancePayload_ConnectGetInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RawHTTPRequest_EncodedQueryParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_service_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*StreamContents_StreamItem); i {
 			case 0:
 				return &v.state
@@ -2513,35 +3068,38 @@ func file_connectrpc_conformance_v1_service_proto_init() {
 	file_connectrpc_conformance_v1_service_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*UnaryResponseDefinition_ResponseData)(nil),
 		(*UnaryResponseDefinition_Error)(nil),
+		(*UnaryResponseDefinition_GeneratedResponseData)(nil),
 	}
-	file_connectrpc_conformance_v1_service_proto_msgTypes[15].OneofWrappers = []interface{}{}
-	file_connectrpc_conformance_v1_service_proto_msgTypes[17].OneofWrappers = []interface{}{
+	file_connectrpc_conformance_v1_service_proto_msgTypes[18].OneofWrappers = []interface{}{}
+	file_connectrpc_conformance_v1_service_proto_msgTypes[20].OneofWrappers = []interface{}{
 		(*RawHTTPRequest_Unary)(nil),
 		(*RawHTTPRequest_Stream)(nil),
 	}
-	file_connectrpc_conformance_v1_service_proto_msgTypes[18].OneofWrappers = []interface{}{
+	file_connectrpc_conformance_v1_service_proto_msgTypes[21].OneofWrappers = []interface{}{
 		(*MessageContents_Binary)(nil),
 		(*MessageContents_Text)(nil),
 		(*MessageContents_BinaryMessage)(nil),
+		(*MessageContents_Generated)(nil),
 	}
-	file_connectrpc_conformance_v1_service_proto_msgTypes[20].OneofWrappers = []interface{}{
+	file_connectrpc_conformance_v1_service_proto_msgTypes[23].OneofWrappers = []interface{}{
 		(*RawHTTPResponse_Unary)(nil),
 		(*RawHTTPResponse_Stream)(nil),
 	}
-	file_connectrpc_conformance_v1_service_proto_msgTypes[21].OneofWrappers = []interface{}{}
 	file_connectrpc_conformance_v1_service_proto_msgTypes[24].OneofWrappers = []interface{}{}
+	file_connectrpc_conformance_v1_service_proto_msgTypes[27].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_connectrpc_conformance_v1_service_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   25,
+			NumEnums:      1,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_connectrpc_conformance_v1_service_proto_goTypes,
 		DependencyIndexes: file_connectrpc_conformance_v1_service_proto_depIdxs,
+		EnumInfos:         file_connectrpc_conformance_v1_service_proto_enumTypes,
 		MessageInfos:      file_connectrpc_conformance_v1_service_proto_msgTypes,
 	}.Build()
 	File_connectrpc_conformance_v1_service_proto = out.File
@@ -147,6 +147,77 @@ func (TestSuite_ConnectVersionMode) EnumDescriptor() ([]byte, []int) {
 	return file_connectrpc_conformance_v1_suite_proto_rawDescGZIP(), []int{0, 1}
 }
 
+type ConnectionFault_Action int32
+
+const (
+	ConnectionFault_ACTION_UNSPECIFIED ConnectionFault_Action = 0
+	// Sends an RST_STREAM frame, with the configured error code, to the
+	// client. The server's stream is also reset, with a CANCEL code.
+	ConnectionFault_ACTION_RST_STREAM ConnectionFault_Action = 1
+	// Sends a GOAWAY frame, with the configured error code, to the client
+	// and then closes the connection. The last stream ID in the frame
+	// includes the RPC's stream, so the client may not assume that the RPC
+	// went unprocessed.
+	ConnectionFault_ACTION_GOAWAY ConnectionFault_Action = 2
+	// Abruptly closes the connection to the client, with a TCP reset.
+	ConnectionFault_ACTION_TCP_RESET ConnectionFault_Action = 3
+	// Ends the response stream, with an empty DATA frame that has the
+	// END_STREAM flag set, without sending any further data or trailers.
+	// The server's stream is reset, with a CANCEL code.
+	ConnectionFault_ACTION_TRUNCATE_BODY ConnectionFault_Action = 4
+	// Stops delivering anything for the RPC's stream to the client. The
+	// request should have a timeout or be canceled by the client, or else
+	// it will never complete.
+	ConnectionFault_ACTION_STALL ConnectionFault_Action = 5
+)
+
+// Enum value maps for ConnectionFault_Action.
+var (
+	ConnectionFault_Action_name = map[int32]string{
+		0: "ACTION_UNSPECIFIED",
+		1: "ACTION_RST_STREAM",
+		2: "ACTION_GOAWAY",
+		3: "ACTION_TCP_RESET",
+		4: "ACTION_TRUNCATE_BODY",
+		5: "ACTION_STALL",
+	}
+	ConnectionFault_Action_value = map[string]int32{
+		"ACTION_UNSPECIFIED":   0,
+		"ACTION_RST_STREAM":    1,
+		"ACTION_GOAWAY":        2,
+		"ACTION_TCP_RESET":     3,
+		"ACTION_TRUNCATE_BODY": 4,
+		"ACTION_STALL":         5,
+	}
+)
+
+func (x ConnectionFault_Action) Enum() *ConnectionFault_Action {
+	p := new(ConnectionFault_Action)
+	*p = x
+	return p
+}
+
+func (x ConnectionFault_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConnectionFault_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_connectrpc_conformance_v1_suite_proto_enumTypes[2].Descriptor()
+}
+
+func (ConnectionFault_Action) Type() protoreflect.EnumType {
+	return &file_connectrpc_conformance_v1_suite_proto_enumTypes[2]
+}
+
+func (x ConnectionFault_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConnectionFault_Action.Descriptor instead.
+func (ConnectionFault_Action) EnumDescriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_suite_proto_rawDescGZIP(), []int{2, 0}
+}
+
 // TestSuite represents a set of conformance test cases. This is also the schema
 // used for the structure of a YAML test file. Each YAML file represents a test
 // suite, which can contain numerous cases. Each test suite has various properties
@@ -195,6 +266,23 @@ type TestSuite struct {
 	// size of received messages. When true, mode should be set to indicate
 	// whether it is the client or the server that must support the limit.
 	ReliesOnMessageReceiveLimit bool `protobuf:"varint,12,opt,name=relies_on_message_receive_limit,json=reliesOnMessageReceiveLimit,proto3" json:"relies_on_message_receive_limit,omitempty"`
+	// If true, the cases in this suite rely on the server supporting gRPC
+	// server reflection. Such suites should have a mode of TEST_MODE_SERVER.
+	ReliesOnReflection bool `protobuf:"varint,13,opt,name=relies_on_reflection,json=reliesOnReflection,proto3" json:"relies_on_reflection,omitempty"`
+	// If true, the cases in this suite rely on the server supporting the
+	// gRPC health checking service. Such suites should have a mode of
+	// TEST_MODE_SERVER.
+	ReliesOnHealth bool `protobuf:"varint,14,opt,name=relies_on_health,json=reliesOnHealth,proto3" json:"relies_on_health,omitempty"`
+	// If true, the cases in this suite rely on the server supporting CORS
+	// (Cross-Origin Resource Sharing). Such suites should have a mode of
+	// TEST_MODE_SERVER.
+	ReliesOnCors bool `protobuf:"varint,15,opt,name=relies_on_cors,json=reliesOnCors,proto3" json:"relies_on_cors,omitempty"`
+	// If true, the cases in this suite rely on the test runner injecting
+	// connection-level faults (see TestCase.connection_fault). The runner
+	// does so via a proxy that must be able to parse the HTTP/2 frames
+	// exchanged between client and server. So such suites must only be
+	// relevant to HTTP/2 and are never run against TLS server configurations.
+	ReliesOnConnectionFaults bool `protobuf:"varint,16,opt,name=relies_on_connection_faults,json=reliesOnConnectionFaults,proto3" json:"relies_on_connection_faults,omitempty"`
 }
 
 func (x *TestSuite) Reset() {
@@ -313,6 +401,34 @@ func (x *TestSuite) GetReliesOnMessageReceiveLimit() bool {
 	return false
 }
 
+func (x *TestSuite) GetReliesOnReflection() bool {
+	if x != nil {
+		return x.ReliesOnReflection
+	}
+	return false
+}
+
+func (x *TestSuite) GetReliesOnHealth() bool {
+	if x != nil {
+		return x.ReliesOnHealth
+	}
+	return false
+}
+
+func (x *TestSuite) GetReliesOnCors() bool {
+	if x != nil {
+		return x.ReliesOnCors
+	}
+	return false
+}
+
+func (x *TestSuite) GetReliesOnConnectionFaults() bool {
+	if x != nil {
+		return x.ReliesOnConnectionFaults
+	}
+	return false
+}
+
 type TestCase struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -366,6 +482,32 @@ type TestCase struct {
 	// expected_response. As long as the actual error's code matches any of these, the
 	// error is considered conformant, and the test case can pass.
 	OtherAllowedErrorCodes []Code `protobuf:"varint,4,rep,packed,name=other_allowed_error_codes,json=otherAllowedErrorCodes,proto3,enum=connectrpc.conformance.v1.Code" json:"other_allowed_error_codes,omitempty"`
+	// If present, the test runner routes this case through a fault-injection
+	// proxy, which sits between the client and server and injects the given
+	// connection-level fault into the RPC. This may only be used in suites
+	// that have relies_on_connection_faults set to true. Since the fault
+	// prevents the client from receiving the full response, such cases must
+	// also define expected_response explicitly.
+	ConnectionFault *ConnectionFault `protobuf:"bytes,5,opt,name=connection_fault,json=connectionFault,proto3" json:"connection_fault,omitempty"`
+	// When true, the message contents of the raw request or raw response in
+	// this test case are compressed using the compression of each permutation
+	// of the test case, instead of the compression indicated in the contents.
+	// For stream contents, only items whose flags have the compressed bit
+	// (0x01) set are compressed.
+	// The values of any "content-encoding", "connect-content-encoding", and
+	// "grpc-encoding" headers in the raw request or response are also replaced
+	// with the name of that compression. This allows a single test case with
+	// a raw payload to be used with all compression algorithms relevant to the
+	// suite. Such suites should not include COMPRESSION_IDENTITY.
+	CompressRawContents bool `protobuf:"varint,6,opt,name=compress_raw_contents,json=compressRawContents,proto3" json:"compress_raw_contents,omitempty"`
+	// When true, the peak memory usage of the implementation under test is
+	// measured while it handles this test case, if the test runner is given
+	// a memory spike threshold. If it grows by more than the threshold, the
+	// test case fails. This is intended for test cases, like compression
+	// bombs, where a correct implementation needs little memory but a naive
+	// one may allocate a lot. Such test cases are sent to a separate server
+	// instance, one at a time, with no other test cases running concurrently.
+	MeasureMemoryUsage bool `protobuf:"varint,7,opt,name=measure_memory_usage,json=measureMemoryUsage,proto3" json:"measure_memory_usage,omitempty"`
 }
 
 func (x *TestCase) Reset() {
@@ -428,6 +570,107 @@ func (x *TestCase) GetOtherAllowedErrorCodes() []Code {
 	return nil
 }
 
+func (x *TestCase) GetConnectionFault() *ConnectionFault {
+	if x != nil {
+		return x.ConnectionFault
+	}
+	return nil
+}
+
+func (x *TestCase) GetCompressRawContents() bool {
+	if x != nil {
+		return x.CompressRawContents
+	}
+	return false
+}
+
+func (x *TestCase) GetMeasureMemoryUsage() bool {
+	if x != nil {
+		return x.MeasureMemoryUsage
+	}
+	return false
+}
+
+// ConnectionFault describes a connection-level event that is injected into
+// an RPC by the test runner's fault-injection proxy. The proxy forwards the
+// RPC normally until the fault is triggered: once the configured number of
+// response body bytes have been delivered to the client, or just before the
+// server would end the response, whichever comes first. At that point, the
+// given action is performed.
+//
+// Faults that affect the whole connection (GOAWAY and TCP resets) also affect
+// any other RPCs that share the connection. So clients under test should not
+// share connections across test cases.
+type ConnectionFault struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The fault to inject.
+	Action ConnectionFault_Action `protobuf:"varint,1,opt,name=action,proto3,enum=connectrpc.conformance.v1.ConnectionFault_Action" json:"action,omitempty"`
+	// The number of bytes of the response body (i.e. payload of HTTP/2 DATA
+	// frames) that are delivered to the client before the fault is injected.
+	// If zero, the fault is injected right after the response headers are
+	// delivered (or instead of them, if the response has no body).
+	AfterResponseBytes uint32 `protobuf:"varint,2,opt,name=after_response_bytes,json=afterResponseBytes,proto3" json:"after_response_bytes,omitempty"`
+	// The HTTP/2 error code to use in the RST_STREAM or GOAWAY frame. This is
+	// ignored for other actions. Note that zero is NO_ERROR; CANCEL is 8.
+	Http2ErrorCode uint32 `protobuf:"varint,3,opt,name=http2_error_code,json=http2ErrorCode,proto3" json:"http2_error_code,omitempty"`
+}
+
+func (x *ConnectionFault) Reset() {
+	*x = ConnectionFault{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConnectionFault) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionFault) ProtoMessage() {}
+
+func (x *ConnectionFault) ProtoReflect() protoreflect.Message {
+	mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionFault.ProtoReflect.Descriptor instead.
+func (*ConnectionFault) Descriptor() ([]byte, []int) {
+	return file_connectrpc_conformance_v1_suite_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ConnectionFault) GetAction() ConnectionFault_Action {
+	if x != nil {
+		return x.Action
+	}
+	return ConnectionFault_ACTION_UNSPECIFIED
+}
+
+func (x *ConnectionFault) GetAfterResponseBytes() uint32 {
+	if x != nil {
+		return x.AfterResponseBytes
+	}
+	return 0
+}
+
+func (x *ConnectionFault) GetHttp2ErrorCode() uint32 {
+	if x != nil {
+		return x.Http2ErrorCode
+	}
+	return 0
+}
+
 type TestCase_ExpandedSize struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -443,7 +686,7 @@ type TestCase_ExpandedSize struct {
 func (x *TestCase_ExpandedSize) Reset() {
 	*x = TestCase_ExpandedSize{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[2]
+		mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -456,7 +699,7 @@ func (x *TestCase_ExpandedSize) String() string {
 func (*TestCase_ExpandedSize) ProtoMessage() {}
 
 func (x *TestCase_ExpandedSize) ProtoReflect() protoreflect.Message {
-	mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[2]
+	mi := &file_connectrpc_conformance_v1_suite_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -491,7 +734,7 @@ var file_connectrpc_conformance_v1_suite_proto_rawDesc = []byte{
 	0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x1a, 0x26, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x6f,
 	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x96, 0x08, 0x0a, 0x09, 0x54, 0x65,
+	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd7, 0x09, 0x0a, 0x09, 0x54, 0x65,
 	0x73, 0x74, 0x53, 0x75, 0x69, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x41, 0x0a, 0x04, 0x6d,
 	0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
@@ -544,66 +787,111 @@ var file_connectrpc_conformance_v1_suite_proto_rawDesc = []byte{
 	0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0c, 0x20,
 	0x01, 0x28, 0x08, 0x52, 0x1b, 0x72, 0x65, 0x6c, 0x69, 0x65, 0x73, 0x4f, 0x6e, 0x4d, 0x65, 0x73,
 	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
-	0x22, 0x51, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x19, 0x0a, 0x15,
-	0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
-	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x45, 0x53, 0x54, 0x5f,
-	0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x14, 0x0a,
-	0x10, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x45,
-	0x52, 0x10, 0x02, 0x22, 0x7d, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x56, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x4e,
-	0x4e, 0x45, 0x43, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44,
-	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
-	0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49,
-	0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x10,
-	0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x56, 0x45, 0x52,
-	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x47, 0x4e, 0x4f, 0x52, 0x45,
-	0x10, 0x02, 0x22, 0xce, 0x03, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x12,
-	0x48, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
+	0x12, 0x30, 0x0a, 0x14, 0x72, 0x65, 0x6c, 0x69, 0x65, 0x73, 0x5f, 0x6f, 0x6e, 0x5f, 0x72, 0x65,
+	0x66, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12,
+	0x72, 0x65, 0x6c, 0x69, 0x65, 0x73, 0x4f, 0x6e, 0x52, 0x65, 0x66, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65, 0x6c, 0x69, 0x65, 0x73, 0x5f, 0x6f, 0x6e, 0x5f,
+	0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x72, 0x65,
+	0x6c, 0x69, 0x65, 0x73, 0x4f, 0x6e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x24, 0x0a, 0x0e,
+	0x72, 0x65, 0x6c, 0x69, 0x65, 0x73, 0x5f, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x72, 0x73, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x6c, 0x69, 0x65, 0x73, 0x4f, 0x6e, 0x43, 0x6f,
+	0x72, 0x73, 0x12, 0x3d, 0x0a, 0x1b, 0x72, 0x65, 0x6c, 0x69, 0x65, 0x73, 0x5f, 0x6f, 0x6e, 0x5f,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x72, 0x65, 0x6c, 0x69, 0x65, 0x73, 0x4f,
+	0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x61, 0x75, 0x6c, 0x74,
+	0x73, 0x22, 0x51, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x19, 0x0a,
+	0x15, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x45, 0x53, 0x54,
+	0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x14,
+	0x0a, 0x10, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x45, 0x52, 0x56,
+	0x45, 0x52, 0x10, 0x02, 0x22, 0x7d, 0x0a, 0x12, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f,
+	0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f,
+	0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x56, 0x45, 0x52, 0x53,
+	0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52, 0x45,
+	0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x56, 0x45,
+	0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x47, 0x4e, 0x4f, 0x52,
+	0x45, 0x10, 0x02, 0x22, 0x8b, 0x05, 0x0a, 0x08, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65,
+	0x12, 0x48, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x59, 0x0a, 0x0f, 0x65, 0x78,
+	0x70, 0x61, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65,
+	0x64, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x5c, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f,
 	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x59, 0x0a, 0x0f, 0x65, 0x78, 0x70,
-	0x61, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x30, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
-	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54,
-	0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64,
-	0x53, 0x69, 0x7a, 0x65, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x73, 0x12, 0x5c, 0x0a, 0x11, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
-	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x2f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e,
-	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x52, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x5a, 0x0a, 0x19, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x61, 0x6c, 0x6c, 0x6f,
-	0x77, 0x65, 0x64, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73, 0x18,
-	0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
-	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x16, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x41, 0x6c, 0x6c,
-	0x6f, 0x77, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x1a, 0x63,
-	0x0a, 0x0c, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x38,
-	0x0a, 0x16, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f,
-	0x74, 0x6f, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00,
-	0x52, 0x13, 0x73, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x54, 0x6f,
-	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x73, 0x69, 0x7a,
-	0x65, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x6c, 0x69,
-	0x6d, 0x69, 0x74, 0x42, 0x8b, 0x02, 0x0a, 0x1d, 0x63, 0x6f, 0x6d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x19, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x65, 0x64, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x16, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x41, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x12,
+	0x55, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6f, 0x6e, 0x6e,
 	0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e,
-	0x63, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0a, 0x53, 0x75, 0x69, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x74,
-	0x6f, 0x50, 0x01, 0x5a, 0x58, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f,
-	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
-	0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x3b,
-	0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x76, 0x31, 0xa2, 0x02, 0x03,
-	0x43, 0x43, 0x58, 0xaa, 0x02, 0x19, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
-	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x56, 0x31, 0xca,
-	0x02, 0x19, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x5c, 0x43, 0x6f, 0x6e,
-	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x25, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x5c, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x6e, 0x63, 0x65, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0xea, 0x02, 0x1b, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
-	0x3a, 0x3a, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x3a, 0x3a, 0x56,
-	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x46, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x5f, 0x72, 0x61, 0x77, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x61, 0x77, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x65,
+	0x61, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x75, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x6d, 0x65, 0x61, 0x73, 0x75, 0x72,
+	0x65, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x55, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x63, 0x0a, 0x0c,
+	0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x38, 0x0a, 0x16,
+	0x73, 0x69, 0x7a, 0x65, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x6f,
+	0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x13,
+	0x73, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x54, 0x6f, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x42, 0x19, 0x0a, 0x17, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x22, 0xc7, 0x02, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x46, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x49, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x31, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72,
+	0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x61, 0x75, 0x6c,
+	0x74, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x30, 0x0a, 0x14, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12,
+	0x61, 0x66, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x68, 0x74, 0x74, 0x70, 0x32, 0x5f, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x68, 0x74,
+	0x74, 0x70, 0x32, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x8c, 0x01, 0x0a,
+	0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x43, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x15, 0x0a, 0x11, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x53, 0x54, 0x5f, 0x53, 0x54,
+	0x52, 0x45, 0x41, 0x4d, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x47, 0x4f, 0x41, 0x57, 0x41, 0x59, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x43, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x43, 0x50, 0x5f, 0x52, 0x45, 0x53, 0x45, 0x54, 0x10, 0x03, 0x12,
+	0x18, 0x0a, 0x14, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x54, 0x52, 0x55, 0x4e, 0x43, 0x41,
+	0x54, 0x45, 0x5f, 0x42, 0x4f, 0x44, 0x59, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x41, 0x43, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x4c, 0x4c, 0x10, 0x05, 0x42, 0x8b, 0x02, 0x0a, 0x1d,
+	0x63, 0x6f, 0x6d, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x0a, 0x53,
+	0x75, 0x69, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x58, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x6e, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x6e, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x63, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x6e, 0x63, 0x65, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x43, 0x58, 0xaa, 0x02, 0x19, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x19, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x72, 0x70, 0x63, 0x5c, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65,
+	0x5c, 0x56, 0x31, 0xe2, 0x02, 0x25, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63,
+	0x5c, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x6e, 0x63, 0x65, 0x5c, 0x56, 0x31, 0x5c,
+	0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x1b, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x72, 0x70, 0x63, 0x3a, 0x3a, 0x43, 0x6f, 0x6e, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x6e, 0x63, 0x65, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -618,39 +906,43 @@ func file_connectrpc_conformance_v1_suite_proto_rawDescGZIP() []byte {
 	return file_connectrpc_conformance_v1_suite_proto_rawDescData
 }
 
-var file_connectrpc_conformance_v1_suite_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_connectrpc_conformance_v1_suite_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_connectrpc_conformance_v1_suite_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_connectrpc_conformance_v1_suite_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_connectrpc_conformance_v1_suite_proto_goTypes = []interface{}{
 	(TestSuite_TestMode)(0),           // 0: connectrpc.conformance.v1.TestSuite.TestMode
 	(TestSuite_ConnectVersionMode)(0), // 1: connectrpc.conformance.v1.TestSuite.ConnectVersionMode
-	(*TestSuite)(nil),                 // 2: connectrpc.conformance.v1.TestSuite
-	(*TestCase)(nil),                  // 3: connectrpc.conformance.v1.TestCase
-	(*TestCase_ExpandedSize)(nil),     // 4: connectrpc.conformance.v1.TestCase.ExpandedSize
-	(Protocol)(0),                     // 5: connectrpc.conformance.v1.Protocol
-	(HTTPVersion)(0),                  // 6: connectrpc.conformance.v1.HTTPVersion
-	(Codec)(0),                        // 7: connectrpc.conformance.v1.Codec
-	(Compression)(0),                  // 8: connectrpc.conformance.v1.Compression
-	(*ClientCompatRequest)(nil),       // 9: connectrpc.conformance.v1.ClientCompatRequest
-	(*ClientResponseResult)(nil),      // 10: connectrpc.conformance.v1.ClientResponseResult
-	(Code)(0),                         // 11: connectrpc.conformance.v1.Code
+	(ConnectionFault_Action)(0),       // 2: connectrpc.conformance.v1.ConnectionFault.Action
+	(*TestSuite)(nil),                 // 3: connectrpc.conformance.v1.TestSuite
+	(*TestCase)(nil),                  // 4: connectrpc.conformance.v1.TestCase
+	(*ConnectionFault)(nil),           // 5: connectrpc.conformance.v1.ConnectionFault
+	(*TestCase_ExpandedSize)(nil),     // 6: connectrpc.conformance.v1.TestCase.ExpandedSize
+	(Protocol)(0),                     // 7: connectrpc.conformance.v1.Protocol
+	(HTTPVersion)(0),                  // 8: connectrpc.conformance.v1.HTTPVersion
+	(Codec)(0),                        // 9: connectrpc.conformance.v1.Codec
+	(Compression)(0),                  // 10: connectrpc.conformance.v1.Compression
+	(*ClientCompatRequest)(nil),       // 11: connectrpc.conformance.v1.ClientCompatRequest
+	(*ClientResponseResult)(nil),      // 12: connectrpc.conformance.v1.ClientResponseResult
+	(Code)(0),                         // 13: connectrpc.conformance.v1.Code
 }
 var file_connectrpc_conformance_v1_suite_proto_depIdxs = []int32{
 	0,  // 0: connectrpc.conformance.v1.TestSuite.mode:type_name -> connectrpc.conformance.v1.TestSuite.TestMode
-	3,  // 1: connectrpc.conformance.v1.TestSuite.test_cases:type_name -> connectrpc.conformance.v1.TestCase
-	5,  // 2: connectrpc.conformance.v1.TestSuite.relevant_protocols:type_name -> connectrpc.conformance.v1.Protocol
-	6,  // 3: connectrpc.conformance.v1.TestSuite.relevant_http_versions:type_name -> connectrpc.conformance.v1.HTTPVersion
-	7,  // 4: connectrpc.conformance.v1.TestSuite.relevant_codecs:type_name -> connectrpc.conformance.v1.Codec
-	8,  // 5: connectrpc.conformance.v1.TestSuite.relevant_compressions:type_name -> connectrpc.conformance.v1.Compression
+	4,  // 1: connectrpc.conformance.v1.TestSuite.test_cases:type_name -> connectrpc.conformance.v1.TestCase
+	7,  // 2: connectrpc.conformance.v1.TestSuite.relevant_protocols:type_name -> connectrpc.conformance.v1.Protocol
+	8,  // 3: connectrpc.conformance.v1.TestSuite.relevant_http_versions:type_name -> connectrpc.conformance.v1.HTTPVersion
+	9,  // 4: connectrpc.conformance.v1.TestSuite.relevant_codecs:type_name -> connectrpc.conformance.v1.Codec
+	10, // 5: connectrpc.conformance.v1.TestSuite.relevant_compressions:type_name -> connectrpc.conformance.v1.Compression
 	1,  // 6: connectrpc.conformance.v1.TestSuite.connect_version_mode:type_name -> connectrpc.conformance.v1.TestSuite.ConnectVersionMode
-	9,  // 7: connectrpc.conformance.v1.TestCase.request:type_name -> connectrpc.conformance.v1.ClientCompatRequest
-	4,  // 8: connectrpc.conformance.v1.TestCase.expand_requests:type_name -> connectrpc.conformance.v1.TestCase.ExpandedSize
-	10, // 9: connectrpc.conformance.v1.TestCase.expected_response:type_name -> connectrpc.conformance.v1.ClientResponseResult
-	11, // 10: connectrpc.conformance.v1.TestCase.other_allowed_error_codes:type_name -> connectrpc.conformance.v1.Code
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	11, // 7: connectrpc.conformance.v1.TestCase.request:type_name -> connectrpc.conformance.v1.ClientCompatRequest
+	6,  // 8: connectrpc.conformance.v1.TestCase.expand_requests:type_name -> connectrpc.conformance.v1.TestCase.ExpandedSize
+	12, // 9: connectrpc.conformance.v1.TestCase.expected_response:type_name -> connectrpc.conformance.v1.ClientResponseResult
+	13, // 10: connectrpc.conformance.v1.TestCase.other_allowed_error_codes:type_name -> connectrpc.conformance.v1.Code
+	5,  // 11: connectrpc.conformance.v1.TestCase.connection_fault:type_name -> connectrpc.conformance.v1.ConnectionFault
+	2,  // 12: connectrpc.conformance.v1.ConnectionFault.action:type_name -> connectrpc.conformance.v1.ConnectionFault.Action
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_connectrpc_conformance_v1_suite_proto_init() }
@@ -686,6 +978,18 @@ func file_connectrpc_conformance_v1_suite_proto_init() {
 			}
 		}
 		file_connectrpc_conformance_v1_suite_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConnectionFault); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_connectrpc_conformance_v1_suite_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TestCase_ExpandedSize); i {
 			case 0:
 				return &v.state
@@ -698,14 +1002,14 @@ func file_connectrpc_conformance_v1_suite_proto_init() {
 			}
 		}
 	}
-	file_connectrpc_conformance_v1_suite_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	file_connectrpc_conformance_v1_suite_proto_msgTypes[3].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_connectrpc_conformance_v1_suite_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   3,
+			NumEnums:      3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
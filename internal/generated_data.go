@@ -0,0 +1,109 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+// The number of bytes that WriteGeneratedData writes at a time. This
+// must be a multiple of 8, for the random pattern.
+const generatedChunkSize = 64 * 1024
+
+// GenerateData returns the bytes described by the given generated data.
+func GenerateData(gen *conformancev1.GeneratedData) ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, gen.GetSize()))
+	if err := WriteGeneratedData(gen, buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteGeneratedData writes the bytes described by the given generated data
+// to the given writer. Unlike GenerateData, this does not need to allocate
+// all of the bytes at once, so it is suitable for very large sizes.
+func WriteGeneratedData(gen *conformancev1.GeneratedData, writer io.Writer) error {
+	var fill func(chunk []byte)
+	switch gen.GetPattern() {
+	case conformancev1.GeneratedData_PATTERN_ZEROS:
+		fill = func([]byte) {} // chunk is never modified, so stays zero
+	case conformancev1.GeneratedData_PATTERN_RANDOM:
+		state := gen.GetSeed()
+		fill = func(chunk []byte) {
+			for i := 0; i+8 <= len(chunk); i += 8 {
+				state += 0x9e3779b97f4a7c15
+				z := state
+				z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+				z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+				binary.LittleEndian.PutUint64(chunk[i:], z^(z>>31))
+			}
+		}
+	case conformancev1.GeneratedData_PATTERN_TEXT:
+		if gen.GetText() == "" {
+			return errors.New("generated data with text pattern must specify text")
+		}
+		text := []byte(gen.GetText())
+		var offset int
+		fill = func(chunk []byte) {
+			for i := range chunk {
+				chunk[i] = text[offset]
+				offset = (offset + 1) % len(text)
+			}
+		}
+	case conformancev1.GeneratedData_PATTERN_UNSPECIFIED:
+		return errors.New("generated data must specify a pattern")
+	default:
+		return fmt.Errorf("generated data has unknown pattern: %v", gen.GetPattern())
+	}
+	// The chunk is 8 bytes larger than what is written, so that the
+	// random pattern can always fill whole 8-byte words.
+	chunk := make([]byte, generatedChunkSize+8)
+	for remaining := int(gen.GetSize()); remaining > 0; {
+		size := generatedChunkSize
+		if remaining < size {
+			size = remaining
+		}
+		fill(chunk[:(size+7)/8*8])
+		if _, err := writer.Write(chunk[:size]); err != nil {
+			return err
+		}
+		remaining -= size
+	}
+	return nil
+}
+
+// StreamResponseData returns the data for all response messages of the given
+// stream response definition, including any data that the server generates.
+func StreamResponseData(def *conformancev1.StreamResponseDefinition) ([][]byte, error) {
+	if len(def.GetGeneratedResponseData()) == 0 {
+		return def.GetResponseData(), nil
+	}
+	responseData := make([][]byte, 0, len(def.GetResponseData())+len(def.GetGeneratedResponseData()))
+	responseData = append(responseData, def.GetResponseData()...)
+	for i, gen := range def.GetGeneratedResponseData() {
+		data, err := GenerateData(gen)
+		if err != nil {
+			return nil, fmt.Errorf("generated response data #%d: %w", i+1, err)
+		}
+		responseData = append(responseData, data)
+	}
+	return responseData, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateData(t *testing.T) {
+	t.Parallel()
+
+	// Known outputs of SplitMix64, starting from a seed of zero.
+	var random [24]byte
+	binary.LittleEndian.PutUint64(random[0:], 0xe220a8397b1dcdaf)
+	binary.LittleEndian.PutUint64(random[8:], 0x6e789e6aa1b965f4)
+	binary.LittleEndian.PutUint64(random[16:], 0x06c45d188009454f)
+
+	testCases := []struct {
+		name        string
+		gen         *conformancev1.GeneratedData
+		expected    []byte
+		expectedErr string
+	}{
+		{
+			name: "zeros",
+			gen: &conformancev1.GeneratedData{
+				Size:    10,
+				Pattern: conformancev1.GeneratedData_PATTERN_ZEROS,
+			},
+			expected: make([]byte, 10),
+		},
+		{
+			name: "random",
+			gen: &conformancev1.GeneratedData{
+				Size:    24,
+				Pattern: conformancev1.GeneratedData_PATTERN_RANDOM,
+			},
+			expected: random[:],
+		},
+		{
+			name: "random truncated",
+			gen: &conformancev1.GeneratedData{
+				Size:    13,
+				Pattern: conformancev1.GeneratedData_PATTERN_RANDOM,
+			},
+			expected: random[:13],
+		},
+		{
+			name: "text",
+			gen: &conformancev1.GeneratedData{
+				Size:    10,
+				Pattern: conformancev1.GeneratedData_PATTERN_TEXT,
+				Text:    "abc",
+			},
+			expected: []byte("abcabcabca"),
+		},
+		{
+			name: "text across chunks",
+			gen: &conformancev1.GeneratedData{
+				Size:    generatedChunkSize*2 + 5,
+				Pattern: conformancev1.GeneratedData_PATTERN_TEXT,
+				Text:    "abcdefg",
+			},
+			expected: bytes.Repeat([]byte("abcdefg"), (generatedChunkSize*2+5)/7+1)[:generatedChunkSize*2+5],
+		},
+		{
+			name: "random across chunks",
+			gen: &conformancev1.GeneratedData{
+				Size:    generatedChunkSize + 20,
+				Pattern: conformancev1.GeneratedData_PATTERN_RANDOM,
+				Seed:    123,
+			},
+			expected: splitMix64(123, generatedChunkSize+20),
+		},
+		{
+			name: "empty",
+			gen: &conformancev1.GeneratedData{
+				Pattern: conformancev1.GeneratedData_PATTERN_TEXT,
+				Text:    "abc",
+			},
+			expected: []byte{},
+		},
+		{
+			name: "text missing",
+			gen: &conformancev1.GeneratedData{
+				Size:    10,
+				Pattern: conformancev1.GeneratedData_PATTERN_TEXT,
+			},
+			expectedErr: "generated data with text pattern must specify text",
+		},
+		{
+			name: "pattern missing",
+			gen: &conformancev1.GeneratedData{
+				Size: 10,
+			},
+			expectedErr: "generated data must specify a pattern",
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			data, err := GenerateData(testCase.gen)
+			if testCase.expectedErr != "" {
+				require.EqualError(t, err, testCase.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, data)
+		})
+	}
+}
+
+// splitMix64 returns size bytes of output of SplitMix64, starting from
+// the given seed, in little-endian order.
+func splitMix64(seed uint64, size int) []byte {
+	data := make([]byte, 0, size+7)
+	state := seed
+	for len(data) < size {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		data = binary.LittleEndian.AppendUint64(data, z^(z>>31))
+	}
+	return data[:size]
+}
+
+func TestStreamResponseData(t *testing.T) {
+	t.Parallel()
+	def := &conformancev1.StreamResponseDefinition{
+		ResponseData: [][]byte{[]byte("foo")},
+		GeneratedResponseData: []*conformancev1.GeneratedData{
+			{Size: 3, Pattern: conformancev1.GeneratedData_PATTERN_ZEROS},
+			{Size: 4, Pattern: conformancev1.GeneratedData_PATTERN_TEXT, Text: "ab"},
+		},
+	}
+	data, err := StreamResponseData(def)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("foo"), {0, 0, 0}, []byte("abab")}, data)
+
+	def.GeneratedResponseData = append(def.GeneratedResponseData, &conformancev1.GeneratedData{Size: 1})
+	_, err = StreamResponseData(def)
+	require.EqualError(t, err, "generated response data #3: generated data must specify a pattern")
+}
@@ -0,0 +1,43 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// RequestsGoAway returns true if the given request messages, from a test
+// case, ask the server to send a GOAWAY frame while handling the RPC.
+func RequestsGoAway(reqs []*anypb.Any) bool {
+	if len(reqs) == 0 {
+		return false
+	}
+	msg, err := reqs[0].UnmarshalNew()
+	if err != nil {
+		return false // the caller deals with invalid messages
+	}
+	switch msg := msg.(type) {
+	case interface {
+		GetResponseDefinition() *conformancev1.UnaryResponseDefinition
+	}:
+		return msg.GetResponseDefinition().GetGoaway() != nil
+	case interface {
+		GetResponseDefinition() *conformancev1.StreamResponseDefinition
+	}:
+		return msg.GetResponseDefinition().GetGoaway() != nil
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestRequestsGoAway(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		msgs     []proto.Message
+		expected bool
+	}{
+		{
+			name: "no messages",
+		},
+		{
+			name: "unary without goaway",
+			msgs: []proto.Message{&conformancev1.UnaryRequest{
+				ResponseDefinition: &conformancev1.UnaryResponseDefinition{},
+			}},
+		},
+		{
+			name: "unary with goaway",
+			msgs: []proto.Message{&conformancev1.UnaryRequest{
+				ResponseDefinition: &conformancev1.UnaryResponseDefinition{Goaway: &conformancev1.GoAway{}},
+			}},
+			expected: true,
+		},
+		{
+			name: "stream with goaway",
+			msgs: []proto.Message{&conformancev1.ServerStreamRequest{
+				ResponseDefinition: &conformancev1.StreamResponseDefinition{Goaway: &conformancev1.GoAway{}},
+			}},
+			expected: true,
+		},
+		{
+			name: "goaway only in later message",
+			msgs: []proto.Message{
+				&conformancev1.BidiStreamRequest{},
+				&conformancev1.BidiStreamRequest{
+					ResponseDefinition: &conformancev1.StreamResponseDefinition{Goaway: &conformancev1.GoAway{}},
+				},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			reqs := make([]*anypb.Any, len(testCase.msgs))
+			for i, msg := range testCase.msgs {
+				var err error
+				reqs[i], err = anypb.New(msg)
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testCase.expected, RequestsGoAway(reqs))
+		})
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrInvalidGRPCWebText is returned when decoding "grpc-web-text" data that
+// is not valid base64.
+var ErrInvalidGRPCWebText = errors.New("invalid grpc-web-text data")
+
+const (
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+)
+
+// IsGRPCWebTextContentType returns true if the given content-type indicates
+// the "grpc-web-text" variant of the gRPC-Web protocol.
+func IsGRPCWebTextContentType(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return contentType == grpcWebTextContentType ||
+		strings.HasPrefix(contentType, grpcWebTextContentType+"+")
+}
+
+// IsGRPCWebBinaryContentType returns true if the given content-type indicates
+// the normal, binary variant of the gRPC-Web protocol.
+func IsGRPCWebBinaryContentType(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return contentType == grpcWebContentType ||
+		strings.HasPrefix(contentType, grpcWebContentType+"+")
+}
+
+// GRPCWebTextToBinaryContentType converts a "grpc-web-text" content-type to
+// the corresponding binary gRPC-Web content-type, preserving any codec suffix.
+// Other content-types are returned unchanged.
+func GRPCWebTextToBinaryContentType(contentType string) string {
+	if !IsGRPCWebTextContentType(contentType) {
+		return contentType
+	}
+	return grpcWebContentType + contentType[len(grpcWebTextContentType):]
+}
+
+// GRPCWebBinaryToTextContentType converts a binary gRPC-Web content-type to
+// the corresponding "grpc-web-text" content-type, preserving any codec suffix.
+// Other content-types are returned unchanged.
+func GRPCWebBinaryToTextContentType(contentType string) string {
+	if !IsGRPCWebBinaryContentType(contentType) {
+		return contentType
+	}
+	return grpcWebTextContentType + contentType[len(grpcWebContentType):]
+}
+
+// GRPCWebTextDecoder incrementally decodes the body of a "grpc-web-text"
+// request or response. Such bodies are base64-encoded, but a sender may encode
+// each chunk of data separately, so the body can be a concatenation of several
+// padded base64 strings. The decoder accepts data in arbitrary pieces, which
+// need not be aligned with the sender's chunks.
+type GRPCWebTextDecoder struct {
+	// un-decoded data that did not comprise a complete 4-byte quantum
+	pending []byte
+	// offset in the encoded stream of the start of pending
+	offset int
+}
+
+// Decode decodes as much of the given data as possible, combined with any
+// data buffered from previous calls. Data that does not form a complete
+// 4-character base64 quantum is buffered until the next call. An error
+// is returned if the data is not valid base64. Once an error is returned,
+// the decoder should not be used anymore.
+func (d *GRPCWebTextDecoder) Decode(data []byte) ([]byte, error) {
+	d.pending = append(d.pending, data...)
+	var result []byte
+	for len(d.pending) >= 4 {
+		end := len(d.pending) / 4 * 4
+		// Padding can only appear at the end of a chunk, so we decode
+		// through the end of the quantum with the first padding char.
+		if pad := bytes.IndexByte(d.pending[:end], '='); pad >= 0 {
+			end = (pad/4 + 1) * 4
+		}
+		decoded := make([]byte, base64.StdEncoding.DecodedLen(end))
+		n, err := base64.StdEncoding.Decode(decoded, d.pending[:end])
+		if err != nil {
+			var corruptErr base64.CorruptInputError
+			if errors.As(err, &corruptErr) {
+				return nil, fmt.Errorf("%w at offset %d", ErrInvalidGRPCWebText, d.offset+int(corruptErr))
+			}
+			return nil, fmt.Errorf("%w at offset %d: %w", ErrInvalidGRPCWebText, d.offset, err)
+		}
+		result = append(result, decoded[:n]...)
+		d.pending = d.pending[end:]
+		d.offset += end
+	}
+	return result, nil
+}
+
+// Close returns an error if the decoder has buffered data that does not form
+// a complete base64 quantum. It should be called when the end of the encoded
+// data is reached.
+func (d *GRPCWebTextDecoder) Close() error {
+	if len(d.pending) > 0 {
+		return fmt.Errorf("%w at offset %d: %w", ErrInvalidGRPCWebText, d.offset, io.ErrUnexpectedEOF)
+	}
+	return nil
+}
+
+// NewGRPCWebTextDecodingReader returns a reader that decodes the given
+// "grpc-web-text" body.
+func NewGRPCWebTextDecodingReader(body io.ReadCloser) io.ReadCloser {
+	return &grpcWebTextDecodingReader{body: body}
+}
+
+type grpcWebTextDecodingReader struct {
+	body    io.ReadCloser
+	decoder GRPCWebTextDecoder
+	buf     []byte
+	err     error
+}
+
+func (r *grpcWebTextDecodingReader) Read(data []byte) (int, error) {
+	for len(r.buf) == 0 && r.err == nil {
+		chunk := make([]byte, max(len(data), 512))
+		n, err := r.body.Read(chunk)
+		if n > 0 {
+			decoded, decodeErr := r.decoder.Decode(chunk[:n])
+			if decodeErr != nil {
+				err = decodeErr
+			}
+			r.buf = decoded
+		}
+		if errors.Is(err, io.EOF) {
+			if closeErr := r.decoder.Close(); closeErr != nil {
+				err = closeErr
+			}
+		}
+		r.err = err
+	}
+	if len(r.buf) > 0 {
+		n := copy(data, r.buf)
+		r.buf = r.buf[n:]
+		return n, nil
+	}
+	return 0, r.err
+}
+
+func (r *grpcWebTextDecodingReader) Close() error {
+	return r.body.Close()
+}
+
+// NewGRPCWebTextEncodingReader returns a reader that base64-encodes the
+// given binary gRPC-Web body. Each chunk read from body is encoded
+// separately, with padding, like a sender that flushes as it goes.
+func NewGRPCWebTextEncodingReader(body io.ReadCloser) io.ReadCloser {
+	return &grpcWebTextEncodingReader{body: body}
+}
+
+type grpcWebTextEncodingReader struct {
+	body io.ReadCloser
+	buf  []byte
+	err  error
+}
+
+func (r *grpcWebTextEncodingReader) Read(data []byte) (int, error) {
+	for len(r.buf) == 0 && r.err == nil {
+		chunk := make([]byte, max(len(data)/4*3, 512))
+		n, err := r.body.Read(chunk)
+		if n > 0 {
+			r.buf = GRPCWebTextEncode(chunk[:n])
+		}
+		r.err = err
+	}
+	if len(r.buf) > 0 {
+		n := copy(data, r.buf)
+		r.buf = r.buf[n:]
+		return n, nil
+	}
+	return 0, r.err
+}
+
+func (r *grpcWebTextEncodingReader) Close() error {
+	return r.body.Close()
+}
+
+// GRPCWebTextEncode base64-encodes the given data, with padding, for
+// use in a "grpc-web-text" body.
+func GRPCWebTextEncode(data []byte) []byte {
+	encoded := make([]byte, base64.StdEncoding.EncodedLen(len(data)))
+	base64.StdEncoding.Encode(encoded, data)
+	return encoded
+}
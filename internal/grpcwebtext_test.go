@@ -0,0 +1,130 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGRPCWebTextDecoder(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		chunks   []string
+		expected string
+		errorMsg string
+	}{
+		{
+			name:     "single-chunk",
+			chunks:   []string{"aGVsbG8sIHdvcmxk"},
+			expected: "hello, world",
+		},
+		{
+			name:     "padded-chunks",
+			chunks:   []string{"aGVsbG8=LCA=d29ybGQ="},
+			expected: "hello, world",
+		},
+		{
+			name:     "split-mid-quantum",
+			chunks:   []string{"aGV", "sbG8=LC", "A=d29y", "bGQ", "="},
+			expected: "hello, world",
+		},
+		{
+			name:     "double-padding",
+			chunks:   []string{"aA==aQ=="},
+			expected: "hi",
+		},
+		{
+			name:     "padding-mid-quantum",
+			chunks:   []string{"aGVsbG8=LC=Ad29ybGQ="},
+			errorMsg: "invalid grpc-web-text data at offset 10",
+		},
+		{
+			name:     "invalid-char",
+			chunks:   []string{"aGVs", "b*8="},
+			errorMsg: "invalid grpc-web-text data at offset 5",
+		},
+		{
+			name:     "incomplete-quantum",
+			chunks:   []string{"aGVsbG8"},
+			errorMsg: "invalid grpc-web-text data at offset 4: unexpected EOF",
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			var decoder GRPCWebTextDecoder
+			var result []byte
+			var err error
+			for _, chunk := range testCase.chunks {
+				var decoded []byte
+				decoded, err = decoder.Decode([]byte(chunk))
+				if err != nil {
+					break
+				}
+				result = append(result, decoded...)
+			}
+			if err == nil {
+				err = decoder.Close()
+			}
+			if testCase.errorMsg != "" {
+				require.EqualError(t, err, testCase.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, string(result))
+		})
+	}
+}
+
+func TestGRPCWebTextReaders(t *testing.T) {
+	t.Parallel()
+	data := bytes.Repeat([]byte("0123456789"), 1000)
+	// Small reads from the source force the encoder to produce
+	// many separately padded chunks.
+	encoded := NewGRPCWebTextEncodingReader(io.NopCloser(iotest.OneByteReader(bytes.NewReader(data))))
+	encodedData, err := io.ReadAll(encoded)
+	require.NoError(t, err)
+	assert.Contains(t, string(encodedData), "==")
+
+	decoded := NewGRPCWebTextDecodingReader(io.NopCloser(iotest.HalfReader(bytes.NewReader(encodedData))))
+	decodedData, err := io.ReadAll(decoded)
+	require.NoError(t, err)
+	assert.Equal(t, data, decodedData)
+
+	decoded = NewGRPCWebTextDecodingReader(io.NopCloser(bytes.NewReader(encodedData[:len(encodedData)-1])))
+	_, err = io.ReadAll(decoded)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.ErrorIs(t, err, ErrInvalidGRPCWebText)
+}
+
+func TestGRPCWebTextContentTypes(t *testing.T) {
+	t.Parallel()
+	assert.True(t, IsGRPCWebTextContentType("application/grpc-web-text"))
+	assert.True(t, IsGRPCWebTextContentType("application/grpc-web-text+json"))
+	assert.False(t, IsGRPCWebTextContentType("application/grpc-web+proto"))
+	assert.True(t, IsGRPCWebBinaryContentType("application/grpc-web+proto"))
+	assert.False(t, IsGRPCWebBinaryContentType("application/grpc-web-text"))
+	assert.Equal(t, "application/grpc-web+json", GRPCWebTextToBinaryContentType("application/grpc-web-text+json"))
+	assert.Equal(t, "application/grpc-web-text", GRPCWebBinaryToTextContentType("application/grpc-web"))
+	assert.Equal(t, "application/proto", GRPCWebBinaryToTextContentType("application/proto"))
+}
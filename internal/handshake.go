@@ -0,0 +1,99 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+)
+
+// HandshakeEnvVar is the name of the environment variable that the test
+// runner sets to "1" when it expects the client or server under test to
+// perform a handshake before anything else.
+const HandshakeEnvVar = "CONNECT_CONFORMANCE_HANDSHAKE"
+
+// HandshakeRequested returns true if the current process was started by
+// a test runner that expects a handshake.
+func HandshakeRequested() bool {
+	return os.Getenv(HandshakeEnvVar) == "1"
+}
+
+// RespondToHandshake reads a HandshakeRequest from the given decoder and
+// then writes the given response to the given encoder.
+func RespondToHandshake(decoder StreamDecoder, encoder StreamEncoder, resp *conformancev1.HandshakeResponse) error {
+	var req conformancev1.HandshakeRequest
+	if err := decoder.DecodeNext(&req); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("input closed before handshake request was received")
+		}
+		return fmt.Errorf("failed to read handshake request: %w", err)
+	}
+	if err := encoder.Encode(resp); err != nil {
+		return fmt.Errorf("failed to write handshake response: %w", err)
+	}
+	return nil
+}
+
+// ReferenceHandshakeResponse describes the features of the reference client
+// and the reference server, for when either is run as the implementation under
+// test with a handshake. The given command is the path of the executable, whose
+// base name is used as the implementation name.
+//
+// The test runner only tests the features that both sides report, so both use
+// the same features, including those that only apply to servers. These must
+// match the features in testing/reference-impls-config.yaml.
+func ReferenceHandshakeResponse(command string) *conformancev1.HandshakeResponse {
+	supportsTLSClientCerts, supportsHalfDuplexBidiOverHTTP1 := true, true
+	supportsReflection, supportsHealth, supportsCORS := true, true, true
+	return &conformancev1.HandshakeResponse{
+		Features: &conformancev1.Features{
+			Versions: []conformancev1.HTTPVersion{
+				conformancev1.HTTPVersion_HTTP_VERSION_1,
+				conformancev1.HTTPVersion_HTTP_VERSION_2,
+				conformancev1.HTTPVersion_HTTP_VERSION_3,
+			},
+			Protocols: []conformancev1.Protocol{
+				conformancev1.Protocol_PROTOCOL_CONNECT,
+				conformancev1.Protocol_PROTOCOL_GRPC,
+				conformancev1.Protocol_PROTOCOL_GRPC_WEB,
+				conformancev1.Protocol_PROTOCOL_GRPC_WEB_TEXT,
+			},
+			Codecs: []conformancev1.Codec{
+				conformancev1.Codec_CODEC_PROTO,
+				conformancev1.Codec_CODEC_JSON,
+			},
+			Compressions: []conformancev1.Compression{
+				conformancev1.Compression_COMPRESSION_IDENTITY,
+				conformancev1.Compression_COMPRESSION_GZIP,
+				conformancev1.Compression_COMPRESSION_BR,
+				conformancev1.Compression_COMPRESSION_ZSTD,
+				conformancev1.Compression_COMPRESSION_DEFLATE,
+				conformancev1.Compression_COMPRESSION_SNAPPY,
+			},
+			SupportsTlsClientCerts:          &supportsTLSClientCerts,
+			SupportsHalfDuplexBidiOverHttp1: &supportsHalfDuplexBidiOverHTTP1,
+			SupportsReflection:              &supportsReflection,
+			SupportsHealth:                  &supportsHealth,
+			SupportsCors:                    &supportsCORS,
+		},
+		ImplementationName:    filepath.Base(command),
+		ImplementationVersion: Version,
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"os"
+	"testing"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/bufbuild/protoyaml-go"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestReferenceHandshakeResponse(t *testing.T) {
+	t.Parallel()
+
+	// The features reported in the handshake must match the config that is
+	// used to test the reference implementations against each other.
+	data, err := os.ReadFile("../testing/reference-impls-config.yaml")
+	require.NoError(t, err)
+	var config conformancev1.Config
+	require.NoError(t, protoyaml.Unmarshal(data, &config))
+	resp := ReferenceHandshakeResponse("/path/to/referenceclient")
+	require.Empty(t, cmp.Diff(config.Features, resp.Features, protocmp.Transform()), "- config; + handshake")
+	require.Equal(t, "referenceclient", resp.ImplementationName)
+}
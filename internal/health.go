@@ -0,0 +1,48 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthTransitionsHeader is the name of the request header that scripts
+// status transitions for a call to the grpc.health.v1.Health/Watch method.
+// See the documentation for supports_health in the Features message.
+const HealthTransitionsHeader = "x-conformance-health-transitions"
+
+// ParseHealthTransitions parses the value of a HealthTransitionsHeader into
+// the sequence of statuses it describes. It returns nil if the given value
+// is empty.
+func ParseHealthTransitions(value string) ([]healthv1.HealthCheckResponse_ServingStatus, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	parts := strings.Split(value, ",")
+	statuses := make([]healthv1.HealthCheckResponse_ServingStatus, len(parts))
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		status, ok := healthv1.HealthCheckResponse_ServingStatus_value[part]
+		if !ok {
+			return nil, fmt.Errorf("invalid %s header: %q is not a valid serving status", HealthTransitionsHeader, part)
+		}
+		statuses[i] = healthv1.HealthCheckResponse_ServingStatus(status)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestParseHealthTransitions(t *testing.T) {
+	t.Parallel()
+
+	statuses, err := ParseHealthTransitions("")
+	require.NoError(t, err)
+	assert.Empty(t, statuses)
+
+	statuses, err = ParseHealthTransitions("NOT_SERVING, SERVING,SERVICE_UNKNOWN")
+	require.NoError(t, err)
+	assert.Equal(t, []healthv1.HealthCheckResponse_ServingStatus{
+		healthv1.HealthCheckResponse_NOT_SERVING,
+		healthv1.HealthCheckResponse_SERVING,
+		healthv1.HealthCheckResponse_SERVICE_UNKNOWN,
+	}, statuses)
+
+	_, err = ParseHealthTransitions("SERVING,BROKEN")
+	require.ErrorContains(t, err, `"BROKEN" is not a valid serving status`)
+}
@@ -19,6 +19,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"sync"
 
 	"connectrpc.com/conformance/internal/compression"
 	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
@@ -37,6 +38,8 @@ func WriteRawMessageContents(contents *conformancev1.MessageContents, writer io.
 		msgBytes = data.BinaryMessage.Value
 	case *conformancev1.MessageContents_Text:
 		msgBytes = []byte(data.Text)
+	case *conformancev1.MessageContents_Generated:
+		return writeGeneratedContents(data.Generated, contents.Compression, writer)
 	default:
 		return fmt.Errorf("invalid message contents data type: %T", data)
 	}
@@ -88,3 +91,60 @@ func WriteRawStreamContents(contents *conformancev1.StreamContents, writer io.Wr
 	}
 	return nil
 }
+
+//nolint:gochecknoglobals
+var (
+	compressedDataMu    sync.Mutex
+	compressedDataCache = map[compressedDataKey][]byte{}
+)
+
+type compressedDataKey struct {
+	size        uint32
+	pattern     conformancev1.GeneratedData_Pattern
+	seed        uint64
+	text        string
+	compression conformancev1.Compression
+}
+
+// writeGeneratedContents writes the given generated data to the given
+// writer, compressed using the given algorithm. Generated data can be
+// very large, like for compression bombs, so it is never allocated all
+// at once. Compressing it can also take a while, so the compressed
+// bytes are cached, since the same contents are usually sent many times.
+func writeGeneratedContents(gen *conformancev1.GeneratedData, algorithm conformancev1.Compression, writer io.Writer) error {
+	if algorithm == conformancev1.Compression_COMPRESSION_UNSPECIFIED ||
+		algorithm == conformancev1.Compression_COMPRESSION_IDENTITY {
+		return WriteGeneratedData(gen, writer)
+	}
+	key := compressedDataKey{
+		size:        gen.GetSize(),
+		pattern:     gen.GetPattern(),
+		seed:        gen.GetSeed(),
+		text:        gen.GetText(),
+		compression: algorithm,
+	}
+	compressedDataMu.Lock()
+	compressed, ok := compressedDataCache[key]
+	compressedDataMu.Unlock()
+	if !ok {
+		compressor, err := compression.GetCompressor(algorithm)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		compressor.Reset(&buf)
+		err = WriteGeneratedData(gen, compressor)
+		if err == nil {
+			err = compressor.Close()
+		}
+		if err != nil {
+			return err
+		}
+		compressed = buf.Bytes()
+		compressedDataMu.Lock()
+		compressedDataCache[key] = compressed
+		compressedDataMu.Unlock()
+	}
+	_, err := writer.Write(compressed)
+	return err
+}
@@ -59,6 +59,17 @@ func TestWriteRawMessageContents(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "generated",
+			data: &conformancev1.MessageContents{
+				Data: &conformancev1.MessageContents_Generated{
+					Generated: &conformancev1.GeneratedData{
+						Size:    1024,
+						Pattern: conformancev1.GeneratedData_PATTERN_ZEROS,
+					},
+				},
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		testCase := testCase
@@ -199,6 +210,10 @@ func checkMessageContents(t *testing.T, msg *conformancev1.MessageContents, data
 		assert.Equal(t, msgData.Text, decompressed.String())
 	case *conformancev1.MessageContents_BinaryMessage:
 		assert.Equal(t, msgData.BinaryMessage.Value, decompressed.Bytes())
+	case *conformancev1.MessageContents_Generated:
+		generated, err := GenerateData(msgData.Generated)
+		require.NoError(t, err)
+		assert.Equal(t, generated, decompressed.Bytes())
 	case nil:
 		assert.Zero(t, decompressed.Len())
 	default:
@@ -16,6 +16,7 @@ package tracer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptrace"
@@ -24,6 +25,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/quic-go/quic-go"
 )
 
 const testCaseNameHeader = "x-test-case-name"
@@ -33,6 +36,9 @@ type builder struct {
 	collector Collector
 	start     time.Time
 	client    bool
+	// the URI path of the request, used to determine the
+	// types of messages in the request and response bodies
+	procedure string
 
 	mu                  sync.Mutex
 	trace               Trace
@@ -69,6 +75,12 @@ func newBuilder(req *http.Request, client bool, collector Collector) (*builder,
 		getHeaders = func() http.Header {
 			headersMu.Lock()
 			defer headersMu.Unlock()
+			if len(headers) == 0 {
+				// Some transports, like HTTP/3, don't support httptrace. And
+				// requests that are created from frames on the wire already
+				// have the actual headers.
+				return req.Header.Clone()
+			}
 			return headers.Clone()
 		}
 	} else {
@@ -83,12 +95,16 @@ func newBuilder(req *http.Request, client bool, collector Collector) (*builder,
 		}
 	}
 	testName := req.Header.Get(testCaseNameHeader)
+	start := time.Now()
 	return &builder{
 		collector: collector,
-		start:     time.Now(),
+		start:     start,
 		client:    client,
+		procedure: req.URL.Path,
 		trace: Trace{
 			TestName: testName,
+			Start:    start,
+			Client:   client,
 			Request:  req,
 			Events:   []Event{&RequestStart{Request: req, getHeaders: getHeaders}},
 		},
@@ -136,12 +152,16 @@ func (b *builder) add(event Event) {
 	case *ResponseError:
 		b.trace.Err = event.Err
 		if b.client {
+			var quicErr *quic.StreamError
 			// Can't use type assertion to http2.StreamError because the standard library
 			// includes vendored copy of the http2 package. So the type assertion would fail
 			// since the type in the vendored package != the type in x/net/http2.
-			if strings.HasSuffix(reflect.TypeOf(event.Err).String(), "http2.StreamError") {
+			switch {
+			case strings.HasSuffix(reflect.TypeOf(event.Err).String(), "http2.StreamError"):
 				b.trace.Request.Proto = "HTTP/2.0"
-			} else {
+			case errors.As(event.Err, &quicErr):
+				b.trace.Request.Proto = "HTTP/3.0"
+			default:
 				// We don't conclusively know what version of HTTP was used.
 				b.trace.Request.Proto = ""
 			}
@@ -168,6 +188,30 @@ func (b *builder) add(event Event) {
 	}
 }
 
+// addFrame adds the given HTTP/2 frame to the trace being built.
+func (b *builder) addFrame(frame *Frame) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.trace.TestName == "" {
+		return
+	}
+	frame.setEventOffset(time.Since(b.start))
+	b.trace.Frames = append(b.trace.Frames, frame)
+}
+
+// setTrailers sets the trailers of the request or response in the
+// trace being built.
+func (b *builder) setTrailers(isRequest bool, trailers http.Header) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case isRequest && b.trace.Request != nil:
+		b.trace.Request.Trailer = trailers
+	case !isRequest && b.trace.Response != nil:
+		b.trace.Response.Trailer = trailers
+	}
+}
+
 func (b *builder) getAndClearLocked() Trace {
 	trace := b.trace
 	b.trace = Trace{} // reset; subsequent calls to add or build ignored
@@ -0,0 +1,336 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// maxDecodedMessageSize is the largest message, both as it appears on the
+// wire and after decompression, that will be decoded for inclusion in a
+// trace. Larger messages are still traced, but only their sizes are recorded.
+const maxDecodedMessageSize = 64 * 1024
+
+// messageDecoder decodes the contents of a request or response body into
+// JSON, so that a trace can show what was actually sent or received.
+type messageDecoder struct {
+	// The type of message in the body. This is nil when the
+	// body is a Connect unary error instead of a message.
+	msgType protoreflect.MessageType
+	// The codec name from the content-type: "proto", "json", or "text".
+	codec string
+	// For unary protocols, the decompressor for the body, if the
+	// Content-Encoding header indicates it is compressed. Stream
+	// protocols instead compress individual messages.
+	bodyDecompressor connect.Decompressor
+}
+
+// newMessageDecoder returns a decoder for the body of a request or
+// response to the given procedure. It returns nil if the body cannot be
+// decoded, such as when the procedure is not known or the body uses an
+// unrecognized codec or compression scheme.
+func newMessageDecoder(procedure string, isRequest bool, statusCode int, headers http.Header) *messageDecoder {
+	contentType := strings.ToLower(headers.Get("Content-Type"))
+	if pos := strings.IndexByte(contentType, ';'); pos >= 0 {
+		contentType = strings.TrimSpace(contentType[:pos])
+	}
+	codec, isStream := codecFromContentType(contentType)
+	var bodyDecompressor connect.Decompressor
+	if encoding := headers.Get("Content-Encoding"); encoding != "" {
+		if isStream {
+			// The full body is encoded, so the stream can't be parsed.
+			return nil
+		}
+		bodyDecompressor = GetDecompressor(encoding)
+		if _, broken := bodyDecompressor.(brokenDecompressor); broken {
+			return nil
+		}
+	}
+	if !isRequest && !isStream && statusCode != http.StatusOK {
+		if contentType != "application/json" {
+			return nil
+		}
+		// This is a Connect unary error.
+		return &messageDecoder{codec: codec, bodyDecompressor: bodyDecompressor}
+	}
+	switch codec {
+	case "proto", "json", "text":
+	default:
+		return nil
+	}
+	msgType := messageTypeForProcedure(procedure, isRequest)
+	if msgType == nil {
+		return nil
+	}
+	return &messageDecoder{
+		msgType:          msgType,
+		codec:            codec,
+		bodyDecompressor: bodyDecompressor,
+	}
+}
+
+// decode returns the given message data in JSON form. If decompressor is
+// non-nil, the data is first decompressed. If the data cannot be decoded,
+// an empty string is returned.
+func (m *messageDecoder) decode(data []byte, decompressor connect.Decompressor) string {
+	if decompressor != nil {
+		var err error
+		if data, err = decompress(data, decompressor); err != nil {
+			return ""
+		}
+	}
+	if m.msgType == nil {
+		return decodeConnectError(data)
+	}
+	msg := m.msgType.New().Interface()
+	var err error
+	switch m.codec {
+	case "json":
+		err = protojson.Unmarshal(data, msg)
+	case "text":
+		err = prototext.Unmarshal(data, msg)
+	default:
+		err = proto.Unmarshal(data, msg)
+	}
+	if err != nil {
+		return ""
+	}
+	return marshalJSON(msg)
+}
+
+// codecFromContentType returns the name of the codec indicated by the
+// given content-type and whether the content-type is for a streaming
+// protocol (which uses enveloped messages).
+func codecFromContentType(contentType string) (codec string, isStream bool) {
+	for _, prefix := range []string{"application/connect", "application/grpc-web-text", "application/grpc-web", "application/grpc"} {
+		if !strings.HasPrefix(contentType, prefix) {
+			continue
+		}
+		suffix := contentType[len(prefix):]
+		if suffix == "" {
+			// gRPC and gRPC-Web default to proto
+			return "proto", true
+		}
+		if suffix[0] != '+' {
+			continue
+		}
+		return suffix[1:], true
+	}
+	return strings.TrimPrefix(contentType, "application/"), false
+}
+
+// messageTypeForProcedure returns the request or response type for the
+// given procedure, which should be a URI path whose last two components
+// are the fully-qualified service name and the method name. It returns
+// nil if the procedure is not a known RPC method.
+func messageTypeForProcedure(procedure string, isRequest bool) protoreflect.MessageType {
+	pos := strings.LastIndexByte(procedure, '/')
+	if pos < 0 {
+		return nil
+	}
+	methodName := procedure[pos+1:]
+	procedure = procedure[:pos]
+	serviceName := procedure[strings.LastIndexByte(procedure, '/')+1:]
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil
+	}
+	svcDesc, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil
+	}
+	methodDesc := svcDesc.Methods().ByName(protoreflect.Name(methodName))
+	if methodDesc == nil {
+		return nil
+	}
+	msgDesc := methodDesc.Output()
+	if isRequest {
+		msgDesc = methodDesc.Input()
+	}
+	msgType, err := protoregistry.GlobalTypes.FindMessageByName(msgDesc.FullName())
+	if err != nil {
+		return nil
+	}
+	return msgType
+}
+
+// decodedError is the JSON form of an RPC error, used to show the
+// error in a Connect or gRPC-Web end-stream message or in a Connect
+// unary error body.
+type decodedError struct {
+	Code    string          `json:"code"`
+	Message string          `json:"message,omitempty"`
+	Details []decodedDetail `json:"details,omitempty"`
+}
+
+type decodedDetail struct {
+	Type string `json:"type"`
+	// The JSON form of the detail message. If the type is
+	// not known, this is the original base64-encoded value.
+	Value json.RawMessage `json:"value"`
+}
+
+// decodeConnectError returns the JSON form of the given Connect error,
+// with the error details decoded. It returns an empty string if the
+// data is not a valid Connect error.
+func decodeConnectError(data []byte) string {
+	var connectErr struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Details []struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(data, &connectErr); err != nil || connectErr.Code == "" {
+		return ""
+	}
+	result := decodedError{
+		Code:    connectErr.Code,
+		Message: connectErr.Message,
+	}
+	for _, detail := range connectErr.Details {
+		result.Details = append(result.Details, decodeErrorDetail(detail.Type, detail.Value))
+	}
+	return marshalDecodedError(&result)
+}
+
+// decodeEndStream returns the JSON form of the error in the given
+// end-stream message. The given flags indicate whether the message
+// is a Connect end-stream message or gRPC-Web trailers. It returns
+// an empty string if the message indicates success or cannot be parsed.
+func decodeEndStream(flags byte, content string) string {
+	if flags&0x02 != 0 {
+		var endStream struct {
+			Error json.RawMessage `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(content), &endStream); err != nil || len(endStream.Error) == 0 {
+			return ""
+		}
+		return decodeConnectError(endStream.Error)
+	}
+	trailers := http.Header{}
+	for _, line := range strings.Split(content, "\n") {
+		key, val, ok := strings.Cut(strings.TrimSuffix(line, "\r"), ":")
+		if !ok {
+			continue
+		}
+		trailers.Add(strings.TrimSpace(key), strings.TrimSpace(val))
+	}
+	return decodeGRPCStatus(trailers)
+}
+
+// decodeGRPCStatus returns the JSON form of the error described by the
+// given gRPC trailers. It returns an empty string if the trailers indicate
+// success or do not include a valid status.
+func decodeGRPCStatus(trailers http.Header) string {
+	code, err := strconv.ParseUint(trailers.Get("Grpc-Status"), 10, 32)
+	if err != nil || code == 0 {
+		return ""
+	}
+	result := decodedError{
+		Code: connect.Code(code).String(),
+	}
+	result.Message = trailers.Get("Grpc-Message")
+	if msg, err := url.PathUnescape(result.Message); err == nil {
+		result.Message = msg
+	}
+	if detailsBin := trailers.Get("Grpc-Status-Details-Bin"); detailsBin != "" {
+		var statusProto status.Status
+		if data, err := decodeBinaryValue(detailsBin); err == nil && proto.Unmarshal(data, &statusProto) == nil {
+			for _, detail := range statusProto.Details {
+				typeName := detail.TypeUrl[strings.LastIndexByte(detail.TypeUrl, '/')+1:]
+				value := base64.RawStdEncoding.EncodeToString(detail.Value)
+				result.Details = append(result.Details, decodeErrorDetail(typeName, value))
+			}
+		}
+	}
+	return marshalDecodedError(&result)
+}
+
+func decodeErrorDetail(typeName, value string) decodedDetail {
+	detail := decodedDetail{Type: typeName}
+	if data, err := decodeBinaryValue(value); err == nil {
+		if msgType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(typeName)); err == nil {
+			msg := msgType.New().Interface()
+			if proto.Unmarshal(data, msg) == nil {
+				if msgJSON := marshalJSON(msg); msgJSON != "" {
+					detail.Value = json.RawMessage(msgJSON)
+					return detail
+				}
+			}
+		}
+	}
+	detail.Value, _ = json.Marshal(value)
+	return detail
+}
+
+// decodeBinaryValue decodes the given base64-encoded value, which
+// may or may not be padded.
+func decodeBinaryValue(value string) ([]byte, error) {
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(value, "="))
+}
+
+func decompress(data []byte, decompressor connect.Decompressor) ([]byte, error) {
+	if err := decompressor.Reset(bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	var uncompressed bytes.Buffer
+	if _, err := uncompressed.ReadFrom(io.LimitReader(decompressor, maxDecodedMessageSize+1)); err != nil {
+		return nil, err
+	}
+	if uncompressed.Len() > maxDecodedMessageSize {
+		return nil, fmt.Errorf("decompressed size exceeds %d bytes", maxDecodedMessageSize)
+	}
+	return uncompressed.Bytes(), nil
+}
+
+func marshalJSON(msg proto.Message) string {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return ""
+	}
+	// The protojson package randomly varies whitespace in its
+	// output, so we compact it for stable and concise traces.
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, data); err != nil {
+		return ""
+	}
+	return compacted.String()
+}
+
+func marshalDecodedError(decoded *decodedError) string {
+	data, err := json.Marshal(decoded)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,247 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	"connectrpc.com/conformance/internal/compression"
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestDecodeMessages(t *testing.T) {
+	t.Parallel()
+	const (
+		unary        = "/connectrpc.conformance.v1.ConformanceService/Unary"
+		serverStream = "/connectrpc.conformance.v1.ConformanceService/ServerStream"
+	)
+	unaryReq := mustMarshal(t, &conformancev1.UnaryRequest{RequestData: []byte("abc")})
+	streamResp := mustMarshal(t, &conformancev1.ServerStreamResponse{
+		Payload: &conformancev1.ConformancePayload{Data: []byte("xyz")},
+	})
+	detail := &conformancev1.Header{Name: "a", Value: []string{"b"}}
+	detailAny, err := anypb.New(detail)
+	require.NoError(t, err)
+	statusBin := mustMarshal(t, &status.Status{Code: 3, Message: "foo bar", Details: []*anypb.Any{detailAny}})
+	detailValue := base64.RawStdEncoding.EncodeToString(mustMarshal(t, detail))
+	const expectedError = `{"code":"invalid_argument","message":"foo bar","details":[{"type":"connectrpc.conformance.v1.Header","value":{"name":"a","value":["b"]}}]}`
+
+	testCases := []struct {
+		name            string
+		procedure       string
+		isRequest       bool
+		statusCode      int
+		headers         http.Header
+		body            []byte
+		expectMessages  []string
+		expectEndStream string
+	}{
+		{
+			name:           "connect-unary-proto",
+			procedure:      unary,
+			isRequest:      true,
+			headers:        headers("Content-Type", "application/proto"),
+			body:           unaryReq,
+			expectMessages: []string{`{"requestData":"YWJj"}`},
+		},
+		{
+			name:           "connect-unary-json-compressed",
+			procedure:      unary,
+			isRequest:      true,
+			headers:        headers("Content-Type", "application/json", "Content-Encoding", "gzip"),
+			body:           compress(t, conformancev1.Compression_COMPRESSION_GZIP, []byte(`{"request_data": "YWJj"}`)),
+			expectMessages: []string{`{"requestData":"YWJj"}`},
+		},
+		{
+			name:       "connect-unary-error",
+			procedure:  unary,
+			statusCode: http.StatusBadRequest,
+			headers:    headers("Content-Type", "application/json"),
+			body: []byte(`{"code":"invalid_argument","message":"foo bar","details":[` +
+				`{"type":"connectrpc.conformance.v1.Header","value":"` + detailValue + `"}]}`),
+			expectMessages: []string{expectedError},
+		},
+		{
+			name:       "connect-stream",
+			procedure:  serverStream,
+			statusCode: http.StatusOK,
+			headers:    headers("Content-Type", "application/connect+proto", "Connect-Content-Encoding", "gzip"),
+			body: concat(
+				envelope(0, streamResp),
+				envelope(1, compress(t, conformancev1.Compression_COMPRESSION_GZIP, streamResp)),
+				envelope(2, []byte(`{"error":{"code":"invalid_argument","message":"foo bar","details":[`+
+					`{"type":"connectrpc.conformance.v1.Header","value":"`+detailValue+`"}]}}`)),
+			),
+			expectMessages:  []string{`{"payload":{"data":"eHl6"}}`, `{"payload":{"data":"eHl6"}}`, ""},
+			expectEndStream: expectedError,
+		},
+		{
+			name:       "grpc-web",
+			procedure:  serverStream,
+			statusCode: http.StatusOK,
+			headers:    headers("Content-Type", "application/grpc-web"),
+			body: concat(
+				envelope(0, streamResp),
+				envelope(128, []byte("grpc-status: 3\r\ngrpc-message: foo%20bar\r\n"+
+					"grpc-status-details-bin: "+base64.RawStdEncoding.EncodeToString(statusBin)+"\r\n")),
+			),
+			expectMessages:  []string{`{"payload":{"data":"eHl6"}}`, ""},
+			expectEndStream: expectedError,
+		},
+		{
+			name:       "grpc-web-success",
+			procedure:  serverStream,
+			statusCode: http.StatusOK,
+			headers:    headers("Content-Type", "application/grpc-web"),
+			body: concat(
+				envelope(0, streamResp),
+				envelope(128, []byte("grpc-status: 0\r\n")),
+			),
+			expectMessages: []string{`{"payload":{"data":"eHl6"}}`, ""},
+		},
+		{
+			name:           "unknown-procedure",
+			procedure:      "/foo.bar.Service/Method",
+			isRequest:      true,
+			headers:        headers("Content-Type", "application/grpc"),
+			body:           envelope(0, unaryReq),
+			expectMessages: []string{""},
+		},
+		{
+			name:           "too-large",
+			procedure:      unary,
+			isRequest:      true,
+			headers:        headers("Content-Type", "application/grpc"),
+			body:           envelope(0, mustMarshal(t, &conformancev1.UnaryRequest{RequestData: make([]byte, maxDecodedMessageSize)})),
+			expectMessages: []string{""},
+		},
+		{
+			name:           "incomplete",
+			procedure:      unary,
+			isRequest:      true,
+			headers:        headers("Content-Type", "application/grpc"),
+			body:           envelope(0, unaryReq)[:prefixLen+2],
+			expectMessages: []string{""},
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			var tracer Tracer
+			tracer.Init(t.Name())
+			req := &http.Request{
+				Method: http.MethodPost,
+				URL:    &url.URL{Path: testCase.procedure},
+				Header: headers(testCaseNameHeader, t.Name()),
+			}
+			builder, _ := newBuilder(req, true, &tracer)
+			reader := newReader(testCase.headers, testCase.statusCode, io.NopCloser(bytes.NewReader(testCase.body)), testCase.isRequest, builder, func() {})
+			_, err := io.Copy(io.Discard, reader)
+			require.NoError(t, err)
+			builder.build()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			trace, err := tracer.Await(ctx, t.Name())
+			require.NoError(t, err)
+			var messages []string
+			var endStream string
+			for _, event := range trace.Events {
+				switch event := event.(type) {
+				case *RequestBodyData:
+					messages = append(messages, event.Message)
+				case *ResponseBodyData:
+					messages = append(messages, event.Message)
+				case *ResponseBodyEndStream:
+					endStream = event.Error
+				}
+			}
+			assert.Equal(t, testCase.expectMessages, messages)
+			assert.Equal(t, testCase.expectEndStream, endStream)
+
+			var printed bytes.Buffer
+			trace.Print(internal.NewPrinter(&printed))
+			for _, msg := range testCase.expectMessages {
+				if msg != "" {
+					assert.Contains(t, printed.String(), "json: "+msg)
+				}
+			}
+		})
+	}
+}
+
+func TestCodecFromContentType(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		contentType  string
+		expectCodec  string
+		expectStream bool
+	}{
+		{contentType: "application/proto", expectCodec: "proto"},
+		{contentType: "application/json", expectCodec: "json"},
+		{contentType: "application/connect+json", expectCodec: "json", expectStream: true},
+		{contentType: "application/grpc", expectCodec: "proto", expectStream: true},
+		{contentType: "application/grpc+text", expectCodec: "text", expectStream: true},
+		{contentType: "application/grpc-web", expectCodec: "proto", expectStream: true},
+		{contentType: "application/grpc-web-text+json", expectCodec: "json", expectStream: true},
+		{contentType: "application/grpcfoo", expectCodec: "grpcfoo"},
+	}
+	for _, testCase := range testCases {
+		codec, isStream := codecFromContentType(testCase.contentType)
+		assert.Equal(t, testCase.expectCodec, codec, testCase.contentType)
+		assert.Equal(t, testCase.expectStream, isStream, testCase.contentType)
+	}
+}
+
+func mustMarshal(t *testing.T, msg proto.Message) []byte {
+	t.Helper()
+	data, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	return data
+}
+
+func compress(t *testing.T, algorithm conformancev1.Compression, data []byte) []byte {
+	t.Helper()
+	comp, err := compression.GetCompressor(algorithm)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	comp.Reset(&buf)
+	_, err = comp.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, comp.Close())
+	return buf.Bytes()
+}
+
+func envelope(flags byte, data []byte) []byte {
+	result := make([]byte, prefixLen, prefixLen+len(data))
+	result[0] = flags
+	binary.BigEndian.PutUint32(result[1:], uint32(len(data)))
+	return append(result, data...)
+}
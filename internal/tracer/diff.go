@@ -0,0 +1,206 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/conformance/internal"
+)
+
+const noValue = "(none)"
+
+// Headers that are expected to vary between implementations or between
+// runs, so they are not compared by Diff. Besides ones that vary between
+// runs, this includes transport-level headers and headers that describe
+// what else an implementation supports (like which compression algorithms
+// it accepts), which routinely differ without affecting the outcome of an
+// RPC. Header names are in canonical form.
+var volatileHeaders = map[string]struct{}{
+	"Date":                    {},
+	"Server":                  {},
+	"Content-Length":          {},
+	"Vary":                    {},
+	"Accept-Encoding":         {},
+	"Connect-Accept-Encoding": {},
+	"Grpc-Accept-Encoding":    {},
+	"Traceresponse":           {},
+}
+
+// Difference describes one way in which a trace differs from a
+// reference trace.
+type Difference struct {
+	// What differs, such as "response status" or
+	// "response trailer Grpc-Status".
+	Subject string
+	// The value in the reference trace.
+	Reference string
+	// The value in the actual trace.
+	Actual string
+}
+
+// Diff computes a structural diff between the given traces. It compares the
+// parts of the traces that should be the same when two implementations handle
+// the same request: the status code, headers, trailers, the envelope flags
+// and sizes of messages in the request and response bodies, the contents of
+// end-stream messages, and whether the operation failed. It returns nil if
+// there are no differences.
+func Diff(reference, actual *Trace) []Difference {
+	var diffs []Difference
+	addDiff := func(subject, ref, act string) {
+		if ref != act {
+			diffs = append(diffs, Difference{Subject: subject, Reference: ref, Actual: act})
+		}
+	}
+
+	var refStatus, actStatus string
+	var refHeaders, actHeaders, refTrailers, actTrailers http.Header
+	if reference.Response != nil {
+		refStatus = strconv.Itoa(reference.Response.StatusCode)
+		refHeaders, refTrailers = reference.Response.Header, reference.Response.Trailer
+	}
+	if actual.Response != nil {
+		actStatus = strconv.Itoa(actual.Response.StatusCode)
+		actHeaders, actTrailers = actual.Response.Header, actual.Response.Trailer
+	}
+	addDiff("response status", valueOrNone(refStatus), valueOrNone(actStatus))
+	diffs = append(diffs, diffHeaders("response header", refHeaders, actHeaders)...)
+	diffs = append(diffs, diffHeaders("response trailer", refTrailers, actTrailers)...)
+
+	refBody, actBody := summarizeBody(reference), summarizeBody(actual)
+	diffs = append(diffs, diffMessages("request message", refBody.requestMessages, actBody.requestMessages)...)
+	diffs = append(diffs, diffMessages("response message", refBody.responseMessages, actBody.responseMessages)...)
+	addDiff("end-stream message", valueOrNone(refBody.endStream), valueOrNone(actBody.endStream))
+
+	// The error messages themselves are not compared since they often
+	// include details that vary, like network addresses.
+	addDiff("failed", strconv.FormatBool(reference.Err != nil), strconv.FormatBool(actual.Err != nil))
+	return diffs
+}
+
+// PrintDiff prints the differences between the given traces, as computed
+// by Diff.
+func PrintDiff(reference, actual *Trace, printer internal.Printer) {
+	diffs := Diff(reference, actual)
+	if len(diffs) == 0 {
+		printer.Printf("(no differences)")
+		return
+	}
+	for _, diff := range diffs {
+		printer.Printf("%s:", diff.Subject)
+		printDiffValue("reference:", diff.Reference, printer)
+		printDiffValue("actual:   ", diff.Actual, printer)
+	}
+}
+
+func printDiffValue(label, value string, printer internal.Printer) {
+	for i, line := range strings.Split(strings.TrimRight(value, "\r\n"), "\n") {
+		if i > 0 {
+			label = strings.Repeat(" ", len(label))
+		}
+		printer.Printf("    %s %s", label, strings.TrimRight(line, "\r"))
+	}
+}
+
+func diffHeaders(kind string, reference, actual http.Header) []Difference {
+	keys := make([]string, 0, len(reference)+len(actual))
+	for key := range reference {
+		keys = append(keys, key)
+	}
+	for key := range actual {
+		if _, ok := reference[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var diffs []Difference
+	for _, key := range keys {
+		if _, ok := volatileHeaders[http.CanonicalHeaderKey(key)]; ok {
+			continue
+		}
+		refVal, actVal := headerValue(reference, key), headerValue(actual, key)
+		if refVal != actVal {
+			diffs = append(diffs, Difference{Subject: kind + " " + key, Reference: refVal, Actual: actVal})
+		}
+	}
+	return diffs
+}
+
+func headerValue(headers http.Header, key string) string {
+	vals, ok := headers[key]
+	if !ok {
+		return "(missing)"
+	}
+	return strings.Join(vals, ", ")
+}
+
+func diffMessages(kind string, reference, actual []string) []Difference {
+	var diffs []Difference
+	for i := 0; i < len(reference) || i < len(actual); i++ {
+		refMsg, actMsg := noValue, noValue
+		if i < len(reference) {
+			refMsg = reference[i]
+		}
+		if i < len(actual) {
+			actMsg = actual[i]
+		}
+		if refMsg != actMsg {
+			diffs = append(diffs, Difference{Subject: fmt.Sprintf("%s #%d", kind, i+1), Reference: refMsg, Actual: actMsg})
+		}
+	}
+	return diffs
+}
+
+type bodySummary struct {
+	requestMessages  []string
+	responseMessages []string
+	endStream        string
+}
+
+func summarizeBody(trace *Trace) bodySummary {
+	var summary bodySummary
+	for _, event := range trace.Events {
+		switch event := event.(type) {
+		case *RequestBodyData:
+			summary.requestMessages = append(summary.requestMessages, describeMessage(event.Envelope, event.Len))
+		case *ResponseBodyData:
+			summary.responseMessages = append(summary.responseMessages, describeMessage(event.Envelope, event.Len))
+		case *ResponseBodyEndStream:
+			summary.endStream = event.Content
+		}
+	}
+	return summary
+}
+
+func describeMessage(env *Envelope, length uint64) string {
+	if env == nil {
+		return fmt.Sprintf("%d bytes", length)
+	}
+	if uint64(env.Len) != length {
+		return fmt.Sprintf("flags=%d, len=%d (%d bytes)", env.Flags, env.Len, length)
+	}
+	return fmt.Sprintf("flags=%d, len=%d", env.Flags, env.Len)
+}
+
+func valueOrNone(val string) string {
+	if val == "" {
+		return noValue
+	}
+	return val
+}
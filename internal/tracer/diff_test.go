@@ -0,0 +1,136 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	reference := &Trace{
+		Response: &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type":         []string{"application/grpc-web"},
+				"Date":                 []string{"Mon, 19 Oct 2026 01:00:00 GMT"},
+				"Server":               []string{"reference"},
+				"Vary":                 []string{"Origin"},
+				"Grpc-Accept-Encoding": []string{"gzip"},
+			},
+		},
+		Events: []Event{
+			&RequestStart{},
+			&RequestBodyData{Envelope: &Envelope{Flags: 0, Len: 10}, Len: 10},
+			&RequestBodyEnd{},
+			&ResponseStart{},
+			&ResponseBodyData{Envelope: &Envelope{Flags: 0, Len: 5}, Len: 5},
+			&ResponseBodyData{Envelope: &Envelope{Flags: 128, Len: 30}, Len: 30},
+			&ResponseBodyEndStream{Content: "grpc-status: 0\r\ngrpc-message: \r\n"},
+			&ResponseBodyEnd{},
+		},
+	}
+
+	t.Run("same", func(t *testing.T) {
+		t.Parallel()
+		actual := *reference
+		actual.Response = &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type":         []string{"application/grpc-web"},
+				"Date":                 []string{"Tue, 20 Oct 2026 01:00:00 GMT"},
+				"Server":               []string{"under-test"},
+				"Content-Length":       []string{"45"},
+				"Grpc-Accept-Encoding": []string{"gzip,br,zstd"},
+				// Not canonical, as recorded by some connection traces.
+				"traceresponse": []string{"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+			},
+		}
+		assert.Empty(t, Diff(reference, &actual))
+
+		var buf bytes.Buffer
+		PrintDiff(reference, &actual, internal.NewPrinter(&buf))
+		assert.Equal(t, "(no differences)\n", buf.String())
+	})
+
+	t.Run("different", func(t *testing.T) {
+		t.Parallel()
+		actual := &Trace{
+			Response: &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Content-Type": []string{"application/grpc-web+proto"},
+				},
+				Trailer: http.Header{
+					"Grpc-Status": []string{"0"},
+				},
+			},
+			Err: errors.New("oops"),
+			Events: []Event{
+				&RequestStart{},
+				&RequestBodyData{Envelope: &Envelope{Flags: 0, Len: 10}, Len: 10},
+				&RequestBodyEnd{},
+				&ResponseStart{},
+				&ResponseBodyData{Envelope: &Envelope{Flags: 1, Len: 5}, Len: 3},
+				&ResponseBodyEnd{Err: errors.New("oops")},
+			},
+		}
+		assert.Equal(t, []Difference{
+			{
+				Subject:   "response header Content-Type",
+				Reference: "application/grpc-web",
+				Actual:    "application/grpc-web+proto",
+			},
+			{
+				Subject:   "response trailer Grpc-Status",
+				Reference: "(missing)",
+				Actual:    "0",
+			},
+			{
+				Subject:   "response message #1",
+				Reference: "flags=0, len=5",
+				Actual:    "flags=1, len=5 (3 bytes)",
+			},
+			{
+				Subject:   "response message #2",
+				Reference: "flags=128, len=30",
+				Actual:    "(none)",
+			},
+			{
+				Subject:   "end-stream message",
+				Reference: "grpc-status: 0\r\ngrpc-message: \r\n",
+				Actual:    "(none)",
+			},
+			{
+				Subject:   "failed",
+				Reference: "false",
+				Actual:    "true",
+			},
+		}, Diff(reference, actual))
+
+		var buf bytes.Buffer
+		PrintDiff(reference, actual, internal.NewPrinter(&buf))
+		assert.Contains(t, buf.String(), "end-stream message:\n"+
+			"    reference: grpc-status: 0\n"+
+			"               grpc-message: \n"+
+			"    actual:    (none)\n")
+	})
+}
@@ -0,0 +1,573 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+)
+
+const (
+	faultProxyDialTimeout = 5 * time.Second
+	// The largest header block fragment that the proxy puts in a frame.
+	// This is the smallest maximum frame size that a peer can advertise.
+	maxHeaderFragmentLen = 16384
+)
+
+// FaultProxy is a TCP proxy that can inject connection-level faults into
+// HTTP/2 RPCs. It forwards all bytes between a client and a server, parsing
+// the HTTP/2 frames in both directions. Streams whose request headers
+// indicate a test case that has a fault configured (via Inject) are
+// disrupted, as described by the fault. All other streams are forwarded
+// as is.
+//
+// Header blocks are decoded and then re-encoded by the proxy, so that
+// dropping the header blocks of a disrupted stream (such as its trailers)
+// does not break header compression for the rest of the connection.
+//
+// Connections that do not start with the HTTP/2 client preface (for
+// example, HTTP/1.1 or TLS connections) are forwarded without inspection,
+// so faults cannot be injected into them.
+type FaultProxy struct {
+	listener net.Listener
+	target   string
+
+	mu     sync.Mutex
+	faults map[string]*conformancev1.ConnectionFault
+	conns  map[*faultConn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewFaultProxy starts a new proxy that listens on a loopback address and
+// forwards connections to the given target address.
+func NewFaultProxy(targetAddr string) (*FaultProxy, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	proxy := &FaultProxy{
+		listener: listener,
+		target:   targetAddr,
+		faults:   map[string]*conformancev1.ConnectionFault{},
+		conns:    map[*faultConn]struct{}{},
+	}
+	proxy.wg.Add(1)
+	go proxy.serve()
+	return proxy, nil
+}
+
+// Addr returns the address on which the proxy is listening.
+func (p *FaultProxy) Addr() *net.TCPAddr {
+	return p.listener.Addr().(*net.TCPAddr) //nolint:forcetypeassert // we know it is a TCP listener
+}
+
+// Inject configures the proxy to inject the given fault into RPCs for the
+// given test case. The test case is identified by the "x-test-case-name"
+// request header.
+func (p *FaultProxy) Inject(testName string, fault *conformancev1.ConnectionFault) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.faults[testName] = fault
+}
+
+// Close stops the proxy, closing all connections that it is forwarding.
+func (p *FaultProxy) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	conns := make([]*faultConn, 0, len(p.conns))
+	for conn := range p.conns {
+		conns = append(conns, conn)
+	}
+	p.mu.Unlock()
+
+	err := p.listener.Close()
+	for _, conn := range conns {
+		conn.close()
+	}
+	p.wg.Wait()
+	return err
+}
+
+func (p *FaultProxy) serve() {
+	defer p.wg.Done()
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.handle(conn)
+		}()
+	}
+}
+
+func (p *FaultProxy) handle(clientConn net.Conn) {
+	serverConn, err := net.DialTimeout("tcp", p.target, faultProxyDialTimeout)
+	if err != nil {
+		_ = clientConn.Close()
+		return
+	}
+	conn := &faultConn{
+		proxy:         p,
+		client:        clientConn,
+		server:        serverConn,
+		clientEncoder: newHeaderEncoder(),
+		serverEncoder: newHeaderEncoder(),
+		streams:       map[uint32]*faultStream{},
+	}
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		conn.close()
+		return
+	}
+	p.conns[conn] = struct{}{}
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		delete(p.conns, conn)
+		p.mu.Unlock()
+	}()
+	conn.run()
+}
+
+func (p *FaultProxy) getFault(testName string) *conformancev1.ConnectionFault {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.faults[testName]
+}
+
+type faultStreamState int
+
+const (
+	// The fault has not yet been injected; frames are forwarded.
+	faultPending = faultStreamState(iota)
+	// The fault has been injected; frames in both directions are dropped.
+	faultInjected
+	// The response has been stalled; only response frames are dropped.
+	faultStalled
+)
+
+type faultStream struct {
+	fault       *conformancev1.ConnectionFault
+	state       faultStreamState
+	gotResponse bool
+	delivered   uint64
+}
+
+// faultConn is a single connection being forwarded by a FaultProxy.
+type faultConn struct {
+	proxy          *FaultProxy
+	client, server net.Conn
+	closeOnce      sync.Once
+
+	// Serializes writes to each side, since frames can be injected by
+	// either direction's goroutine.
+	clientWriteMu, serverWriteMu sync.Mutex
+
+	// Encoders for the header blocks forwarded to each side.
+	clientEncoder, serverEncoder *headerEncoder
+
+	mu           sync.Mutex
+	streams      map[uint32]*faultStream
+	lastStreamID uint32
+}
+
+func (c *faultConn) run() {
+	defer c.close()
+	preface := make([]byte, len(clientPreface))
+	n, err := io.ReadFull(c.client, preface)
+	if _, writeErr := c.server.Write(preface[:n]); err != nil || writeErr != nil {
+		return
+	}
+	if !prefaceIsValid(preface) {
+		// Not HTTP/2 (or not clear-text), so we can't inspect it.
+		go func() {
+			defer c.close()
+			_, _ = io.Copy(c.client, c.server)
+		}()
+		_, _ = io.Copy(c.server, c.client)
+		return
+	}
+	go func() {
+		defer c.close()
+		_ = c.forward(false)
+	}()
+	_ = c.forward(true)
+}
+
+func (c *faultConn) close() {
+	c.closeOnce.Do(func() {
+		_ = c.client.Close()
+		_ = c.server.Close()
+	})
+}
+
+// forward reads frames from one side of the connection and handles them,
+// which usually means writing them to the other side. If isRequest is true,
+// frames are read from the client; otherwise they are read from the server.
+func (c *faultConn) forward(isRequest bool) error {
+	src := c.server
+	if isRequest {
+		src = c.client
+	}
+	decoder := hpack.NewDecoder(math.MaxUint32, nil)
+	var headerBlock bytes.Buffer
+	for {
+		raw := make([]byte, frameHeaderLen)
+		if _, err := io.ReadFull(src, raw); err != nil {
+			return err
+		}
+		header, err := http2.ReadFrameHeader(bytes.NewReader(raw))
+		if err != nil {
+			return err
+		}
+		raw = append(raw, make([]byte, header.Length)...)
+		if _, err := io.ReadFull(src, raw[frameHeaderLen:]); err != nil {
+			return err
+		}
+		if header.Type == http2.FrameHeaders || header.Type == http2.FrameContinuation {
+			// Header blocks may span multiple frames. We must parse them all
+			// together, to keep the HPACK decoder in sync with the peer.
+			headerBlock.Write(raw)
+			if !header.Flags.Has(http2.FlagHeadersEndHeaders) {
+				continue
+			}
+			raw = append([]byte(nil), headerBlock.Bytes()...)
+			headerBlock.Reset()
+		}
+		framer := http2.NewFramer(io.Discard, bytes.NewReader(raw))
+		framer.ReadMetaHeaders = decoder
+		frame, err := framer.ReadFrame()
+		if err != nil {
+			// We can no longer reliably interpret this side of the
+			// connection. Since header blocks are re-encoded, we can't
+			// forward the rest as is either. So give up on the connection.
+			return err
+		}
+		if isRequest {
+			err = c.handleRequestFrame(frame, raw)
+		} else {
+			err = c.handleResponseFrame(frame, raw)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *faultConn) handleRequestFrame(frame http2.Frame, raw []byte) error {
+	streamID := frame.Header().StreamID
+	if streamID == 0 {
+		if settings, ok := frame.(*http2.SettingsFrame); ok {
+			c.clientEncoder.applySettings(settings)
+		}
+		return c.write(false, raw)
+	}
+	c.mu.Lock()
+	stream := c.streams[streamID]
+	if metaFrame, ok := frame.(*http2.MetaHeadersFrame); ok && stream == nil && streamID > c.lastStreamID {
+		c.lastStreamID = streamID
+		req := makeRequest(metaFrame.Fields, 2)
+		if fault := c.proxy.getFault(req.Header.Get(testCaseNameHeader)); fault != nil {
+			stream = &faultStream{fault: fault}
+			c.streams[streamID] = stream
+		}
+	}
+	injected := stream != nil && stream.state == faultInjected
+	c.mu.Unlock()
+
+	if !injected {
+		return c.forwardFrame(false, frame, raw)
+	}
+	// The server's stream has already been reset, so we drop
+	// anything else the client sends for it.
+	if frame.Header().Type == http2.FrameData {
+		return c.replenish(true, frame.Header().Length)
+	}
+	return nil
+}
+
+func (c *faultConn) handleResponseFrame(frame http2.Frame, raw []byte) error {
+	streamID := frame.Header().StreamID
+	c.mu.Lock()
+	stream := c.streams[streamID]
+	c.mu.Unlock()
+	if settings, ok := frame.(*http2.SettingsFrame); ok {
+		c.serverEncoder.applySettings(settings)
+	}
+	if streamID == 0 || stream == nil {
+		return c.forwardFrame(true, frame, raw)
+	}
+	if stream.state != faultPending {
+		// The fault was already injected, so drop anything else the
+		// server sends for this stream.
+		if frame.Header().Type == http2.FrameData {
+			return c.replenish(false, frame.Header().Length)
+		}
+		return nil
+	}
+
+	threshold := uint64(stream.fault.AfterResponseBytes)
+	switch frame := frame.(type) {
+	case *http2.MetaHeadersFrame:
+		if stream.gotResponse || frame.StreamEnded() {
+			// These are trailers, or the response has no body. Either way,
+			// this would end the response, so inject the fault instead.
+			return c.inject(streamID, stream)
+		}
+		stream.gotResponse = true
+		if err := c.forwardFrame(true, frame, raw); err != nil {
+			return err
+		}
+		if threshold == 0 {
+			return c.inject(streamID, stream)
+		}
+		return nil
+	case *http2.DataFrame:
+		data := frame.Data()
+		remaining := threshold - stream.delivered
+		if uint64(len(data)) < remaining || (uint64(len(data)) == remaining && !frame.StreamEnded()) {
+			stream.delivered += uint64(len(data))
+			if err := c.write(true, raw); err != nil {
+				return err
+			}
+			if stream.delivered == threshold {
+				return c.inject(streamID, stream)
+			}
+			return nil
+		}
+		// Only deliver a prefix of this frame's data, up to the threshold.
+		// The rest is dropped.
+		var buf bytes.Buffer
+		if remaining > 0 {
+			if err := http2.NewFramer(&buf, nil).WriteData(streamID, false, data[:remaining]); err != nil {
+				return err
+			}
+			if err := c.write(true, buf.Bytes()); err != nil {
+				return err
+			}
+			stream.delivered += remaining
+		}
+		if err := c.replenish(false, frame.Header().Length-uint32(remaining)); err != nil {
+			return err
+		}
+		return c.inject(streamID, stream)
+	case *http2.RSTStreamFrame:
+		// The server gave up on the stream before the fault was triggered.
+		c.mu.Lock()
+		delete(c.streams, streamID)
+		c.mu.Unlock()
+		return c.write(true, raw)
+	default:
+		return c.write(true, raw)
+	}
+}
+
+// forwardFrame writes the given frame to the client, if toClient is true,
+// or to the server. Header blocks are re-encoded; other frames are written
+// as is.
+func (c *faultConn) forwardFrame(toClient bool, frame http2.Frame, raw []byte) error {
+	metaFrame, ok := frame.(*http2.MetaHeadersFrame)
+	if !ok {
+		return c.write(toClient, raw)
+	}
+	encoder := c.serverEncoder
+	if toClient {
+		encoder = c.clientEncoder
+	}
+	data, err := encoder.encode(metaFrame)
+	if err != nil {
+		return err
+	}
+	return c.write(toClient, data)
+}
+
+// inject performs the given stream's fault. This is only called from the
+// goroutine that forwards response frames.
+func (c *faultConn) inject(streamID uint32, stream *faultStream) error {
+	errCode := http2.ErrCode(stream.fault.Http2ErrorCode)
+	switch stream.fault.Action {
+	case conformancev1.ConnectionFault_ACTION_RST_STREAM:
+		c.setState(stream, faultInjected)
+		if err := c.writeFrame(true, func(framer *http2.Framer) error {
+			return framer.WriteRSTStream(streamID, errCode)
+		}); err != nil {
+			return err
+		}
+		return c.writeFrame(false, func(framer *http2.Framer) error {
+			return framer.WriteRSTStream(streamID, http2.ErrCodeCancel)
+		})
+	case conformancev1.ConnectionFault_ACTION_TRUNCATE_BODY:
+		c.setState(stream, faultInjected)
+		if err := c.writeFrame(true, func(framer *http2.Framer) error {
+			return framer.WriteData(streamID, true, nil)
+		}); err != nil {
+			return err
+		}
+		return c.writeFrame(false, func(framer *http2.Framer) error {
+			return framer.WriteRSTStream(streamID, http2.ErrCodeCancel)
+		})
+	case conformancev1.ConnectionFault_ACTION_GOAWAY:
+		c.mu.Lock()
+		lastStreamID := c.lastStreamID
+		c.mu.Unlock()
+		err := c.writeFrame(true, func(framer *http2.Framer) error {
+			return framer.WriteGoAway(lastStreamID, errCode, []byte("fault injected"))
+		})
+		c.close()
+		if err != nil {
+			return err
+		}
+		return errFaultInjected
+	case conformancev1.ConnectionFault_ACTION_TCP_RESET:
+		if tcpConn, ok := c.client.(*net.TCPConn); ok {
+			// Discard unsent data and send RST instead of FIN when closed.
+			_ = tcpConn.SetLinger(0)
+		}
+		c.close()
+		return errFaultInjected
+	case conformancev1.ConnectionFault_ACTION_STALL:
+		c.setState(stream, faultStalled)
+		return nil
+	default:
+		return fmt.Errorf("unsupported connection fault action: %v", stream.fault.Action)
+	}
+}
+
+var errFaultInjected = errors.New("connection closed by injected fault")
+
+func (c *faultConn) setState(stream *faultStream, state faultStreamState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stream.state = state
+}
+
+// replenish sends a connection-level WINDOW_UPDATE frame for data that
+// was dropped, so the peer doesn't run out of flow control window for
+// other streams on the connection. If toClient is true, the frame is sent
+// to the client; otherwise, to the server.
+func (c *faultConn) replenish(toClient bool, length uint32) error {
+	if length == 0 {
+		return nil
+	}
+	return c.writeFrame(toClient, func(framer *http2.Framer) error {
+		return framer.WriteWindowUpdate(0, length)
+	})
+}
+
+func (c *faultConn) writeFrame(toClient bool, writeFunc func(*http2.Framer) error) error {
+	var buf bytes.Buffer
+	if err := writeFunc(http2.NewFramer(&buf, nil)); err != nil {
+		return err
+	}
+	return c.write(toClient, buf.Bytes())
+}
+
+// headerEncoder encodes the header blocks that a faultConn forwards to one
+// side of the connection. Since the proxy only forwards some of the header
+// blocks that it receives, it can't forward them as they were encoded by
+// the peer: the receiver's HPACK decoder would get out of sync with the
+// peer's encoder.
+type headerEncoder struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	encoder *hpack.Encoder
+}
+
+func newHeaderEncoder() *headerEncoder {
+	enc := &headerEncoder{}
+	enc.encoder = hpack.NewEncoder(&enc.buf)
+	return enc
+}
+
+// applySettings limits the size of the dynamic table, if the given settings
+// are from the side that receives the encoded header blocks and reduce it.
+func (e *headerEncoder) applySettings(settings *http2.SettingsFrame) {
+	if settings.IsAck() {
+		return
+	}
+	if size, ok := settings.Value(http2.SettingHeaderTableSize); ok {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		e.encoder.SetMaxDynamicTableSizeLimit(size)
+	}
+}
+
+// encode returns the given header block, re-encoded as a HEADERS frame,
+// followed by CONTINUATION frames if needed.
+func (e *headerEncoder) encode(frame *http2.MetaHeadersFrame) ([]byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.buf.Reset()
+	for _, field := range frame.Fields {
+		if err := e.encoder.WriteField(field); err != nil {
+			return nil, err
+		}
+	}
+	block := e.buf.Bytes()
+	var result bytes.Buffer
+	framer := http2.NewFramer(&result, nil)
+	streamID := frame.Header().StreamID
+	first := true
+	for first || len(block) > 0 {
+		fragment := block
+		if len(fragment) > maxHeaderFragmentLen {
+			fragment = fragment[:maxHeaderFragmentLen]
+		}
+		block = block[len(fragment):]
+		var err error
+		if first {
+			err = framer.WriteHeaders(http2.HeadersFrameParam{
+				StreamID:      streamID,
+				BlockFragment: fragment,
+				EndStream:     frame.StreamEnded(),
+				EndHeaders:    len(block) == 0,
+				Priority:      frame.Priority,
+			})
+			first = false
+		} else {
+			err = framer.WriteContinuation(streamID, len(block) == 0, fragment)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result.Bytes(), nil
+}
+
+func (c *faultConn) write(toClient bool, data []byte) error {
+	conn, mu := c.server, &c.serverWriteMu
+	if toClient {
+		conn, mu = c.client, &c.clientWriteMu
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	_, err := conn.Write(data)
+	return err
+}
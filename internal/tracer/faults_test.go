@@ -0,0 +1,226 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	conformancev1 "connectrpc.com/conformance/internal/gen/proto/go/connectrpc/conformance/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func TestFaultProxy(t *testing.T) {
+	t.Parallel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	svr := &http.Server{
+		Handler: h2c.NewHandler(http.HandlerFunc(func(respWriter http.ResponseWriter, _ *http.Request) {
+			respWriter.Header().Set("Trailer", "x-trailer")
+			respWriter.WriteHeader(http.StatusOK)
+			for i := 0; i < 4; i++ {
+				_, _ = respWriter.Write([]byte("abcd"))
+				respWriter.(http.Flusher).Flush() //nolint:forcetypeassert
+			}
+			respWriter.Header().Set("x-trailer", "done")
+		}), &http2.Server{}),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		_ = svr.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		_ = svr.Close()
+	})
+
+	proxy, err := NewFaultProxy(listener.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = proxy.Close()
+	})
+
+	testCases := []struct {
+		name          string
+		fault         *conformancev1.ConnectionFault
+		timeout       time.Duration
+		expectBody    string
+		expectTrailer string
+		expectErr     func(*testing.T, error)
+		// If true, another request, without a fault, is sent on the same
+		// connection after the first. This verifies that header blocks
+		// dropped by the fault don't break the rest of the connection.
+		followUp bool
+	}{
+		{
+			name:          "no-fault",
+			expectBody:    "abcdabcdabcdabcd",
+			expectTrailer: "done",
+		},
+		{
+			name: "rst-stream",
+			fault: &conformancev1.ConnectionFault{
+				Action:             conformancev1.ConnectionFault_ACTION_RST_STREAM,
+				AfterResponseBytes: 6,
+				Http2ErrorCode:     uint32(http2.ErrCodeEnhanceYourCalm),
+			},
+			expectBody: "abcdab",
+			expectErr: func(t *testing.T, err error) {
+				t.Helper()
+				var streamErr http2.StreamError
+				require.ErrorAs(t, err, &streamErr)
+				assert.Equal(t, http2.ErrCodeEnhanceYourCalm, streamErr.Code)
+			},
+		},
+		{
+			name: "truncate",
+			fault: &conformancev1.ConnectionFault{
+				Action:             conformancev1.ConnectionFault_ACTION_TRUNCATE_BODY,
+				AfterResponseBytes: 10,
+			},
+			expectBody: "abcdabcdab",
+		},
+		{
+			name: "truncate-before-trailers",
+			fault: &conformancev1.ConnectionFault{
+				Action:             conformancev1.ConnectionFault_ACTION_TRUNCATE_BODY,
+				AfterResponseBytes: 1000,
+			},
+			expectBody: "abcdabcdabcdabcd",
+			followUp:   true,
+		},
+		{
+			name: "rst-stream-before-trailers",
+			fault: &conformancev1.ConnectionFault{
+				Action:             conformancev1.ConnectionFault_ACTION_RST_STREAM,
+				AfterResponseBytes: 1000,
+				Http2ErrorCode:     uint32(http2.ErrCodeCancel),
+			},
+			expectBody: "abcdabcdabcdabcd",
+			expectErr: func(t *testing.T, err error) {
+				t.Helper()
+				var streamErr http2.StreamError
+				require.ErrorAs(t, err, &streamErr)
+				assert.Equal(t, http2.ErrCodeCancel, streamErr.Code)
+			},
+			followUp: true,
+		},
+		{
+			name: "goaway",
+			fault: &conformancev1.ConnectionFault{
+				Action:             conformancev1.ConnectionFault_ACTION_GOAWAY,
+				AfterResponseBytes: 4,
+				Http2ErrorCode:     uint32(http2.ErrCodeInternal),
+			},
+			expectBody: "abcd",
+			expectErr: func(t *testing.T, err error) {
+				t.Helper()
+				var goAwayErr http2.GoAwayError
+				require.ErrorAs(t, err, &goAwayErr)
+				assert.Equal(t, http2.ErrCodeInternal, goAwayErr.ErrCode)
+			},
+		},
+		{
+			name: "tcp-reset",
+			fault: &conformancev1.ConnectionFault{
+				Action:             conformancev1.ConnectionFault_ACTION_TCP_RESET,
+				AfterResponseBytes: 4,
+			},
+			expectBody: "abcd",
+			expectErr: func(t *testing.T, err error) {
+				t.Helper()
+				require.Error(t, err)
+			},
+		},
+		{
+			name: "stall",
+			fault: &conformancev1.ConnectionFault{
+				Action:             conformancev1.ConnectionFault_ACTION_STALL,
+				AfterResponseBytes: 8,
+			},
+			timeout:    200 * time.Millisecond,
+			expectBody: "abcdabcd",
+			expectErr: func(t *testing.T, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, context.DeadlineExceeded)
+			},
+			followUp: true,
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			if testCase.fault != nil {
+				proxy.Inject(testCase.name, testCase.fault)
+			}
+			ctx := context.Background()
+			if testCase.timeout != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, testCase.timeout)
+				t.Cleanup(cancel)
+			}
+			// Use a separate transport for each case, since some faults
+			// break the whole connection.
+			client := &http.Client{
+				Transport: &http2.Transport{
+					AllowHTTP: true,
+					DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+						return (&net.Dialer{}).DialContext(ctx, network, addr)
+					},
+				},
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+proxy.Addr().String()+"/", strings.NewReader("request"))
+			require.NoError(t, err)
+			req.Header.Set(testCaseNameHeader, testCase.name)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				_ = resp.Body.Close()
+			})
+			body, err := io.ReadAll(resp.Body)
+			assert.Equal(t, testCase.expectBody, string(body))
+			if testCase.expectErr == nil {
+				require.NoError(t, err)
+				assert.Equal(t, testCase.expectTrailer, resp.Trailer.Get("x-trailer"))
+			} else {
+				testCase.expectErr(t, err)
+			}
+			if !testCase.followUp {
+				return
+			}
+			followUpReq, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://"+proxy.Addr().String()+"/", strings.NewReader("request"))
+			require.NoError(t, err)
+			followUpReq.Header.Set(testCaseNameHeader, testCase.name+"/follow-up")
+			followUpResp, err := client.Do(followUpReq)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				_ = followUpResp.Body.Close()
+			})
+			body, err = io.ReadAll(followUpResp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "abcdabcdabcdabcd", string(body))
+			assert.Equal(t, "done", followUpResp.Trailer.Get("x-trailer"))
+		})
+	}
+}
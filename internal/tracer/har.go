@@ -0,0 +1,335 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+)
+
+// WriteHAR writes the given traces to w as an HTTP Archive (HAR), in the
+// HAR 1.2 format, with one entry per trace. This allows traces to be loaded
+// into browser developer tools and other HAR viewers.
+//
+// The format has no place for some of the data in a trace, so it is
+// included as custom fields, whose names start with an underscore:
+//   - Entries include the test case name in a "_testName" field and, if
+//     the operation failed, the error in an "_error" field.
+//   - Responses include trailers in a "_trailers" field.
+//   - The request's "postData" and the response's "content" include the
+//     messages in the body, in a "_messages" field. For streaming protocols,
+//     each message includes its envelope (flags and length). End-stream
+//     messages also include their contents.
+//   - If the trace includes HTTP/2 frames, entries include them in a
+//     "_frames" field.
+func WriteHAR(w io.Writer, traces ...*Trace) error {
+	doc := harDocument{
+		Log: harLog{
+			Version: "1.2",
+			Creator: harCreator{Name: "connectconformance", Version: internal.Version},
+			Entries: make([]harEntry, 0, len(traces)),
+		},
+	}
+	for _, trace := range traces {
+		doc.Log.Entries = append(doc.Log.Entries, newHAREntry(trace))
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&doc)
+}
+
+type harDocument struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	TestName        string      `json:"_testName,omitempty"`
+	Error           string      `json:"_error,omitempty"`
+	Frames          []harFrame  `json:"_frames,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type harPostData struct {
+	MimeType string       `json:"mimeType"`
+	Text     string       `json:"text"`
+	Messages []harMessage `json:"_messages,omitempty"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+	Trailers    []harNameValue `json:"_trailers,omitempty"`
+}
+
+type harContent struct {
+	Size     int64        `json:"size"`
+	MimeType string       `json:"mimeType"`
+	Messages []harMessage `json:"_messages,omitempty"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harMessage struct {
+	Index    int          `json:"index"`
+	OffsetMs float64      `json:"offsetMs"`
+	Envelope *harEnvelope `json:"envelope,omitempty"`
+	// The number of bytes of the message that were actually
+	// written or read, which may be less than the length in
+	// the envelope if the message was incomplete.
+	Bytes uint64 `json:"bytes"`
+	// The decoded message, if it could be decoded.
+	Message   json.RawMessage `json:"message,omitempty"`
+	EndStream string          `json:"endStream,omitempty"`
+	// The decoded error in the end-stream message, if any.
+	EndStreamError json.RawMessage `json:"endStreamError,omitempty"`
+}
+
+type harFrame struct {
+	OffsetMs   float64 `json:"offsetMs"`
+	FromClient bool    `json:"fromClient"`
+	StreamID   uint32  `json:"streamId"`
+	Type       string  `json:"type"`
+	Length     uint32  `json:"length"`
+	Summary    string  `json:"summary,omitempty"`
+}
+
+type harEnvelope struct {
+	Flags  byte   `json:"flags"`
+	Length uint32 `json:"length"`
+}
+
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+func newHAREntry(trace *Trace) harEntry {
+	entry := harEntry{
+		StartedDateTime: trace.Start.Format(time.RFC3339Nano),
+		TestName:        trace.TestName,
+		Request: harRequest{
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+		},
+		Response: harResponse{
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			HeadersSize: -1,
+		},
+	}
+	if trace.Err != nil {
+		entry.Error = trace.Err.Error()
+	}
+
+	// Timings are derived from the event offsets. The request is being
+	// sent until the request body ends, the client then waits for the
+	// response to start, and then receives the response until the
+	// response body ends. For full-duplex operations, the request body
+	// may end after the response starts, in which case sending is
+	// considered done when the response starts.
+	var requestEnd, responseStart, end time.Duration
+	var hasResponse bool
+	var reqMessages, respMessages []harMessage
+	for _, event := range trace.Events {
+		switch event := event.(type) {
+		case *RequestStart:
+			entry.Request.Method = event.Request.Method
+			entry.Request.URL = requestURL(event.Request)
+			entry.Request.HTTPVersion = event.Request.Proto
+			entry.Request.Headers = harHeaders(event.getHeaders())
+			for name, vals := range event.Request.URL.Query() {
+				for _, val := range vals {
+					entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: name, Value: val})
+				}
+			}
+			sortNameValues(entry.Request.QueryString)
+		case *RequestBodyData:
+			reqMessages = append(reqMessages, newHARMessage(event.MessageIndex, event.Offset, event.Envelope, event.Len, event.Message))
+			entry.Request.BodySize += messageSize(event.Envelope, event.Len)
+			requestEnd = event.Offset
+		case *RequestBodyEnd:
+			requestEnd = event.Offset
+		case *ResponseStart:
+			hasResponse = true
+			responseStart = event.Offset
+			resp := event.Response
+			entry.Response.Status = resp.StatusCode
+			entry.Response.StatusText = http.StatusText(resp.StatusCode)
+			entry.Response.HTTPVersion = resp.Proto
+			entry.Response.Headers = harHeaders(resp.Header)
+			entry.Response.Content.MimeType = resp.Header.Get("Content-Type")
+		case *ResponseBodyData:
+			respMessages = append(respMessages, newHARMessage(event.MessageIndex, event.Offset, event.Envelope, event.Len, event.Message))
+			entry.Response.BodySize += messageSize(event.Envelope, event.Len)
+		case *ResponseBodyEndStream:
+			if len(respMessages) > 0 {
+				respMessages[len(respMessages)-1].EndStream = event.Content
+				if event.Error != "" {
+					respMessages[len(respMessages)-1].EndStreamError = json.RawMessage(event.Error)
+				}
+			}
+		}
+		if event.offset() > end {
+			end = event.offset()
+		}
+	}
+	if len(reqMessages) > 0 {
+		entry.Request.PostData = &harPostData{
+			MimeType: trace.Request.Header.Get("Content-Type"),
+			Messages: reqMessages,
+		}
+	}
+	entry.Response.Content.Size = entry.Response.BodySize
+	entry.Response.Content.Messages = respMessages
+	if trace.Response != nil {
+		entry.Response.Trailers = harHeaders(trace.Response.Trailer)
+		if len(entry.Response.Trailers) == 0 {
+			entry.Response.Trailers = nil
+		}
+	}
+
+	send := requestEnd
+	if hasResponse && send > responseStart {
+		send = responseStart
+	}
+	if send > end {
+		send = end
+	}
+	timings := harTimings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1, Send: millis(send)}
+	if hasResponse {
+		timings.Wait = millis(responseStart - send)
+		timings.Receive = millis(end - responseStart)
+	} else {
+		timings.Wait = millis(end - send)
+	}
+	entry.Timings = timings
+	entry.Time = timings.Send + timings.Wait + timings.Receive
+	for _, frame := range trace.Frames {
+		entry.Frames = append(entry.Frames, harFrame{
+			OffsetMs:   millis(frame.Offset),
+			FromClient: frame.FromClient,
+			StreamID:   frame.StreamID,
+			Type:       frame.Type,
+			Length:     frame.Length,
+			Summary:    frame.Summary,
+		})
+	}
+	return entry
+}
+
+func newHARMessage(index int, offset time.Duration, env *Envelope, length uint64, message string) harMessage {
+	msg := harMessage{Index: index, OffsetMs: millis(offset), Bytes: length}
+	if message != "" {
+		msg.Message = json.RawMessage(message)
+	}
+	if env != nil {
+		msg.Envelope = &harEnvelope{Flags: env.Flags, Length: env.Len}
+	}
+	return msg
+}
+
+// messageSize returns the number of bytes in the body for a message,
+// including the envelope prefix if there is one.
+func messageSize(env *Envelope, length uint64) int64 {
+	if env != nil {
+		return int64(length) + prefixLen
+	}
+	return int64(length)
+}
+
+func requestURL(req *http.Request) string {
+	urlClone := *req.URL
+	if urlClone.Host == "" {
+		urlClone.Host = req.Host
+	}
+	if req.TLS != nil {
+		urlClone.Scheme = "https"
+	} else {
+		urlClone.Scheme = "http"
+	}
+	return urlClone.String()
+}
+
+func harHeaders(headers http.Header) []harNameValue {
+	result := make([]harNameValue, 0, len(headers))
+	for name, vals := range headers {
+		for _, val := range vals {
+			result = append(result, harNameValue{Name: name, Value: val})
+		}
+	}
+	sortNameValues(result)
+	return result
+}
+
+func sortNameValues(values []harNameValue) {
+	// Stable, so that repeated values stay in their original order.
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].Name < values[j].Name
+	})
+}
+
+func millis(d time.Duration) float64 {
+	return d.Seconds() * 1000
+}
@@ -0,0 +1,129 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteHAR(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	req := &http.Request{
+		Method: http.MethodPost,
+		URL:    &url.URL{Path: "/connectrpc.conformance.v1.ConformanceService/ServerStream"},
+		Host:   "127.0.0.1:8080",
+		Proto:  "HTTP/2.0",
+		Header: headers("Content-Type", "application/connect+proto"),
+	}
+	resp := &http.Response{
+		StatusCode: 200,
+		Proto:      "HTTP/2.0",
+		Header:     headers("Content-Type", "application/connect+proto"),
+		Trailer:    headers("X-Custom-Trailer", "bing"),
+	}
+	trace := &Trace{
+		TestName: "Suite/server-stream/success",
+		Start:    start,
+		Request:  req,
+		Response: resp,
+		Err:      errors.New("oops"),
+		Events: []Event{
+			&RequestStart{Request: req, getHeaders: func() http.Header { return req.Header }},
+			&RequestBodyData{Envelope: &Envelope{Len: 10}, Len: 10, eventOffset: eventOffset{Offset: time.Millisecond}},
+			&RequestBodyEnd{eventOffset: eventOffset{Offset: 2 * time.Millisecond}},
+			&ResponseStart{Response: resp, eventOffset: eventOffset{Offset: 5 * time.Millisecond}},
+			&ResponseBodyData{Envelope: &Envelope{Len: 20}, Len: 20, Message: `{"payload":{}}`, eventOffset: eventOffset{Offset: 6 * time.Millisecond}},
+			&ResponseBodyData{Envelope: &Envelope{Flags: 2, Len: 2}, Len: 2, MessageIndex: 1, eventOffset: eventOffset{Offset: 7 * time.Millisecond}},
+			&ResponseBodyEndStream{Content: `{"error":{"code":"internal"}}`, Error: `{"code":"internal"}`, eventOffset: eventOffset{Offset: 7 * time.Millisecond}},
+			&ResponseBodyEnd{eventOffset: eventOffset{Offset: 8 * time.Millisecond}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteHAR(&buf, trace))
+	var doc harDocument
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "1.2", doc.Log.Version)
+	require.Len(t, doc.Log.Entries, 1)
+	entry := doc.Log.Entries[0]
+	assert.Equal(t, "2024-01-02T03:04:05Z", entry.StartedDateTime)
+	assert.Equal(t, "Suite/server-stream/success", entry.TestName)
+	assert.Equal(t, "oops", entry.Error)
+	assert.InDelta(t, 8.0, entry.Time, 0.001)
+	assert.Equal(t, harTimings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1, Send: 2, Wait: 3, Receive: 3}, entry.Timings)
+
+	assert.Equal(t, "POST", entry.Request.Method)
+	assert.Equal(t, "http://127.0.0.1:8080/connectrpc.conformance.v1.ConformanceService/ServerStream", entry.Request.URL)
+	assert.Equal(t, []harNameValue{{Name: "Content-Type", Value: "application/connect+proto"}}, entry.Request.Headers)
+	assert.Equal(t, int64(15), entry.Request.BodySize)
+	require.NotNil(t, entry.Request.PostData)
+	assert.Equal(t, []harMessage{
+		{Index: 0, OffsetMs: 1, Envelope: &harEnvelope{Length: 10}, Bytes: 10},
+	}, entry.Request.PostData.Messages)
+
+	assert.Equal(t, 200, entry.Response.Status)
+	assert.Equal(t, "OK", entry.Response.StatusText)
+	assert.Equal(t, int64(32), entry.Response.BodySize)
+	assert.Equal(t, int64(32), entry.Response.Content.Size)
+	assert.Equal(t, "application/connect+proto", entry.Response.Content.MimeType)
+	assert.Equal(t, []harNameValue{{Name: "X-Custom-Trailer", Value: "bing"}}, entry.Response.Trailers)
+	require.Len(t, entry.Response.Content.Messages, 2)
+	// The HAR file is indented, so decoded JSON is compared separately.
+	respMessages := entry.Response.Content.Messages
+	assert.JSONEq(t, `{"payload":{}}`, string(respMessages[0].Message))
+	assert.JSONEq(t, `{"code":"internal"}`, string(respMessages[1].EndStreamError))
+	respMessages[0].Message = nil
+	respMessages[1].EndStreamError = nil
+	assert.Equal(t, []harMessage{
+		{Index: 0, OffsetMs: 6, Envelope: &harEnvelope{Length: 20}, Bytes: 20},
+		{Index: 1, OffsetMs: 7, Envelope: &harEnvelope{Flags: 2, Length: 2}, Bytes: 2, EndStream: `{"error":{"code":"internal"}}`},
+	}, respMessages)
+}
+
+func TestWriteHAR_NoResponse(t *testing.T) {
+	t.Parallel()
+	req := &http.Request{
+		Method: http.MethodPost,
+		URL:    &url.URL{Path: "/foo"},
+		Header: http.Header{},
+	}
+	trace := &Trace{
+		Request: req,
+		Err:     errors.New("connection refused"),
+		Events: []Event{
+			&RequestStart{Request: req, getHeaders: func() http.Header { return req.Header }},
+			&ResponseError{Err: errors.New("connection refused"), eventOffset: eventOffset{Offset: 3 * time.Millisecond}},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, WriteHAR(&buf, trace))
+	var doc harDocument
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	require.Len(t, doc.Log.Entries, 1)
+	entry := doc.Log.Entries[0]
+	assert.Equal(t, 0, entry.Response.Status)
+	assert.Nil(t, entry.Request.PostData)
+	assert.Equal(t, harTimings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1, Send: 0, Wait: 3, Receive: 0}, entry.Timings)
+}
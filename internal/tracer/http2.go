@@ -53,12 +53,32 @@ const (
 // and responses are written. Otherwise, this is a client connection, and
 // requests are written and responses are read.
 func TracingHTTP2Conn(conn net.Conn, isServer bool, collector Collector) net.Conn {
+	return TracingHTTP2ConnWithObserver(conn, isServer, collector, nil)
+}
+
+// HTTP2FrameObserver is notified of each frame sent or received on an HTTP/2
+// connection, after the frame is decoded. If isRequest is true, the frame was
+// sent by the client; otherwise, by the server. The testName is that of the
+// test case whose RPC uses the frame's stream or, for frames that are not
+// associated with a stream, whose RPC used the most recent stream. It is
+// empty if not known, such as when the client's headers could not be decoded.
+type HTTP2FrameObserver func(frame http2.Frame, isRequest bool, testName string)
+
+// TracingHTTP2ConnWithObserver is like TracingHTTP2Conn, except that the given
+// observer, if non-nil, is also notified of the frames on the connection. This
+// allows the decoding done by the tracer to be shared.
+func TracingHTTP2ConnWithObserver(conn net.Conn, isServer bool, collector Collector, observer HTTP2FrameObserver) net.Conn {
 	tracer := &tracingHTTP2Conn{
-		Conn:        conn,
-		isServer:    isServer,
-		collector:   &http2RetryCollector{collector: collector},
-		readTracer:  http2FrameTracer{isRequest: isServer},
-		writeTracer: http2FrameTracer{isRequest: !isServer},
+		Conn:         conn,
+		isServer:     isServer,
+		recordFrames: recordsFrames(collector),
+		observer:     observer,
+		collector:    &http2RetryCollector{collector: collector},
+		readTracer:   http2FrameTracer{isRequest: isServer},
+		writeTracer:  http2FrameTracer{isRequest: !isServer},
+	}
+	if t, ok := collector.(*Tracer); ok && t != nil && t.Capture != nil {
+		tracer.capture = t.Capture.newConn(conn.LocalAddr(), conn.RemoteAddr(), isServer)
 	}
 	tracer.readTracer.c = tracer
 	tracer.readTracer.decoder = hpack.NewDecoder(math.MaxUint32, nil)
@@ -87,19 +107,27 @@ func TracingHTTP2Listener(listener net.Listener, collector Collector) net.Listen
 
 type tracingHTTP2Conn struct {
 	net.Conn
-	isServer  bool
-	collector *http2RetryCollector
+	isServer     bool
+	recordFrames bool
+	collector    *http2RetryCollector
+	capture      *capturedConn
+	observer     HTTP2FrameObserver
 
-	mu          sync.Mutex
-	streams     map[uint32]*http2Stream
-	maxStreamID uint32
-	readTracer  http2FrameTracer
-	writeTracer http2FrameTracer
+	mu           sync.Mutex
+	streams      map[uint32]*http2Stream
+	maxStreamID  uint32
+	lastTestName string
+	readTracer   http2FrameTracer
+	writeTracer  http2FrameTracer
 }
 
 func (c *tracingHTTP2Conn) Read(data []byte) (n int, err error) {
 	n, err = c.Conn.Read(data)
 	c.readTracer.trace(data[:n])
+	c.capture.read(data[:n])
+	if errors.Is(err, io.EOF) {
+		c.capture.close(false)
+	}
 
 	if err != nil {
 		// We ignore timeout errors because the HTTP/2 server
@@ -124,6 +152,7 @@ func (c *tracingHTTP2Conn) Write(data []byte) (n int, err error) {
 	// other goroutine, all while we are concurrently capturing the trace.
 	// That leads to strange non-deterministic issues with event ordering.
 	c.writeTracer.trace(data)
+	c.capture.write(data)
 	n, err = c.Conn.Write(data)
 	if err != nil {
 		c.cancelAll(err)
@@ -133,6 +162,7 @@ func (c *tracingHTTP2Conn) Write(data []byte) (n int, err error) {
 
 func (c *tracingHTTP2Conn) Close() error {
 	err := c.Conn.Close()
+	c.capture.close(true)
 	if err == nil {
 		c.cancelAll(errors.New("socket closed"))
 	} else {
@@ -144,6 +174,48 @@ func (c *tracingHTTP2Conn) Close() error {
 func (c *tracingHTTP2Conn) handleFrame(frame http2.Frame, isRequest bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	streamID := frame.Header().StreamID
+	testName := c.testNameLocked(streamID)
+	c.handleFrameLocked(frame, isRequest)
+	if c.observer != nil {
+		if testName == "" {
+			// The frame may have started a new stream.
+			testName = c.testNameLocked(streamID)
+		}
+		c.observer(frame, isRequest, testName)
+	}
+}
+
+// handleFrameFailed is called when a frame, with the given header, can't be
+// decoded. After this, no more frames are decoded in that direction.
+func (c *tracingHTTP2Conn) handleFrameFailed(header http2.FrameHeader) {
+	if header.Type != http2.FrameHeaders && header.Type != http2.FrameContinuation {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// The frame may have started a new stream, but we can't tell
+	// for which test case. So we no longer know the test case of
+	// the most recent stream.
+	c.lastTestName = ""
+}
+
+func (c *tracingHTTP2Conn) testNameLocked(streamID uint32) string {
+	if streamID == 0 {
+		return c.lastTestName
+	}
+	if stream := c.streams[streamID]; stream != nil {
+		return stream.builder.trace.TestName
+	}
+	return ""
+}
+
+func (c *tracingHTTP2Conn) handleFrameLocked(frame http2.Frame, isRequest bool) {
+	if c.recordFrames {
+		// We record the frame before handling it, since handling
+		// it could complete the trace.
+		c.recordFrameLocked(frame, isRequest)
+	}
 	switch frame := frame.(type) {
 	case *http2.MetaHeadersFrame:
 		stream, isNew := c.getStreamLocked(frame, isRequest)
@@ -152,16 +224,20 @@ func (c *tracingHTTP2Conn) handleFrame(frame http2.Frame, isRequest bool) {
 		}
 		switch {
 		case isNew:
-			// request headers, which resulted in a new stream; nothing else to do
+			// request headers, which resulted in a new stream; the
+			// frame could not be recorded until the stream existed
+			if c.recordFrames {
+				stream.builder.addFrame(newFrame(frame, isRequest))
+			}
 		case !isRequest && !stream.gotResponse:
 			// response headers
 			c.receiveResponseLocked(stream, frame)
 		case isRequest:
 			// request trailers
-			stream.builder.trace.Request.Trailer = makeHeaders(frame)
+			stream.builder.trace.Request.Trailer = makeHeaders(frame.Fields)
 		default:
 			// response trailers
-			stream.builder.trace.Response.Trailer = makeHeaders(frame)
+			stream.builder.trace.Response.Trailer = makeHeaders(frame.Fields)
 		}
 		if frame.StreamEnded() {
 			c.closeStreamLocked(frame.StreamID, stream, isRequest, nil)
@@ -194,11 +270,29 @@ func (c *tracingHTTP2Conn) handleFrame(frame http2.Frame, isRequest bool) {
 	}
 }
 
+// recordFrameLocked adds the given frame to the trace for its stream. If
+// it is a connection-level frame, it is added to the traces of all active
+// streams.
+func (c *tracingHTTP2Conn) recordFrameLocked(frame http2.Frame, isRequest bool) {
+	streamID := frame.Header().StreamID
+	if streamID != 0 {
+		if stream := c.streams[streamID]; stream != nil {
+			stream.builder.addFrame(newFrame(frame, isRequest))
+		}
+		return
+	}
+	for _, stream := range c.streams {
+		stream.builder.addFrame(newFrame(frame, isRequest))
+	}
+}
+
 func (c *tracingHTTP2Conn) receiveResponseLocked(stream *http2Stream, frame *http2.MetaHeadersFrame) {
 	stream.gotResponse = true
-	resp := makeResponse(frame) //nolint:bodyclose // there is no body to close on this response
+	resp := makeResponse(frame.Fields, 2) //nolint:bodyclose // there is no body to close on this response
 	stream.builder.add(&ResponseStart{Response: resp})
-	stream.responseTracer.isStreamProtocol, stream.responseTracer.decompressor = propertiesFromHeaders(resp.Header)
+	stream.responseTracer.isStreamProtocol, stream.responseTracer.decompressor, stream.responseTracer.textDecoder =
+		propertiesFromHeaders(resp.Header)
+	stream.responseTracer.decoder = newMessageDecoder(stream.builder.procedure, false, resp.StatusCode, resp.Header)
 	stream.responseTracer.builder = stream.builder
 }
 
@@ -218,14 +312,22 @@ func (c *tracingHTTP2Conn) getStreamLocked(frame *http2.MetaHeadersFrame, create
 }
 
 func (c *tracingHTTP2Conn) newStreamLocked(frame *http2.MetaHeadersFrame) *http2Stream {
-	req := makeRequest(frame)
+	req := makeRequest(frame.Fields, 2)
 	builder, _ := newBuilder(req, !c.isServer, c.collector)
-	isStream, decompressor := propertiesFromHeaders(req.Header)
+	isStream, decompressor, textDecoder := propertiesFromHeaders(req.Header)
 	stream := &http2Stream{
-		builder:       builder,
-		requestTracer: dataTracer{isRequest: true, isStreamProtocol: isStream, decompressor: decompressor, builder: builder},
+		builder: builder,
+		requestTracer: dataTracer{
+			isRequest:        true,
+			isStreamProtocol: isStream,
+			decompressor:     decompressor,
+			textDecoder:      textDecoder,
+			decoder:          newMessageDecoder(builder.procedure, true, 0, req.Header),
+			builder:          builder,
+		},
 	}
 	c.collector.newAttempt(builder.trace.TestName)
+	c.lastTestName = builder.trace.TestName
 	if c.streams == nil {
 		c.streams = map[uint32]*http2Stream{}
 	}
@@ -401,6 +503,7 @@ func (h *http2FrameTracer) emitFrame() bool {
 	frame, err := framer.ReadFrame()
 	if err != nil {
 		h.broken = true
+		h.c.handleFrameFailed(h.header)
 		return false
 	}
 	h.c.handleFrame(frame, h.isRequest)
@@ -504,8 +607,8 @@ func (h *http2RetryCollector) cancel() {
 	}
 }
 
-func makeRequest(frame *http2.MetaHeadersFrame) *http.Request {
-	path := getPseudoHeader(frame, ":path")
+func makeRequest(fields []hpack.HeaderField, protoMajor int) *http.Request {
+	path := getPseudoHeader(fields, ":path")
 	var query string
 	var forceQuery bool
 	if strings.Contains(path, "?") {
@@ -515,24 +618,24 @@ func makeRequest(frame *http2.MetaHeadersFrame) *http.Request {
 		forceQuery = query == ""
 	}
 	req := &http.Request{
-		Proto:      "HTTP/2.0",
-		ProtoMajor: 2,
+		Proto:      fmt.Sprintf("HTTP/%d.0", protoMajor),
+		ProtoMajor: protoMajor,
 		ProtoMinor: 0,
 		URL: &url.URL{
-			Scheme:     getPseudoHeader(frame, ":scheme"),
-			Host:       getPseudoHeader(frame, ":authority"),
+			Scheme:     getPseudoHeader(fields, ":scheme"),
+			Host:       getPseudoHeader(fields, ":authority"),
 			Path:       path,
 			RawQuery:   query,
 			ForceQuery: forceQuery,
 		},
-		Method: getPseudoHeader(frame, ":method"),
-		Header: makeHeaders(frame),
+		Method: getPseudoHeader(fields, ":method"),
+		Header: makeHeaders(fields),
 	}
 	return req
 }
 
-func makeResponse(frame *http2.MetaHeadersFrame) *http.Response {
-	status := getPseudoHeader(frame, ":status")
+func makeResponse(fields []hpack.HeaderField, protoMajor int) *http.Response {
+	status := getPseudoHeader(fields, ":status")
 	var statusInt int
 	if status == "" {
 		statusInt = 500
@@ -544,28 +647,89 @@ func makeResponse(frame *http2.MetaHeadersFrame) *http.Response {
 		}
 	}
 	return &http.Response{
-		Proto:      "HTTP/2.0",
-		ProtoMajor: 2,
+		Proto:      fmt.Sprintf("HTTP/%d.0", protoMajor),
+		ProtoMajor: protoMajor,
 		ProtoMinor: 0,
 		StatusCode: statusInt,
 		Status:     fmt.Sprintf("%d %s", statusInt, http.StatusText(statusInt)),
-		Header:     makeHeaders(frame),
+		Header:     makeHeaders(fields),
+	}
+}
+
+// newFrame creates a Frame that describes the given HTTP/2 frame.
+func newFrame(frame http2.Frame, fromClient bool) *Frame {
+	hdr := frame.Header()
+	var details []string
+	if flags := frameFlags(hdr); len(flags) > 0 {
+		details = append(details, "flags="+strings.Join(flags, "|"))
+	}
+	switch frame := frame.(type) {
+	case *http2.SettingsFrame:
+		_ = frame.ForeachSetting(func(setting http2.Setting) error {
+			details = append(details, fmt.Sprintf("%v=%d", setting.ID, setting.Val))
+			return nil
+		})
+	case *http2.WindowUpdateFrame:
+		details = append(details, fmt.Sprintf("increment=%d", frame.Increment))
+	case *http2.RSTStreamFrame:
+		details = append(details, fmt.Sprintf("code=%v", frame.ErrCode))
+	case *http2.PingFrame:
+		details = append(details, fmt.Sprintf("data=%x", frame.Data))
+	case *http2.GoAwayFrame:
+		details = append(details, fmt.Sprintf("last_stream=%d", frame.LastStreamID), fmt.Sprintf("code=%v", frame.ErrCode))
+		if debugData := frame.DebugData(); len(debugData) > 0 {
+			details = append(details, fmt.Sprintf("debug=%q", debugData))
+		}
+	case *http2.PriorityFrame:
+		details = append(details, fmt.Sprintf("depends_on=%d", frame.StreamDep), fmt.Sprintf("weight=%d", frame.Weight))
+	}
+	return &Frame{
+		FromClient: fromClient,
+		StreamID:   hdr.StreamID,
+		Type:       hdr.Type.String(),
+		Length:     hdr.Length,
+		Summary:    strings.Join(details, " "),
+	}
+}
+
+// frameFlags returns the names of the flags set in the given frame header.
+func frameFlags(hdr http2.FrameHeader) []string {
+	var flags []string
+	switch hdr.Type {
+	case http2.FrameSettings, http2.FramePing:
+		if hdr.Flags.Has(http2.FlagSettingsAck) {
+			flags = append(flags, "ACK")
+		}
+	case http2.FrameData, http2.FrameHeaders:
+		if hdr.Flags.Has(http2.FlagDataEndStream) {
+			flags = append(flags, "END_STREAM")
+		}
+		if hdr.Type == http2.FrameHeaders && hdr.Flags.Has(http2.FlagHeadersEndHeaders) {
+			flags = append(flags, "END_HEADERS")
+		}
+		if hdr.Flags.Has(http2.FlagDataPadded) {
+			flags = append(flags, "PADDED")
+		}
+		if hdr.Type == http2.FrameHeaders && hdr.Flags.Has(http2.FlagHeadersPriority) {
+			flags = append(flags, "PRIORITY")
+		}
 	}
+	return flags
 }
 
-func makeHeaders(frame *http2.MetaHeadersFrame) http.Header {
-	headers := make(http.Header, len(frame.Fields))
-	for _, hdr := range frame.Fields {
+func makeHeaders(fields []hpack.HeaderField) http.Header {
+	headers := make(http.Header, len(fields))
+	for _, hdr := range fields {
 		if strings.HasPrefix(hdr.Name, ":") {
-			continue // http/2 pseudo-header
+			continue // pseudo-header
 		}
 		headers.Add(hdr.Name, hdr.Value)
 	}
 	return headers
 }
 
-func getPseudoHeader(frame *http2.MetaHeadersFrame, headerName string) string {
-	for _, hdr := range frame.Fields {
+func getPseudoHeader(fields []hpack.HeaderField, headerName string) string {
+	for _, hdr := range fields {
 		if hdr.Name == headerName {
 			return hdr.Value
 		}
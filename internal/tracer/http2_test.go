@@ -0,0 +1,140 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func TestTracingHTTP2Frames(t *testing.T) {
+	t.Parallel()
+	clientTracer := Tracer{RecordFrames: true}
+	var serverTracer Tracer
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &http.Server{
+		Handler: h2c.NewHandler(http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+			respWriter.Header().Set("Content-Type", "application/grpc")
+			respWriter.WriteHeader(http.StatusOK)
+			respWriter.(http.Flusher).Flush()
+			_, _ = io.Copy(io.Discard, req.Body)
+			_, _ = respWriter.Write([]byte{0, 0, 0, 0, 3, 'a', 'b', 'c'})
+		}), &http2.Server{}),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		_ = server.Serve(TracingHTTP2Listener(listener, &serverTracer))
+	}()
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	clientConn, err := (&http2.Transport{AllowHTTP: true}).NewClientConn(TracingHTTP2Conn(conn, false, &clientTracer))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = clientConn.Close()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	clientTracer.Init(t.Name())
+	serverTracer.Init(t.Name())
+	bodyReader, bodyWriter := io.Pipe()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+listener.Addr().String()+"/foo", bodyReader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set(testCaseNameHeader, t.Name())
+	resp, err := clientConn.RoundTrip(req)
+	require.NoError(t, err)
+	// A ping while the stream is active is a connection-level
+	// frame that should be included in the trace.
+	require.NoError(t, clientConn.Ping(ctx))
+	_, err = bodyWriter.Write([]byte{0, 0, 0, 0, 2, 'h', 'i'})
+	require.NoError(t, err)
+	require.NoError(t, bodyWriter.Close())
+	_, err = io.Copy(io.Discard, resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	clientTrace, err := clientTracer.Await(ctx, t.Name())
+	require.NoError(t, err)
+	serverTrace, err := serverTracer.Await(ctx, t.Name())
+	require.NoError(t, err)
+	assert.Empty(t, serverTrace.Frames)
+
+	// Connection-level frames that set up the connection, like SETTINGS
+	// and WINDOW_UPDATE, may or may not arrive while the stream is active,
+	// so we only check stream frames and pings.
+	require.NotEmpty(t, clientTrace.Frames)
+	streamID := clientTrace.Frames[0].StreamID
+	var summaries []string
+	for i, frame := range clientTrace.Frames {
+		if i > 0 {
+			assert.GreaterOrEqual(t, frame.Offset, clientTrace.Frames[i-1].Offset)
+		}
+		if frame.StreamID == 0 && frame.Type != "PING" {
+			continue
+		}
+		if frame.Type != "PING" {
+			assert.Equal(t, streamID, frame.StreamID)
+		}
+		direction := "<"
+		if frame.FromClient {
+			direction = ">"
+		}
+		summaries = append(summaries, strings.TrimSpace(direction+" "+frame.Type+" "+frame.Summary))
+	}
+	ping := pingData(clientTrace.Frames)
+	assert.Equal(t, []string{
+		"> HEADERS flags=END_HEADERS",
+		"< HEADERS flags=END_HEADERS",
+		"> PING data=" + ping,
+		"< PING flags=ACK data=" + ping,
+		"> DATA",
+		"> DATA flags=END_STREAM",
+		"< DATA flags=END_STREAM",
+	}, summaries)
+
+	var buf bytes.Buffer
+	clientTrace.Print(internal.NewPrinter(&buf))
+	assert.Contains(t, buf.String(), " request>")
+	assert.Contains(t, buf.String(), "frame: PING stream=0 len=8")
+	assert.Contains(t, buf.String(), fmt.Sprintf("frame: HEADERS stream=%d", streamID))
+}
+
+func pingData(frames []*Frame) string {
+	for _, frame := range frames {
+		if frame.Type == "PING" {
+			return strings.TrimPrefix(frame.Summary, "data=")
+		}
+	}
+	return ""
+}
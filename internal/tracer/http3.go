@@ -0,0 +1,489 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/quic-go/qpack"
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/quic-go/quic-go/quicvarint"
+	"golang.org/x/net/http2/hpack"
+)
+
+const (
+	http3FrameTypeData    = 0x00
+	http3FrameTypeHeaders = 0x01
+
+	// A frame header is two variable-length integers (the
+	// frame type and length), each at most eight bytes.
+	maxHTTP3FrameHeaderLen = 16
+)
+
+// TracingHTTP3Conn applies tracing to the given QUIC connection, which uses
+// the HTTP/3 protocol. Each bidirectional stream on the connection carries
+// a single HTTP operation, whose frames are traced. Header fields are
+// decoded using QPACK, but only with the static table. That is sufficient
+// for peers that never use the dynamic table, like quic-go. If a header
+// block can't be decoded, the rest of the stream is not traced. If the
+// operation's trace had already started, it is ended with a ResponseError
+// that explains why it is incomplete. Otherwise, on the server, the operation
+// can be traced instead by TracingHTTP3FallbackHandler.
+//
+// If isServer is true, this is a server connection, so requests are read
+// and responses are written. Otherwise, this is a client connection, and
+// requests are written and responses are read.
+func TracingHTTP3Conn(conn quic.EarlyConnection, isServer bool, collector Collector) quic.EarlyConnection {
+	tracer := &tracingHTTP3Conn{
+		EarlyConnection: conn,
+		isServer:        isServer,
+		collector:       collector,
+	}
+	go func() {
+		<-conn.Context().Done()
+		err := context.Cause(conn.Context())
+		if err == nil || errors.Is(err, context.Canceled) {
+			err = errors.New("connection closed")
+		}
+		tracer.cancelAll(err)
+	}()
+	return tracer
+}
+
+// TracingHTTP3Listener applies tracing to the given listener, which
+// accepts QUIC connections that use the HTTP/3 protocol.
+//
+// This function calls TracingHTTP3Conn for each connection accepted. All
+// connections accepted are considered server connections.
+func TracingHTTP3Listener(listener http3.QUICEarlyListener, collector Collector) http3.QUICEarlyListener {
+	return &tracingHTTP3Listener{QUICEarlyListener: listener, collector: collector}
+}
+
+// HTTP3ConnContext records the given connection in the given context, so that
+// TracingHTTP3FallbackHandler can find it. It is meant to be used as the
+// ConnContext of an http3.Server that uses TracingHTTP3Listener.
+func HTTP3ConnContext(ctx context.Context, conn quic.Connection) context.Context {
+	traced, ok := conn.(*tracingHTTP3Conn)
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, http3ConnKey{}, traced)
+}
+
+// TracingHTTP3FallbackHandler applies TracingHandler to requests that are not
+// already being traced by the connection on which they were received, such as
+// when the request's header block can't be decoded. The connection must have
+// been recorded in the request context by HTTP3ConnContext. Requests that
+// did not arrive via TracingHTTP3Listener are not traced.
+func TracingHTTP3FallbackHandler(handler http.Handler, collector Collector) http.Handler {
+	tracingHandler := TracingHandler(handler, collector)
+	return http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+		conn, ok := req.Context().Value(http3ConnKey{}).(*tracingHTTP3Conn)
+		if ok && !conn.isTracing(req.Header.Get(testCaseNameHeader)) {
+			tracingHandler.ServeHTTP(respWriter, req)
+			return
+		}
+		handler.ServeHTTP(respWriter, req)
+	})
+}
+
+type http3ConnKey struct{}
+
+type tracingHTTP3Listener struct {
+	http3.QUICEarlyListener
+	collector Collector
+}
+
+func (t *tracingHTTP3Listener) Accept(ctx context.Context) (quic.EarlyConnection, error) {
+	conn, err := t.QUICEarlyListener.Accept(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return TracingHTTP3Conn(conn, true, t.collector), nil
+}
+
+type tracingHTTP3Conn struct {
+	quic.EarlyConnection
+	isServer  bool
+	collector Collector
+
+	mu      sync.Mutex
+	streams map[quic.StreamID]*tracingHTTP3Stream
+}
+
+func (c *tracingHTTP3Conn) AcceptStream(ctx context.Context) (quic.Stream, error) {
+	stream, err := c.EarlyConnection.AcceptStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.newStream(stream), nil
+}
+
+func (c *tracingHTTP3Conn) OpenStream() (quic.Stream, error) {
+	stream, err := c.EarlyConnection.OpenStream()
+	if err != nil {
+		return nil, err
+	}
+	return c.newStream(stream), nil
+}
+
+func (c *tracingHTTP3Conn) OpenStreamSync(ctx context.Context) (quic.Stream, error) {
+	stream, err := c.EarlyConnection.OpenStreamSync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.newStream(stream), nil
+}
+
+func (c *tracingHTTP3Conn) newStream(stream quic.Stream) *tracingHTTP3Stream {
+	traced := &tracingHTTP3Stream{Stream: stream, conn: c}
+	traced.readTracer = http3FrameTracer{
+		stream:    traced,
+		isRequest: c.isServer,
+		decoder:   qpack.NewDecoder(nil),
+	}
+	traced.writeTracer = http3FrameTracer{
+		stream:    traced,
+		isRequest: !c.isServer,
+		decoder:   qpack.NewDecoder(nil),
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.streams == nil {
+		c.streams = map[quic.StreamID]*tracingHTTP3Stream{}
+	}
+	c.streams[stream.StreamID()] = traced
+	return traced
+}
+
+// isTracing returns true if a stream on this connection is being traced
+// for the given test case.
+func (c *tracingHTTP3Conn) isTracing(testName string) bool {
+	c.mu.Lock()
+	streams := make([]*tracingHTTP3Stream, 0, len(c.streams))
+	for _, stream := range c.streams {
+		streams = append(streams, stream)
+	}
+	c.mu.Unlock()
+	for _, stream := range streams {
+		stream.mu.Lock()
+		tracing := stream.builder != nil && stream.testName == testName
+		stream.mu.Unlock()
+		if tracing {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *tracingHTTP3Conn) removeStream(streamID quic.StreamID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.streams, streamID)
+}
+
+func (c *tracingHTTP3Conn) cancelAll(err error) {
+	c.mu.Lock()
+	streams := make([]*tracingHTTP3Stream, 0, len(c.streams))
+	for _, stream := range c.streams {
+		streams = append(streams, stream)
+	}
+	c.mu.Unlock()
+	for _, stream := range streams {
+		stream.closeDirection(true, err)
+		stream.closeDirection(false, err)
+	}
+}
+
+type tracingHTTP3Stream struct {
+	quic.Stream
+	conn        *tracingHTTP3Conn
+	readTracer  http3FrameTracer
+	writeTracer http3FrameTracer
+
+	mu             sync.Mutex
+	builder        *builder
+	testName       string
+	requestTracer  dataTracer
+	requestDone    bool
+	gotResponse    bool
+	responseTracer dataTracer
+	responseDone   bool
+}
+
+func (s *tracingHTTP3Stream) Read(data []byte) (n int, err error) {
+	n, err = s.Stream.Read(data)
+	s.readTracer.trace(data[:n])
+	if err != nil {
+		var streamErr error
+		if !errors.Is(err, io.EOF) {
+			streamErr = err
+		}
+		s.closeDirection(s.readTracer.isRequest, streamErr)
+	}
+	return n, err
+}
+
+func (s *tracingHTTP3Stream) Write(data []byte) (n int, err error) {
+	// Like with HTTP/2, we trace the data before actually writing it,
+	// so the peer can't reply before we've finished tracing this data.
+	s.writeTracer.trace(data)
+	n, err = s.Stream.Write(data)
+	if err != nil {
+		s.closeDirection(s.writeTracer.isRequest, err)
+	}
+	return n, err
+}
+
+func (s *tracingHTTP3Stream) Close() error {
+	s.closeDirection(s.writeTracer.isRequest, nil)
+	return s.Stream.Close()
+}
+
+func (s *tracingHTTP3Stream) CancelRead(code quic.StreamErrorCode) {
+	s.closeDirection(s.readTracer.isRequest, &quic.StreamError{
+		StreamID:  s.StreamID(),
+		ErrorCode: code,
+	})
+	s.Stream.CancelRead(code)
+}
+
+func (s *tracingHTTP3Stream) CancelWrite(code quic.StreamErrorCode) {
+	s.closeDirection(s.writeTracer.isRequest, &quic.StreamError{
+		StreamID:  s.StreamID(),
+		ErrorCode: code,
+	})
+	s.Stream.CancelWrite(code)
+}
+
+func (s *tracingHTTP3Stream) handleHeaders(fields []hpack.HeaderField, isRequest bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case isRequest && s.builder == nil:
+		// request headers
+		req := makeRequest(fields, 3)
+		s.builder, _ = newBuilder(req, !s.conn.isServer, s.conn.collector)
+		s.testName = req.Header.Get(testCaseNameHeader)
+		isStream, decompressor, textDecoder := propertiesFromHeaders(req.Header)
+		s.requestTracer = dataTracer{
+			isRequest:        true,
+			isStreamProtocol: isStream,
+			decompressor:     decompressor,
+			textDecoder:      textDecoder,
+			decoder:          newMessageDecoder(s.builder.procedure, true, 0, req.Header),
+			builder:          s.builder,
+		}
+	case s.builder == nil:
+		// response without a request? ignore
+	case isRequest:
+		// request trailers
+		s.builder.setTrailers(true, makeHeaders(fields))
+	case !s.gotResponse:
+		resp := makeResponse(fields, 3) //nolint:bodyclose // there is no body to close on this response
+		if resp.StatusCode < 200 {
+			// ignore informational responses
+			return
+		}
+		s.gotResponse = true
+		s.builder.add(&ResponseStart{Response: resp})
+		isStream, decompressor, textDecoder := propertiesFromHeaders(resp.Header)
+		s.responseTracer = dataTracer{
+			isRequest:        false,
+			isStreamProtocol: isStream,
+			decompressor:     decompressor,
+			textDecoder:      textDecoder,
+			decoder:          newMessageDecoder(s.builder.procedure, false, resp.StatusCode, resp.Header),
+			builder:          s.builder,
+		}
+	default:
+		// response trailers
+		s.builder.setTrailers(false, makeHeaders(fields))
+	}
+}
+
+func (s *tracingHTTP3Stream) handleData(data []byte, isRequest bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case s.builder == nil:
+		// no request headers, so nothing to trace
+	case isRequest:
+		s.requestTracer.trace(data)
+	case s.gotResponse:
+		s.responseTracer.trace(data)
+	}
+}
+
+// handleUndecodableHeaders ends the trace, if one was started, when a header
+// block can't be decoded, since the rest of the stream can't be traced.
+func (s *tracingHTTP3Stream) handleUndecodableHeaders(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.builder == nil {
+		return
+	}
+	s.requestTracer.emitUnfinished()
+	if s.gotResponse {
+		s.responseTracer.emitUnfinished()
+	}
+	s.builder.add(&ResponseError{Err: fmt.Errorf("trace is incomplete: could not decode HTTP/3 header block, "+
+		"which may use the QPACK dynamic table, which is not supported: %w", err)})
+}
+
+// closeDirection records the end of the request or response, which happens
+// when the stream is closed or reset in that direction. A nil error means
+// the stream was closed normally.
+func (s *tracingHTTP3Stream) closeDirection(isRequest bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if isRequest {
+		if s.requestDone {
+			return
+		}
+		s.requestDone = true
+	} else {
+		if s.responseDone {
+			return
+		}
+		s.responseDone = true
+	}
+	if s.requestDone && s.responseDone {
+		s.conn.removeStream(s.StreamID())
+	}
+	if s.builder == nil {
+		return // never saw request headers
+	}
+	var streamErr *quic.StreamError
+	if errors.As(err, &streamErr) {
+		// Include the name of the HTTP/3 error code.
+		err = fmt.Errorf("%w (%v)", err, http3.ErrCode(streamErr.ErrorCode))
+	}
+	switch {
+	case isRequest:
+		s.requestTracer.emitUnfinished()
+		s.builder.add(&RequestBodyEnd{Err: err})
+	case s.gotResponse:
+		s.requestTracer.emitUnfinished()
+		s.responseTracer.emitUnfinished()
+		s.builder.add(&ResponseBodyEnd{Err: err})
+	default:
+		if err == nil {
+			err = errors.New("stream closed before response headers")
+		}
+		s.requestTracer.emitUnfinished()
+		s.builder.add(&ResponseError{Err: err})
+	}
+}
+
+type http3FrameTracer struct {
+	stream    *tracingHTTP3Stream
+	isRequest bool
+	decoder   *qpack.Decoder
+
+	broken    bool
+	prefix    []byte
+	inFrame   bool
+	frameType uint64
+	expecting uint64
+	actual    uint64
+	frame     bytes.Buffer
+}
+
+func (h *http3FrameTracer) trace(data []byte) {
+	for len(data) > 0 && !h.broken {
+		if !h.inFrame {
+			// still reading frame header
+			data = data[h.traceHeader(data):]
+			continue
+		}
+		data = data[h.traceFrame(data):]
+	}
+}
+
+// traceHeader reads the frame type and length from the given data and
+// returns the number of bytes consumed.
+func (h *http3FrameTracer) traceHeader(data []byte) int {
+	start := len(h.prefix)
+	h.prefix = append(h.prefix, data[:min(len(data), maxHTTP3FrameHeaderLen-start)]...)
+	frameType, typeLen, err := quicvarint.Parse(h.prefix)
+	if err != nil {
+		// need to read more data to finish header
+		return len(h.prefix) - start
+	}
+	length, lengthLen, err := quicvarint.Parse(h.prefix[typeLen:])
+	if err != nil {
+		// need to read more data to finish header
+		return len(h.prefix) - start
+	}
+	consumed := typeLen + lengthLen - start
+	h.prefix = h.prefix[:0]
+	h.frameType = frameType
+	h.expecting = length
+	h.actual = 0
+	h.inFrame = true
+	if length == 0 {
+		h.endFrame()
+	}
+	return consumed
+}
+
+// traceFrame reads frame contents from the given data and returns
+// the number of bytes consumed.
+func (h *http3FrameTracer) traceFrame(data []byte) int {
+	need := h.expecting - h.actual
+	if uint64(len(data)) < need {
+		need = uint64(len(data))
+	}
+	chunk := data[:need]
+	h.actual += need
+	switch h.frameType {
+	case http3FrameTypeData:
+		h.stream.handleData(chunk, h.isRequest)
+	case http3FrameTypeHeaders:
+		h.frame.Write(chunk)
+	}
+	if h.actual == h.expecting {
+		h.endFrame()
+	}
+	return int(need)
+}
+
+func (h *http3FrameTracer) endFrame() {
+	h.inFrame = false
+	if h.frameType != http3FrameTypeHeaders {
+		return
+	}
+	defer h.frame.Reset()
+	qpackFields, err := h.decoder.DecodeFull(h.frame.Bytes())
+	if err != nil {
+		// Probably uses the dynamic table, which we don't support.
+		h.broken = true
+		h.stream.handleUndecodableHeaders(err)
+		return
+	}
+	fields := make([]hpack.HeaderField, len(qpackFields))
+	for i, field := range qpackFields {
+		fields[i] = hpack.HeaderField{Name: field.Name, Value: field.Value}
+	}
+	h.stream.handleHeaders(fields, h.isRequest)
+}
@@ -0,0 +1,245 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracingHTTP3(t *testing.T) {
+	t.Parallel()
+	certBytes, keyBytes, err := internal.NewServerCert()
+	require.NoError(t, err)
+	cert, err := internal.ParseServerCert(certBytes, keyBytes)
+	require.NoError(t, err)
+	serverTLSConf, err := internal.NewServerTLSConfig(cert, tls.NoClientCert, nil)
+	require.NoError(t, err)
+	serverTLSConf = http3.ConfigureTLSConfig(serverTLSConf)
+	clientTLSConf, err := internal.NewClientTLSConfig(certBytes, nil, nil)
+	require.NoError(t, err)
+
+	var clientTracer, serverTracer Tracer
+	listener, err := quic.ListenAddrEarly("127.0.0.1:0", serverTLSConf, nil)
+	require.NoError(t, err)
+	server := &http3.Server{
+		Handler: http.HandlerFunc(func(respWriter http.ResponseWriter, req *http.Request) {
+			_, _ = io.Copy(io.Discard, req.Body)
+			respWriter.Header().Set("Content-Type", "application/grpc")
+			if req.URL.Path == "/reset" {
+				respWriter.WriteHeader(http.StatusOK)
+				_, _ = respWriter.Write([]byte{0, 0, 0, 0, 3})
+				respWriter.(http.Flusher).Flush()
+				panic(http.ErrAbortHandler)
+			}
+			_, _ = respWriter.Write([]byte{0, 0, 0, 0, 3, 'a', 'b', 'c'})
+		}),
+	}
+	go func() {
+		_ = server.ServeListener(TracingHTTP3Listener(listener, &serverTracer))
+	}()
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+	transport := &http3.RoundTripper{
+		TLSClientConfig: clientTLSConf,
+		Dial: func(ctx context.Context, addr string, tlsCfg *tls.Config, cfg *quic.Config) (quic.EarlyConnection, error) {
+			conn, err := quic.DialAddrEarly(ctx, addr, tlsCfg, cfg)
+			if err != nil {
+				return nil, err
+			}
+			return TracingHTTP3Conn(conn, false, &clientTracer), nil
+		},
+	}
+	t.Cleanup(func() {
+		_ = transport.Close()
+	})
+
+	sendRequest := func(t *testing.T, path string) {
+		t.Helper()
+		clientTracer.Init(t.Name())
+		serverTracer.Init(t.Name())
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+listener.Addr().String()+path, bytes.NewReader([]byte{0, 0, 0, 0, 2, 'h', 'i'}))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/grpc")
+		req.Header.Set(testCaseNameHeader, t.Name())
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			// The stream may be reset before the response headers are read.
+			return
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+	await := func(t *testing.T, tracer *Tracer) *Trace {
+		t.Helper()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		trace, err := tracer.Await(ctx, t.Name())
+		require.NoError(t, err)
+		return trace
+	}
+
+	t.Run("success", func(t *testing.T) { //nolint:paralleltest // uses shared client and server
+		sendRequest(t, "/success")
+		for _, trace := range []*Trace{await(t, &clientTracer), await(t, &serverTracer)} {
+			require.NoError(t, trace.Err)
+			assert.Equal(t, "HTTP/3.0", trace.Request.Proto)
+			assert.Equal(t, &url.URL{Scheme: "https", Host: listener.Addr().String(), Path: "/success"}, trace.Request.URL)
+			assert.Equal(t, "application/grpc", trace.Request.Header.Get("Content-Type"))
+			assert.Equal(t, t.Name(), trace.Request.Header.Get(testCaseNameHeader))
+			requestStart, ok := trace.Events[0].(*RequestStart)
+			require.True(t, ok)
+			assert.Equal(t, "application/grpc", requestStart.getHeaders().Get("Content-Type"))
+			require.NotNil(t, trace.Response)
+			assert.Equal(t, http.StatusOK, trace.Response.StatusCode)
+			assert.Equal(t, "application/grpc", trace.Response.Header.Get("Content-Type"))
+			assert.Equal(t,
+				"*tracer.RequestStart,*tracer.RequestBodyData,*tracer.RequestBodyEnd,*tracer.ResponseStart,"+
+					"*tracer.ResponseBodyData,*tracer.ResponseBodyEnd",
+				eventTypes(trace.Events))
+		}
+	})
+	t.Run("reset", func(t *testing.T) { //nolint:paralleltest // uses shared client and server
+		sendRequest(t, "/reset")
+		for _, trace := range []*Trace{await(t, &clientTracer), await(t, &serverTracer)} {
+			var streamErr *quic.StreamError
+			require.ErrorAs(t, trace.Err, &streamErr)
+			assert.Equal(t, quic.StreamErrorCode(http3.ErrCodeInternalError), streamErr.ErrorCode)
+		}
+	})
+}
+
+func TestTracingHTTP3UndecodableHeaders(t *testing.T) {
+	t.Parallel()
+	certBytes, keyBytes, err := internal.NewServerCert()
+	require.NoError(t, err)
+	cert, err := internal.ParseServerCert(certBytes, keyBytes)
+	require.NoError(t, err)
+	serverTLSConf, err := internal.NewServerTLSConfig(cert, tls.NoClientCert, nil)
+	require.NoError(t, err)
+	serverTLSConf = http3.ConfigureTLSConfig(serverTLSConf)
+	clientTLSConf, err := internal.NewClientTLSConfig(certBytes, nil, nil)
+	require.NoError(t, err)
+
+	// This server responds with a header block that refers to the QPACK
+	// dynamic table, which the tracer can't decode.
+	listener, err := quic.ListenAddrEarly("127.0.0.1:0", serverTLSConf, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+	go func() {
+		conn, err := listener.Accept(context.Background())
+		if err != nil {
+			return
+		}
+		stream, err := conn.AcceptStream(context.Background())
+		if err != nil {
+			return
+		}
+		_, _ = stream.Write([]byte{http3FrameTypeHeaders, 3, 0x00, 0x00, 0x80})
+		_ = stream.Close()
+	}()
+
+	var clientTracer Tracer
+	clientTracer.Init(t.Name())
+	transport := &http3.RoundTripper{
+		TLSClientConfig: clientTLSConf,
+		Dial: func(ctx context.Context, addr string, tlsCfg *tls.Config, cfg *quic.Config) (quic.EarlyConnection, error) {
+			conn, err := quic.DialAddrEarly(ctx, addr, tlsCfg, cfg)
+			if err != nil {
+				return nil, err
+			}
+			return TracingHTTP3Conn(conn, false, &clientTracer), nil
+		},
+	}
+	t.Cleanup(func() {
+		_ = transport.Close()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+listener.Addr().String()+"/undecodable", bytes.NewReader([]byte{0, 0, 0, 0, 2, 'h', 'i'}))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set(testCaseNameHeader, t.Name())
+	resp, err := transport.RoundTrip(req)
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+
+	trace, err := clientTracer.Await(ctx, t.Name())
+	require.NoError(t, err)
+	require.ErrorContains(t, trace.Err, "trace is incomplete: could not decode HTTP/3 header block")
+	assert.IsType(t, &ResponseError{}, trace.Events[len(trace.Events)-1])
+}
+
+func TestTracingHTTP3FallbackHandler(t *testing.T) {
+	t.Parallel()
+	var collector Tracer
+	conn := &tracingHTTP3Conn{isServer: true, collector: &collector}
+	// A stream on the connection is already tracing the first test case.
+	tracedStream := &tracingHTTP3Stream{conn: conn, builder: &builder{}, testName: t.Name() + "/traced"}
+	conn.streams = map[quic.StreamID]*tracingHTTP3Stream{0: tracedStream}
+	handler := TracingHTTP3FallbackHandler(http.HandlerFunc(func(respWriter http.ResponseWriter, _ *http.Request) {
+		respWriter.WriteHeader(http.StatusOK)
+	}), &collector)
+
+	for _, testCase := range []struct {
+		name        string
+		withConn    bool
+		expectTrace bool
+	}{
+		{name: "traced", withConn: true, expectTrace: false},
+		{name: "untraced", withConn: true, expectTrace: true},
+		{name: "no-conn", withConn: false, expectTrace: false},
+	} {
+		testName := t.Name() + "/" + testCase.name
+		collector.Init(testName)
+		ctx := context.Background()
+		if testCase.withConn {
+			ctx = HTTP3ConnContext(ctx, conn)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "/test", http.NoBody)
+		require.NoError(t, err)
+		req.Header.Set(testCaseNameHeader, testName)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		awaitCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		trace, err := collector.Await(awaitCtx, testName)
+		cancel()
+		if testCase.expectTrace {
+			require.NoError(t, err, testCase.name)
+			assert.Equal(t, testName, trace.TestName)
+		} else {
+			require.Error(t, err, testCase.name)
+		}
+	}
+}
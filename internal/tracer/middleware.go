@@ -43,7 +43,7 @@ func TracingRoundTripper(transport http.RoundTripper, collector Collector) http.
 			return nil, err
 		}
 		builder.add(&ResponseStart{Response: resp})
-		resp.Body = newReader(resp.Header, resp.Body, false, builder, cancel)
+		resp.Body = newReader(resp.Header, resp.StatusCode, resp.Body, false, builder, cancel)
 		return resp, nil
 	})
 }
@@ -125,11 +125,13 @@ func (t *tracingResponseWriter) WriteHeader(statusCode int) {
 	}
 	t.started = true
 	t.respWriter.WriteHeader(statusCode)
-	isStreamProtocol, decompressor := propertiesFromHeaders(t.Header())
+	isStreamProtocol, decompressor, textDecoder := propertiesFromHeaders(t.Header())
 	t.dataTracer = dataTracer{
 		isRequest:        false,
 		isStreamProtocol: isStreamProtocol,
 		decompressor:     decompressor,
+		textDecoder:      textDecoder,
+		decoder:          newMessageDecoder(t.builder.procedure, false, statusCode, t.Header()),
 		builder:          t.builder,
 	}
 	contentLenStr := t.Header().Get("Content-Length")
@@ -0,0 +1,330 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	pcapngSectionHeader  = 0x0A0D0D0A
+	pcapngInterfaceDesc  = 1
+	pcapngEnhancedPacket = 6
+	pcapngByteOrderMagic = 0x1A2B3C4D
+	// Packets are raw IPv4 or IPv6 packets, with no link-layer header.
+	pcapngLinkTypeRaw = 101
+
+	tcpFlagFIN = 0x01
+	tcpFlagSYN = 0x02
+	tcpFlagPSH = 0x08
+	tcpFlagACK = 0x10
+
+	// The maximum number of payload bytes in a single synthetic
+	// TCP segment. Larger writes are split into multiple segments.
+	maxSegmentLen = 16 * 1024
+
+	// The server port used for connections without TCP addresses. Wireshark
+	// dissects this port as HTTP, which hands off to its HTTP/2 dissector
+	// when a connection starts with the HTTP/2 preface. Port 443 would
+	// instead be dissected as TLS, which the captured bytes are not.
+	syntheticServerPort = 80
+	// The range of client ports used for connections without TCP addresses,
+	// which is the range of dynamic (ephemeral) ports.
+	minSyntheticClientPort = 49152
+	maxSyntheticClientPort = 65535
+)
+
+// PacketCapture writes the clear-text bytes of traced connections to a file
+// in pcapng format, so they can be examined with tools like Wireshark. Since
+// the bytes are captured after TLS decryption (and before encryption), each
+// connection is written as an unencrypted TCP connection, with synthetic
+// IP and TCP headers. The packets for a connection include a TCP handshake
+// at the start and, when the connection is closed, a FIN from the side that
+// closed it.
+//
+// Currently, only HTTP/2 connections are captured, by TracingHTTP2Conn.
+type PacketCapture struct {
+	mu       sync.Mutex
+	w        *bufio.Writer
+	err      error
+	nextPort uint16
+}
+
+// NewPacketCapture creates a new capture that writes to the given writer.
+// The pcapng header is written immediately. The Flush method must be called
+// after all connections are captured to make sure all packets are written.
+func NewPacketCapture(w io.Writer) (*PacketCapture, error) {
+	capture := &PacketCapture{w: bufio.NewWriter(w), nextPort: minSyntheticClientPort}
+	// Section header block, with no options.
+	var block []byte
+	block = binary.LittleEndian.AppendUint32(block, pcapngByteOrderMagic)
+	block = binary.LittleEndian.AppendUint16(block, 1) // major version
+	block = binary.LittleEndian.AppendUint16(block, 0) // minor version
+	block = binary.LittleEndian.AppendUint64(block, ^uint64(0))
+	capture.writeBlockLocked(pcapngSectionHeader, block)
+	// Interface description block, with no options, so timestamps
+	// are in microseconds.
+	block = block[:0]
+	block = binary.LittleEndian.AppendUint16(block, pcapngLinkTypeRaw)
+	block = binary.LittleEndian.AppendUint16(block, 0) // reserved
+	block = binary.LittleEndian.AppendUint32(block, 0) // no snap length
+	capture.writeBlockLocked(pcapngInterfaceDesc, block)
+	if capture.err != nil {
+		return nil, capture.err
+	}
+	return capture, nil
+}
+
+// Flush writes any buffered packets to the underlying writer.
+func (c *PacketCapture) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	return c.w.Flush()
+}
+
+// newConn starts the capture of a connection. The given addresses are the
+// connection's local and remote addresses. If they are not TCP addresses,
+// like for a connection over a pipe, loopback addresses with synthetic
+// ports are used instead.
+func (c *PacketCapture) newConn(local, remote net.Addr, isServer bool) *capturedConn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	client, server := local, remote
+	if isServer {
+		client, server = remote, local
+	}
+	conn := &capturedConn{capture: c, isServer: isServer, clientSeq: 1, serverSeq: 1}
+	clientAddr, clientOK := client.(*net.TCPAddr)
+	serverAddr, serverOK := server.(*net.TCPAddr)
+	if clientOK && serverOK && (clientAddr.IP.To4() == nil) == (serverAddr.IP.To4() == nil) {
+		conn.clientIP, conn.clientPort = clientAddr.IP, uint16(clientAddr.Port)
+		conn.serverIP, conn.serverPort = serverAddr.IP, uint16(serverAddr.Port)
+	} else {
+		conn.clientIP, conn.clientPort = net.IPv4(127, 0, 0, 1), c.nextPort
+		conn.serverIP, conn.serverPort = net.IPv4(127, 0, 0, 1), syntheticServerPort
+		if c.nextPort == maxSyntheticClientPort {
+			c.nextPort = minSyntheticClientPort
+		} else {
+			c.nextPort++
+		}
+	}
+	if ip4 := conn.clientIP.To4(); ip4 != nil {
+		conn.clientIP, conn.serverIP = ip4, conn.serverIP.To4()
+	}
+	now := time.Now()
+	conn.writeSegmentLocked(now, true, tcpFlagSYN, nil)
+	conn.clientSeq++
+	conn.writeSegmentLocked(now, false, tcpFlagSYN|tcpFlagACK, nil)
+	conn.serverSeq++
+	conn.writeSegmentLocked(now, true, tcpFlagACK, nil)
+	return conn
+}
+
+func (c *PacketCapture) writeBlockLocked(blockType uint32, body []byte) {
+	if c.err != nil {
+		return
+	}
+	padding := (4 - len(body)%4) % 4
+	totalLen := uint32(12 + len(body) + padding)
+	block := make([]byte, 0, totalLen)
+	block = binary.LittleEndian.AppendUint32(block, blockType)
+	block = binary.LittleEndian.AppendUint32(block, totalLen)
+	block = append(block, body...)
+	block = append(block, make([]byte, padding)...)
+	block = binary.LittleEndian.AppendUint32(block, totalLen)
+	_, c.err = c.w.Write(block)
+}
+
+func (c *PacketCapture) writePacketLocked(timestamp time.Time, packet []byte) {
+	micros := uint64(timestamp.UnixMicro())
+	body := make([]byte, 0, 20+len(packet))
+	body = binary.LittleEndian.AppendUint32(body, 0) // interface ID
+	body = binary.LittleEndian.AppendUint32(body, uint32(micros>>32))
+	body = binary.LittleEndian.AppendUint32(body, uint32(micros))
+	body = binary.LittleEndian.AppendUint32(body, uint32(len(packet))) // captured length
+	body = binary.LittleEndian.AppendUint32(body, uint32(len(packet))) // original length
+	body = append(body, packet...)
+	c.writeBlockLocked(pcapngEnhancedPacket, body)
+}
+
+// capturedConn is a single connection in a PacketCapture.
+type capturedConn struct {
+	capture  *PacketCapture
+	isServer bool
+	// Guarded by capture.mu.
+	clientIP, serverIP     net.IP
+	clientPort, serverPort uint16
+	clientSeq, serverSeq   uint32
+	clientClosed           bool
+	serverClosed           bool
+}
+
+// read captures data received on the connection. It is a no-op if c is nil.
+func (c *capturedConn) read(data []byte) {
+	if c != nil {
+		c.add(data, c.isServer)
+	}
+}
+
+// write captures data sent on the connection. It is a no-op if c is nil.
+func (c *capturedConn) write(data []byte) {
+	if c != nil {
+		c.add(data, !c.isServer)
+	}
+}
+
+// close captures the closing of the connection. If local is true, the
+// local side closed the connection; otherwise, the remote side did. It
+// is a no-op if c is nil.
+func (c *capturedConn) close(local bool) {
+	if c == nil {
+		return
+	}
+	c.capture.mu.Lock()
+	defer c.capture.mu.Unlock()
+	fromClient := local != c.isServer
+	if fromClient {
+		if c.clientClosed {
+			return
+		}
+		c.clientClosed = true
+	} else {
+		if c.serverClosed {
+			return
+		}
+		c.serverClosed = true
+	}
+	c.writeSegmentLocked(time.Now(), fromClient, tcpFlagFIN|tcpFlagACK, nil)
+	if fromClient {
+		c.clientSeq++
+	} else {
+		c.serverSeq++
+	}
+}
+
+func (c *capturedConn) add(data []byte, fromClient bool) {
+	if len(data) == 0 {
+		return
+	}
+	c.capture.mu.Lock()
+	defer c.capture.mu.Unlock()
+	if (fromClient && c.clientClosed) || (!fromClient && c.serverClosed) {
+		return
+	}
+	now := time.Now()
+	for len(data) > 0 {
+		segment := data
+		if len(segment) > maxSegmentLen {
+			segment = segment[:maxSegmentLen]
+		}
+		data = data[len(segment):]
+		c.writeSegmentLocked(now, fromClient, tcpFlagPSH|tcpFlagACK, segment)
+		if fromClient {
+			c.clientSeq += uint32(len(segment))
+		} else {
+			c.serverSeq += uint32(len(segment))
+		}
+	}
+}
+
+func (c *capturedConn) writeSegmentLocked(timestamp time.Time, fromClient bool, flags byte, payload []byte) {
+	srcIP, srcPort, seq := c.clientIP, c.clientPort, c.clientSeq
+	dstIP, dstPort, ack := c.serverIP, c.serverPort, c.serverSeq
+	if !fromClient {
+		srcIP, srcPort, seq = c.serverIP, c.serverPort, c.serverSeq
+		dstIP, dstPort, ack = c.clientIP, c.clientPort, c.clientSeq
+	}
+	if flags&tcpFlagACK == 0 {
+		ack = 0
+	}
+	segment := make([]byte, 0, 20+len(payload))
+	segment = binary.BigEndian.AppendUint16(segment, srcPort)
+	segment = binary.BigEndian.AppendUint16(segment, dstPort)
+	segment = binary.BigEndian.AppendUint32(segment, seq)
+	segment = binary.BigEndian.AppendUint32(segment, ack)
+	segment = append(segment, 5<<4, flags)                  // header length (in 32-bit words), flags
+	segment = binary.BigEndian.AppendUint16(segment, 65535) // window
+	segment = binary.BigEndian.AppendUint16(segment, 0)     // checksum, computed below
+	segment = binary.BigEndian.AppendUint16(segment, 0)     // urgent pointer
+	segment = append(segment, payload...)
+
+	// The TCP checksum includes a pseudo-header with the IP addresses.
+	var pseudo []byte
+	pseudo = append(pseudo, srcIP...)
+	pseudo = append(pseudo, dstIP...)
+	if len(srcIP) == net.IPv4len {
+		pseudo = append(pseudo, 0, 6) // protocol: TCP
+		pseudo = binary.BigEndian.AppendUint16(pseudo, uint16(len(segment)))
+	} else {
+		pseudo = binary.BigEndian.AppendUint32(pseudo, uint32(len(segment)))
+		pseudo = append(pseudo, 0, 0, 0, 6) // next header: TCP
+	}
+	binary.BigEndian.PutUint16(segment[16:], internetChecksum(pseudo, segment))
+
+	var packet []byte
+	if len(srcIP) == net.IPv4len {
+		packet = make([]byte, 0, 20+len(segment))
+		packet = append(packet, 4<<4|5, 0) // version, header length (in 32-bit words), DSCP/ECN
+		packet = binary.BigEndian.AppendUint16(packet, uint16(20+len(segment)))
+		packet = append(packet, 0, 0, 0x40, 0) // identification, flags (don't fragment), fragment offset
+		packet = append(packet, 64, 6, 0, 0)   // TTL, protocol (TCP), checksum (computed below)
+		packet = append(packet, srcIP...)
+		packet = append(packet, dstIP...)
+		binary.BigEndian.PutUint16(packet[10:], internetChecksum(packet))
+	} else {
+		packet = make([]byte, 0, 40+len(segment))
+		packet = append(packet, 6<<4, 0, 0, 0) // version, traffic class, flow label
+		packet = binary.BigEndian.AppendUint16(packet, uint16(len(segment)))
+		packet = append(packet, 6, 64) // next header (TCP), hop limit
+		packet = append(packet, srcIP...)
+		packet = append(packet, dstIP...)
+	}
+	packet = append(packet, segment...)
+	c.capture.writePacketLocked(timestamp, packet)
+}
+
+// internetChecksum computes the checksum used in IP and TCP headers, which
+// is the ones' complement of the ones' complement sum of the given data, as
+// 16-bit words.
+func internetChecksum(data ...[]byte) uint16 {
+	var sum uint32
+	var odd bool
+	var prev byte
+	for _, chunk := range data {
+		for _, b := range chunk {
+			if odd {
+				sum += uint32(prev)<<8 | uint32(b)
+			} else {
+				prev = b
+			}
+			odd = !odd
+		}
+	}
+	if odd {
+		sum += uint32(prev) << 8
+	}
+	for sum > 0xffff {
+		sum = sum>>16 + sum&0xffff
+	}
+	return ^uint16(sum)
+}
@@ -0,0 +1,207 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketCapture(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name               string
+		local, remote      net.Addr
+		isServer           bool
+		expectIPLen        int
+		expectClientPort   uint16
+		expectServerPort   uint16
+		expectClientIPAddr net.IP
+	}{
+		{
+			name:               "server ipv4",
+			local:              &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080},
+			remote:             &net.TCPAddr{IP: net.IPv4(127, 0, 0, 2), Port: 54321},
+			isServer:           true,
+			expectIPLen:        net.IPv4len,
+			expectClientPort:   54321,
+			expectServerPort:   8080,
+			expectClientIPAddr: net.IPv4(127, 0, 0, 2).To4(),
+		},
+		{
+			name:               "client ipv6",
+			local:              &net.TCPAddr{IP: net.IPv6loopback, Port: 54321},
+			remote:             &net.TCPAddr{IP: net.IPv6loopback, Port: 8080},
+			expectIPLen:        net.IPv6len,
+			expectClientPort:   54321,
+			expectServerPort:   8080,
+			expectClientIPAddr: net.IPv6loopback,
+		},
+		{
+			name:               "pipe",
+			local:              pipeAddr{},
+			remote:             pipeAddr{},
+			expectIPLen:        net.IPv4len,
+			expectClientPort:   minSyntheticClientPort,
+			expectServerPort:   syntheticServerPort,
+			expectClientIPAddr: net.IPv4(127, 0, 0, 1).To4(),
+		},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			capture, err := NewPacketCapture(&buf)
+			require.NoError(t, err)
+			conn := capture.newConn(testCase.local, testCase.remote, testCase.isServer)
+			request := bytes.Repeat([]byte("abcdefgh"), maxSegmentLen/8+1)
+			if testCase.isServer {
+				conn.read(request)
+				conn.write([]byte("response"))
+			} else {
+				conn.write(request)
+				conn.read([]byte("response"))
+			}
+			conn.close(false)
+			conn.close(false) // no-op
+			conn.read([]byte("ignored after close"))
+			require.NoError(t, capture.Flush())
+
+			blocks := parsePcapng(t, buf.Bytes())
+			require.Len(t, blocks, 9)
+			assert.Equal(t, uint32(pcapngSectionHeader), blocks[0].blockType)
+			assert.Equal(t, uint32(pcapngInterfaceDesc), blocks[1].blockType)
+			assert.Equal(t, uint16(pcapngLinkTypeRaw), binary.LittleEndian.Uint16(blocks[1].body))
+
+			type segment struct {
+				fromClient bool
+				flags      byte
+				seq, ack   uint32
+				payloadLen int
+			}
+			expectSegments := []segment{
+				{fromClient: true, flags: tcpFlagSYN, seq: 1},
+				{fromClient: false, flags: tcpFlagSYN | tcpFlagACK, seq: 1, ack: 2},
+				{fromClient: true, flags: tcpFlagACK, seq: 2, ack: 2},
+				{fromClient: true, flags: tcpFlagPSH | tcpFlagACK, seq: 2, ack: 2, payloadLen: maxSegmentLen},
+				{fromClient: true, flags: tcpFlagPSH | tcpFlagACK, seq: 2 + maxSegmentLen, ack: 2, payloadLen: 8},
+				{fromClient: false, flags: tcpFlagPSH | tcpFlagACK, seq: 2, ack: 2 + maxSegmentLen + 8, payloadLen: 8},
+				// The remote side closed the connection.
+				{fromClient: testCase.isServer, flags: tcpFlagFIN | tcpFlagACK},
+			}
+			var payload []byte
+			for i, block := range blocks[2:] {
+				require.Equal(t, uint32(pcapngEnhancedPacket), block.blockType)
+				capturedLen := binary.LittleEndian.Uint32(block.body[12:])
+				packet := block.body[20 : 20+capturedLen]
+				var srcIP net.IP
+				var tcp []byte
+				if testCase.expectIPLen == net.IPv4len {
+					require.Equal(t, byte(0x45), packet[0])
+					assert.Equal(t, int(binary.BigEndian.Uint16(packet[2:])), len(packet))
+					assert.Zero(t, internetChecksum(packet[:20]), "IP header checksum should be valid")
+					srcIP = packet[12:16]
+					tcp = packet[20:]
+				} else {
+					require.Equal(t, byte(0x60), packet[0])
+					assert.Equal(t, int(binary.BigEndian.Uint16(packet[4:])), len(packet)-40)
+					srcIP = packet[8:24]
+					tcp = packet[40:]
+				}
+				var pseudo []byte
+				if testCase.expectIPLen == net.IPv4len {
+					pseudo = append(pseudo, packet[12:20]...)
+					pseudo = append(pseudo, 0, 6)
+					pseudo = binary.BigEndian.AppendUint16(pseudo, uint16(len(tcp)))
+				} else {
+					pseudo = append(pseudo, packet[8:40]...)
+					pseudo = binary.BigEndian.AppendUint32(pseudo, uint32(len(tcp)))
+					pseudo = append(pseudo, 0, 0, 0, 6)
+				}
+				assert.Zero(t, internetChecksum(pseudo, tcp), "TCP checksum should be valid")
+
+				fromClient := srcIP.Equal(testCase.expectClientIPAddr) &&
+					binary.BigEndian.Uint16(tcp) == testCase.expectClientPort
+				if !fromClient {
+					assert.Equal(t, testCase.expectServerPort, binary.BigEndian.Uint16(tcp))
+				}
+				seg := segment{
+					fromClient: fromClient,
+					flags:      tcp[13],
+					seq:        binary.BigEndian.Uint32(tcp[4:]),
+					ack:        binary.BigEndian.Uint32(tcp[8:]),
+					payloadLen: len(tcp) - 20,
+				}
+				expect := expectSegments[i]
+				if expect.flags&tcpFlagFIN != 0 {
+					// Sequence numbers depend on which side closed.
+					expect.seq, expect.ack = seg.seq, seg.ack
+				}
+				assert.Equal(t, expect, seg, "segment %d", i)
+				if seg.fromClient {
+					payload = append(payload, tcp[20:]...)
+				}
+			}
+			assert.Equal(t, request, payload)
+		})
+	}
+}
+
+func TestPacketCaptureSyntheticPortsWrap(t *testing.T) {
+	t.Parallel()
+	capture, err := NewPacketCapture(io.Discard)
+	require.NoError(t, err)
+	capture.nextPort = maxSyntheticClientPort - 1
+	var ports []uint16
+	for i := 0; i < 3; i++ {
+		conn := capture.newConn(pipeAddr{}, pipeAddr{}, false)
+		assert.Equal(t, uint16(syntheticServerPort), conn.serverPort)
+		ports = append(ports, conn.clientPort)
+	}
+	assert.Equal(t, []uint16{maxSyntheticClientPort - 1, maxSyntheticClientPort, minSyntheticClientPort}, ports)
+}
+
+type pcapngBlock struct {
+	blockType uint32
+	body      []byte
+}
+
+func parsePcapng(t *testing.T, data []byte) []pcapngBlock {
+	t.Helper()
+	var blocks []pcapngBlock
+	for len(data) > 0 {
+		require.GreaterOrEqual(t, len(data), 12)
+		blockType := binary.LittleEndian.Uint32(data)
+		totalLen := binary.LittleEndian.Uint32(data[4:])
+		require.Zero(t, totalLen%4)
+		require.LessOrEqual(t, int(totalLen), len(data))
+		require.Equal(t, totalLen, binary.LittleEndian.Uint32(data[totalLen-4:]))
+		blocks = append(blocks, pcapngBlock{blockType: blockType, body: data[8 : totalLen-4]})
+		data = data[totalLen:]
+	}
+	return blocks
+}
+
+type pipeAddr struct{}
+
+func (pipeAddr) Network() string { return "pipe" }
+func (pipeAddr) String() string  { return "pipe" }
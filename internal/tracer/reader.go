@@ -25,6 +25,7 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"connectrpc.com/conformance/internal"
 	"connectrpc.com/connect"
 )
 
@@ -43,11 +44,11 @@ type tracingReader struct {
 func newRequestReader(headers http.Header, reader io.ReadCloser, isRequest bool, builder *builder) io.ReadCloser {
 	// no action to take when request body is done
 	whenDone := func() {}
-	return newReader(headers, reader, isRequest, builder, whenDone)
+	return newReader(headers, 0, reader, isRequest, builder, whenDone)
 }
 
-func newReader(headers http.Header, reader io.ReadCloser, isRequest bool, builder *builder, whenDone func()) io.ReadCloser {
-	isStream, decompressor := propertiesFromHeaders(headers)
+func newReader(headers http.Header, statusCode int, reader io.ReadCloser, isRequest bool, builder *builder, whenDone func()) io.ReadCloser {
+	isStream, decompressor, textDecoder := propertiesFromHeaders(headers)
 	return &tracingReader{
 		reader:    reader,
 		isRequest: isRequest,
@@ -57,6 +58,8 @@ func newReader(headers http.Header, reader io.ReadCloser, isRequest bool, builde
 			isRequest:        isRequest,
 			isStreamProtocol: isStream,
 			decompressor:     decompressor,
+			textDecoder:      textDecoder,
+			decoder:          newMessageDecoder(builder.procedure, isRequest, statusCode, headers),
 			builder:          builder,
 		},
 	}
@@ -105,7 +108,13 @@ type dataTracer struct {
 	isRequest        bool
 	isStreamProtocol bool
 	decompressor     connect.Decompressor
-	builder          *builder
+	// non-nil for gRPC-Web text, in which case the data is
+	// base64-encoded and must be decoded before it is parsed
+	textDecoder *internal.GRPCWebTextDecoder
+	// non-nil if message contents can be decoded, in which
+	// case data events include the JSON form of each message
+	decoder *messageDecoder
+	builder *builder
 
 	mu        sync.Mutex
 	prefix    []byte
@@ -113,14 +122,41 @@ type dataTracer struct {
 	expecting uint32
 	actual    uint64
 	endStream *bytes.Buffer
+	// contents of the current message, if it is to be decoded
+	message *bytes.Buffer
 }
 
 func (d *dataTracer) trace(data []byte) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
+	if d.textDecoder != nil {
+		decoded, err := d.textDecoder.Decode(data)
+		if err != nil {
+			// Malformed data, so we can't make sense of the rest
+			// of the stream. Just count the bytes.
+			d.textDecoder = nil
+			d.isStreamProtocol = false
+			d.decoder = nil
+			d.message = nil
+		} else {
+			data = decoded
+		}
+	}
 	if !d.isStreamProtocol {
 		d.actual += uint64(len(data))
+		if d.decoder != nil {
+			if d.actual > maxDecodedMessageSize {
+				// Too large to decode.
+				d.decoder = nil
+				d.message = nil
+			} else {
+				if d.message == nil {
+					d.message = &bytes.Buffer{}
+				}
+				_, _ = d.message.Write(data)
+			}
+		}
 		return
 	}
 	for {
@@ -163,24 +199,23 @@ func (d *dataTracer) tracePrefixLocked(data []byte) (int, bool) {
 	}
 	d.expecting = d.env.Len
 	d.prefix = d.prefix[:0]
-	if d.expecting == 0 {
+	isEndStream := !d.isRequest && (d.env.Flags&0x82) != 0
+	switch {
+	case d.expecting == 0:
 		// If we're not expecting any more data for this message, go
 		// ahead and emit event.
-		if d.isRequest {
-			d.builder.add(&RequestBodyData{
-				Envelope: d.env,
-				Len:      0,
-			})
-		} else {
-			d.builder.add(&ResponseBodyData{
-				Envelope: d.env,
-				Len:      0,
-			})
+		var message string
+		if d.decoder != nil && !isEndStream {
+			message = d.decodeLocked(nil)
 		}
+		d.addDataLocked(0, message)
 		d.env = nil
-	} else if !d.isRequest && (d.env.Flags&0x82) != 0 {
+	case isEndStream:
 		// This is a response end-stream message. Capture the contents.
 		d.endStream = bytes.NewBuffer(make([]byte, 0, d.env.Len))
+	case d.decoder != nil && d.env.Len <= maxDecodedMessageSize:
+		// Capture the contents so the message can be decoded.
+		d.message = bytes.NewBuffer(make([]byte, 0, d.env.Len))
 	}
 	return need, true
 }
@@ -193,24 +228,23 @@ func (d *dataTracer) traceMessageLocked(data []byte) (int, bool) {
 		if d.endStream != nil {
 			_, _ = d.endStream.Write(data)
 		}
+		if d.message != nil {
+			_, _ = d.message.Write(data)
+		}
 		return need, false
 	}
 
-	if d.isRequest {
-		d.builder.add(&RequestBodyData{
-			Envelope: d.env,
-			Len:      uint64(d.expecting),
-		})
-	} else {
-		d.builder.add(&ResponseBodyData{
-			Envelope: d.env,
-			Len:      uint64(d.expecting),
-		})
+	var message string
+	if d.message != nil {
+		_, _ = d.message.Write(data[:need])
+		message = d.decodeLocked(d.message.Bytes())
+		d.message = nil
 	}
+	d.addDataLocked(uint64(d.expecting), message)
 	if d.endStream != nil { //nolint:nestif
 		_, _ = d.endStream.Write(data[:need])
 		var content string
-		if d.decompressor == nil {
+		if d.decompressor == nil || d.env.Flags&0x01 == 0 {
 			content = d.endStream.String()
 		} else {
 			var uncompressed bytes.Buffer
@@ -224,6 +258,7 @@ func (d *dataTracer) traceMessageLocked(data []byte) (int, bool) {
 		if content != "" {
 			d.builder.add(&ResponseBodyEndStream{
 				Content: content,
+				Error:   decodeEndStream(d.env.Flags, content),
 			})
 		}
 		d.endStream = nil
@@ -246,26 +281,51 @@ func (d *dataTracer) emitUnfinished() {
 	}
 
 	if unfinished > 0 {
-		if d.isRequest {
-			d.builder.add(&RequestBodyData{
-				Envelope: d.env,
-				Len:      unfinished,
-			})
-		} else {
-			d.builder.add(&ResponseBodyData{
-				Envelope: d.env,
-				Len:      unfinished,
-			})
+		var message string
+		if !d.isStreamProtocol && d.message != nil {
+			// For non-stream protocols, the whole body is a single message.
+			message = d.decoder.decode(d.message.Bytes(), d.decoder.bodyDecompressor)
 		}
+		d.addDataLocked(unfinished, message)
 	}
 
 	d.endStream = nil // we didn't finish reading end-stream message; discard what we got
+	d.message = nil
 	d.env = nil
 	d.expecting = 0
 	d.actual = 0
 	d.prefix = d.prefix[:0]
 }
 
+func (d *dataTracer) addDataLocked(length uint64, message string) {
+	if d.isRequest {
+		d.builder.add(&RequestBodyData{
+			Envelope: d.env,
+			Len:      length,
+			Message:  message,
+		})
+	} else {
+		d.builder.add(&ResponseBodyData{
+			Envelope: d.env,
+			Len:      length,
+			Message:  message,
+		})
+	}
+}
+
+// decodeLocked decodes the given enveloped message, decompressing
+// it first if the envelope indicates it is compressed.
+func (d *dataTracer) decodeLocked(data []byte) string {
+	var decompressor connect.Decompressor
+	if d.env.Flags&0x01 != 0 {
+		if _, broken := d.decompressor.(brokenDecompressor); broken || d.decompressor == nil {
+			return ""
+		}
+		decompressor = d.decompressor
+	}
+	return d.decoder.decode(data, decompressor)
+}
+
 // brokenDecompressor is a no-op implementation that treats all compressed
 // messages as if they were empty.
 type brokenDecompressor struct{}
@@ -282,20 +342,22 @@ func (brokenDecompressor) Reset(io.Reader) error {
 	return nil
 }
 
-func propertiesFromHeaders(headers http.Header) (isStream bool, decomp connect.Decompressor) {
+func propertiesFromHeaders(headers http.Header) (isStream bool, decomp connect.Decompressor, textDecoder *internal.GRPCWebTextDecoder) {
 	contentType := strings.ToLower(headers.Get("Content-Type"))
 	if headers.Get("Content-Encoding") != "" {
 		// full body is encoded, so don't bother trying to parse stream
-		return false, brokenDecompressor{}
+		return false, brokenDecompressor{}, nil
 	}
 	switch {
 	case strings.HasPrefix(contentType, "application/connect"):
-		return true, GetDecompressor(headers.Get("Connect-Content-Encoding"))
+		return true, GetDecompressor(headers.Get("Connect-Content-Encoding")), nil
+	case internal.IsGRPCWebTextContentType(contentType):
+		return true, GetDecompressor(headers.Get("Grpc-Encoding")), &internal.GRPCWebTextDecoder{}
 	case strings.HasPrefix(contentType, "application/grpc"):
-		return true, GetDecompressor(headers.Get("Grpc-Encoding"))
+		return true, GetDecompressor(headers.Get("Grpc-Encoding")), nil
 	default:
 		// We should only need a decompressor for streams (to decompress the end-stream message)
 		// So for non-stream protocols, this no-op decompressor should suffice.
-		return false, brokenDecompressor{}
+		return false, brokenDecompressor{}, nil
 	}
 }
@@ -0,0 +1,550 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/gob"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Store holds completed traces, keyed by test name. Implementations
+// must be safe for concurrent use.
+type Store interface {
+	// Put stores the given trace. Any trace previously stored
+	// for the same test name is replaced.
+	Put(testName string, trace *Trace)
+	// Get returns the trace for the given test name or nil if
+	// there is no such trace, such as if it was deleted or the
+	// store discarded it.
+	Get(testName string) *Trace
+	// Delete discards the trace for the given test name.
+	Delete(testName string)
+	// Names returns the test names of all stored traces, sorted.
+	Names() []string
+}
+
+// MemoryStore is a Store that holds traces in memory. The zero value
+// is ready to use.
+type MemoryStore struct {
+	mu     sync.Mutex
+	traces map[string]*Trace
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func (s *MemoryStore) Put(testName string, trace *Trace) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.traces == nil {
+		s.traces = map[string]*Trace{}
+	}
+	s.traces[testName] = trace
+}
+
+func (s *MemoryStore) Get(testName string) *Trace {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.traces[testName]
+}
+
+func (s *MemoryStore) Delete(testName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.traces, testName)
+}
+
+func (s *MemoryStore) Names() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.traces))
+	for name := range s.traces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DiskStore is a Store that writes traces to files in a temporary
+// directory, so that a large number of traces, or traces with large
+// messages, do not need to be held in memory. The total size of the
+// files is limited: when it would be exceeded, the oldest traces of
+// passing test cases, stored with PutPassing, are discarded first, and
+// then the oldest of the other traces. A trace that cannot be written is
+// also discarded.
+//
+// Traces are read back from disk in a form that has all of the data
+// needed to print them, diff them, and write them as HAR files. But
+// some details are lost: errors in the trace are replaced with errors
+// that have the same message, and the request and response only have
+// their metadata (no bodies, contexts, or TLS connection state).
+//
+// Close should be called to remove the directory when the store is no
+// longer needed.
+type DiskStore struct {
+	dir      string
+	maxBytes int64
+
+	mu      sync.Mutex
+	entries map[string]diskEntry
+	// entries in the order they were added, for discarding the
+	// oldest traces first, separately for passing test cases
+	// and for others
+	passingOrder []diskEntry
+	order        []diskEntry
+	totalSize    int64
+	nextID       int
+}
+
+var _ Store = (*DiskStore)(nil)
+
+type diskEntry struct {
+	testName string
+	id       int
+	size     int64
+	passing  bool
+}
+
+// NewDiskStore creates a new store in a new temporary directory. The
+// total size of all stored traces will not exceed maxBytes.
+func NewDiskStore(maxBytes int64) (*DiskStore, error) {
+	dir, err := os.MkdirTemp("", "connectconformance-traces-")
+	if err != nil {
+		return nil, err
+	}
+	return &DiskStore{dir: dir, maxBytes: maxBytes, entries: map[string]diskEntry{}}, nil
+}
+
+func (s *DiskStore) Put(testName string, trace *Trace) {
+	s.put(testName, trace, false)
+}
+
+// PutPassing stores the given trace, for a test case that passed. When
+// the size limit would be exceeded, such traces are discarded before
+// those stored with Put.
+func (s *DiskStore) PutPassing(testName string, trace *Trace) {
+	s.put(testName, trace, true)
+}
+
+func (s *DiskStore) put(testName string, trace *Trace, passing bool) {
+	var buf bytes.Buffer
+	encodeErr := gob.NewEncoder(&buf).Encode(newStoredTrace(trace))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleteLocked(testName)
+	if encodeErr != nil || int64(buf.Len()) > s.maxBytes {
+		return
+	}
+	for s.totalSize+int64(buf.Len()) > s.maxBytes {
+		if !s.discardOldestLocked() {
+			break
+		}
+	}
+	s.nextID++
+	entry := diskEntry{testName: testName, id: s.nextID, size: int64(buf.Len()), passing: passing}
+	if err := os.WriteFile(s.fileName(entry.id), buf.Bytes(), 0o600); err != nil {
+		return
+	}
+	s.entries[testName] = entry
+	if passing {
+		s.passingOrder = append(s.passingOrder, entry)
+	} else {
+		s.order = append(s.order, entry)
+	}
+	s.totalSize += entry.size
+}
+
+func (s *DiskStore) Get(testName string) *Trace {
+	s.mu.Lock()
+	entry, ok := s.entries[testName]
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	data, err := os.ReadFile(s.fileName(entry.id))
+	if err != nil {
+		// Concurrently deleted or replaced.
+		return nil
+	}
+	var stored storedTrace
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&stored); err != nil {
+		return nil
+	}
+	return stored.trace()
+}
+
+func (s *DiskStore) Delete(testName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deleteLocked(testName)
+}
+
+func (s *DiskStore) Names() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.entries))
+	for name := range s.entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Close discards all traces and removes the store's directory.
+func (s *DiskStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = map[string]diskEntry{}
+	s.passingOrder = nil
+	s.order = nil
+	s.totalSize = 0
+	return os.RemoveAll(s.dir)
+}
+
+func (s *DiskStore) deleteLocked(testName string) {
+	entry, ok := s.entries[testName]
+	if !ok {
+		return
+	}
+	delete(s.entries, testName)
+	s.totalSize -= entry.size
+	_ = os.Remove(s.fileName(entry.id))
+	// The entry is left in s.passingOrder or s.order and skipped
+	// when discarding.
+}
+
+// discardOldestLocked discards the oldest trace of a passing test case or,
+// if there are none, the oldest of the other traces. It returns false if
+// there are no traces to discard.
+func (s *DiskStore) discardOldestLocked() bool {
+	return s.discardOldestInLocked(&s.passingOrder) || s.discardOldestInLocked(&s.order)
+}
+
+func (s *DiskStore) discardOldestInLocked(order *[]diskEntry) bool {
+	for len(*order) > 0 {
+		oldest := (*order)[0]
+		*order = (*order)[1:]
+		if s.entries[oldest.testName] == oldest {
+			s.deleteLocked(oldest.testName)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *DiskStore) fileName(id int) string {
+	return filepath.Join(s.dir, strconv.Itoa(id)+".trace")
+}
+
+// storedTrace is the form of a trace that is written to disk by
+// DiskStore. The fields of Trace and of the various events cannot be
+// encoded directly, because they include interfaces, functions, and
+// unexported fields.
+type storedTrace struct {
+	TestName string
+	Start    time.Time
+	Client   bool
+	Request  *storedRequest
+	Response *storedResponse
+	Err      *string
+	Events   []storedEvent
+	Frames   []storedFrame
+}
+
+type storedRequest struct {
+	Method                 string
+	URL                    string
+	Host                   string
+	Proto                  string
+	ProtoMajor, ProtoMinor int
+	Header                 http.Header
+	ContentLength          int64
+	TLS                    bool
+}
+
+type storedResponse struct {
+	Status                 string
+	StatusCode             int
+	Proto                  string
+	ProtoMajor, ProtoMinor int
+	Header, Trailer        http.Header
+	ContentLength          int64
+}
+
+type storedEvent struct {
+	Type   string
+	Offset time.Duration
+	// For RequestStart, the headers that were sent or received.
+	Headers http.Header
+	// For ResponseStart, the response, if it is not the same
+	// as the trace's response.
+	Response *storedResponse
+	// For RequestBodyData and ResponseBodyData.
+	Envelope     *Envelope
+	Len          uint64
+	MessageIndex int
+	Message      string
+	// For ResponseBodyEndStream.
+	Content string
+	Error   string
+	// For events with an error.
+	Err *string
+}
+
+type storedFrame struct {
+	FromClient bool
+	StreamID   uint32
+	Type       string
+	Length     uint32
+	Summary    string
+	Offset     time.Duration
+}
+
+func newStoredTrace(trace *Trace) *storedTrace {
+	stored := &storedTrace{
+		TestName: trace.TestName,
+		Start:    trace.Start,
+		Client:   trace.Client,
+		Request:  newStoredRequest(trace.Request),
+		Response: newStoredResponse(trace.Response),
+		Err:      errorString(trace.Err),
+		Events:   make([]storedEvent, 0, len(trace.Events)),
+		Frames:   make([]storedFrame, 0, len(trace.Frames)),
+	}
+	for _, event := range trace.Events {
+		storedEvt := storedEvent{Offset: event.offset()}
+		switch event := event.(type) {
+		case *RequestStart:
+			storedEvt.Type = "RequestStart"
+			storedEvt.Headers = event.getHeaders()
+		case *RequestBodyData:
+			storedEvt.Type = "RequestBodyData"
+			storedEvt.Envelope, storedEvt.Len = event.Envelope, event.Len
+			storedEvt.MessageIndex, storedEvt.Message = event.MessageIndex, event.Message
+		case *RequestBodyEnd:
+			storedEvt.Type = "RequestBodyEnd"
+			storedEvt.Err = errorString(event.Err)
+		case *RequestCanceled:
+			storedEvt.Type = "RequestCanceled"
+		case *ResponseStart:
+			storedEvt.Type = "ResponseStart"
+			if event.Response != trace.Response {
+				storedEvt.Response = newStoredResponse(event.Response)
+			}
+		case *ResponseError:
+			storedEvt.Type = "ResponseError"
+			storedEvt.Err = errorString(event.Err)
+		case *ResponseBodyData:
+			storedEvt.Type = "ResponseBodyData"
+			storedEvt.Envelope, storedEvt.Len = event.Envelope, event.Len
+			storedEvt.MessageIndex, storedEvt.Message = event.MessageIndex, event.Message
+		case *ResponseBodyEndStream:
+			storedEvt.Type = "ResponseBodyEndStream"
+			storedEvt.Content, storedEvt.Error = event.Content, event.Error
+		case *ResponseBodyEnd:
+			storedEvt.Type = "ResponseBodyEnd"
+			storedEvt.Err = errorString(event.Err)
+		default:
+			continue
+		}
+		stored.Events = append(stored.Events, storedEvt)
+	}
+	for _, frame := range trace.Frames {
+		stored.Frames = append(stored.Frames, storedFrame{
+			FromClient: frame.FromClient,
+			StreamID:   frame.StreamID,
+			Type:       frame.Type,
+			Length:     frame.Length,
+			Summary:    frame.Summary,
+			Offset:     frame.Offset,
+		})
+	}
+	return stored
+}
+
+func (s *storedTrace) trace() *Trace {
+	trace := &Trace{
+		TestName: s.TestName,
+		Start:    s.Start,
+		Client:   s.Client,
+		Request:  s.Request.request(),
+		Response: s.Response.response(),
+		Err:      errorFromString(s.Err),
+		Events:   make([]Event, 0, len(s.Events)),
+	}
+	for _, storedEvt := range s.Events {
+		var event Event
+		switch storedEvt.Type {
+		case "RequestStart":
+			headers := storedEvt.Headers
+			event = &RequestStart{Request: trace.Request, getHeaders: func() http.Header { return headers.Clone() }}
+		case "RequestBodyData":
+			event = &RequestBodyData{
+				Envelope:     storedEvt.Envelope,
+				Len:          storedEvt.Len,
+				MessageIndex: storedEvt.MessageIndex,
+				Message:      storedEvt.Message,
+			}
+		case "RequestBodyEnd":
+			event = &RequestBodyEnd{Err: errorFromString(storedEvt.Err)}
+		case "RequestCanceled":
+			event = &RequestCanceled{}
+		case "ResponseStart":
+			resp := trace.Response
+			if storedEvt.Response != nil {
+				resp = storedEvt.Response.response()
+			}
+			event = &ResponseStart{Response: resp}
+		case "ResponseError":
+			event = &ResponseError{Err: errorFromString(storedEvt.Err)}
+		case "ResponseBodyData":
+			event = &ResponseBodyData{
+				Envelope:     storedEvt.Envelope,
+				Len:          storedEvt.Len,
+				MessageIndex: storedEvt.MessageIndex,
+				Message:      storedEvt.Message,
+			}
+		case "ResponseBodyEndStream":
+			event = &ResponseBodyEndStream{Content: storedEvt.Content, Error: storedEvt.Error}
+		case "ResponseBodyEnd":
+			event = &ResponseBodyEnd{Err: errorFromString(storedEvt.Err)}
+		default:
+			continue
+		}
+		event.setEventOffset(storedEvt.Offset)
+		trace.Events = append(trace.Events, event)
+	}
+	for _, storedFrm := range s.Frames {
+		frame := &Frame{
+			FromClient: storedFrm.FromClient,
+			StreamID:   storedFrm.StreamID,
+			Type:       storedFrm.Type,
+			Length:     storedFrm.Length,
+			Summary:    storedFrm.Summary,
+		}
+		frame.Offset = storedFrm.Offset
+		trace.Frames = append(trace.Frames, frame)
+	}
+	return trace
+}
+
+func newStoredRequest(req *http.Request) *storedRequest {
+	if req == nil {
+		return nil
+	}
+	stored := &storedRequest{
+		Method:        req.Method,
+		Host:          req.Host,
+		Proto:         req.Proto,
+		ProtoMajor:    req.ProtoMajor,
+		ProtoMinor:    req.ProtoMinor,
+		Header:        req.Header,
+		ContentLength: req.ContentLength,
+		TLS:           req.TLS != nil,
+	}
+	if req.URL != nil {
+		stored.URL = req.URL.String()
+	}
+	return stored
+}
+
+func (s *storedRequest) request() *http.Request {
+	if s == nil {
+		return nil
+	}
+	req := &http.Request{
+		Method:        s.Method,
+		URL:           &url.URL{},
+		Host:          s.Host,
+		Proto:         s.Proto,
+		ProtoMajor:    s.ProtoMajor,
+		ProtoMinor:    s.ProtoMinor,
+		Header:        s.Header,
+		ContentLength: s.ContentLength,
+	}
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	if reqURL, err := url.Parse(s.URL); err == nil {
+		req.URL = reqURL
+	}
+	if s.TLS {
+		// The actual connection state is not stored, but
+		// a non-nil value indicates that TLS was used.
+		req.TLS = &tls.ConnectionState{}
+	}
+	return req
+}
+
+func newStoredResponse(resp *http.Response) *storedResponse {
+	if resp == nil {
+		return nil
+	}
+	return &storedResponse{
+		Status:        resp.Status,
+		StatusCode:    resp.StatusCode,
+		Proto:         resp.Proto,
+		ProtoMajor:    resp.ProtoMajor,
+		ProtoMinor:    resp.ProtoMinor,
+		Header:        resp.Header,
+		Trailer:       resp.Trailer,
+		ContentLength: resp.ContentLength,
+	}
+}
+
+func (s *storedResponse) response() *http.Response {
+	if s == nil {
+		return nil
+	}
+	resp := &http.Response{
+		Status:        s.Status,
+		StatusCode:    s.StatusCode,
+		Proto:         s.Proto,
+		ProtoMajor:    s.ProtoMajor,
+		ProtoMinor:    s.ProtoMinor,
+		Header:        s.Header,
+		Trailer:       s.Trailer,
+		ContentLength: s.ContentLength,
+	}
+	if resp.Header == nil {
+		resp.Header = http.Header{}
+	}
+	return resp
+}
+
+func errorString(err error) *string {
+	if err == nil {
+		return nil
+	}
+	str := err.Error()
+	return &str
+}
+
+func errorFromString(str *string) error {
+	if str == nil {
+		return nil
+	}
+	return errors.New(*str)
+}
@@ -0,0 +1,145 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"connectrpc.com/conformance/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskStore(t *testing.T) {
+	t.Parallel()
+	store, err := NewDiskStore(1024 * 1024)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, store.Close())
+	})
+
+	req := &http.Request{
+		Method:     http.MethodPost,
+		URL:        &url.URL{Path: "/connectrpc.conformance.v1.ConformanceService/ServerStream"},
+		Host:       "127.0.0.1:8080",
+		Proto:      "HTTP/2.0",
+		ProtoMajor: 2,
+		Header:     headers("Content-Type", "application/connect+proto"),
+		TLS:        &tls.ConnectionState{},
+	}
+	resp := &http.Response{
+		Status:        "200 OK",
+		StatusCode:    200,
+		Proto:         "HTTP/2.0",
+		ProtoMajor:    2,
+		Header:        headers("Content-Type", "application/connect+proto"),
+		Trailer:       headers("X-Custom-Trailer", "bing"),
+		ContentLength: -1,
+	}
+	frame := &Frame{FromClient: true, StreamID: 1, Type: "HEADERS", Length: 20, Summary: "END_HEADERS"}
+	frame.Offset = time.Millisecond / 2
+	trace := &Trace{
+		TestName: "Suite/server-stream/success",
+		Start:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Client:   true,
+		Request:  req,
+		Response: resp,
+		Err:      errors.New("oops"),
+		Events: []Event{
+			&RequestStart{Request: req, getHeaders: func() http.Header { return headers("Content-Type", "application/connect+proto", "Te", "trailers") }},
+			&RequestBodyData{Envelope: &Envelope{Len: 10}, Len: 10, eventOffset: eventOffset{Offset: time.Millisecond}},
+			&RequestBodyEnd{eventOffset: eventOffset{Offset: 2 * time.Millisecond}},
+			&ResponseStart{Response: resp, eventOffset: eventOffset{Offset: 5 * time.Millisecond}},
+			&ResponseBodyData{Envelope: &Envelope{Len: 20}, Len: 20, Message: `{"payload":{}}`, eventOffset: eventOffset{Offset: 6 * time.Millisecond}},
+			&ResponseBodyEndStream{Content: `{"error":{"code":"internal"}}`, Error: `{"code":"internal"}`, eventOffset: eventOffset{Offset: 7 * time.Millisecond}},
+			&ResponseBodyEnd{Err: errors.New("bad"), eventOffset: eventOffset{Offset: 8 * time.Millisecond}},
+		},
+		Frames: []*Frame{frame},
+	}
+	store.Put(trace.TestName, trace)
+	assert.Equal(t, []string{trace.TestName}, store.Names())
+	assert.Nil(t, store.Get("Suite/unknown"))
+	restored := store.Get(trace.TestName)
+	require.NotNil(t, restored)
+
+	// The restored trace prints and converts to HAR the same as the original.
+	var expected, actual internal.SimplePrinter
+	trace.Print(&expected)
+	restored.Print(&actual)
+	assert.Equal(t, expected.Messages, actual.Messages)
+	var expectedHAR, actualHAR bytes.Buffer
+	require.NoError(t, WriteHAR(&expectedHAR, trace))
+	require.NoError(t, WriteHAR(&actualHAR, restored))
+	assert.Equal(t, expectedHAR.String(), actualHAR.String())
+	assert.Empty(t, Diff(trace, restored))
+	assert.True(t, restored.Client)
+	assert.Equal(t, "oops", restored.Err.Error())
+	assert.Same(t, restored.Response, restored.Events[3].(*ResponseStart).Response)
+
+	store.Delete(trace.TestName)
+	assert.Nil(t, store.Get(trace.TestName))
+	assert.Empty(t, store.Names())
+}
+
+func TestDiskStore_Limit(t *testing.T) {
+	t.Parallel()
+	newTrace := func(message string) *Trace {
+		return &Trace{Events: []Event{&ResponseBodyData{Message: message}}}
+	}
+	probe, err := NewDiskStore(1024 * 1024)
+	require.NoError(t, err)
+	probe.Put("probe", newTrace("x"))
+	size := probe.totalSize
+	require.NoError(t, probe.Close())
+
+	// Room for two traces.
+	store, err := NewDiskStore(2*size + 1)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, store.Close())
+	})
+	store.Put("a", newTrace("a"))
+	store.Put("b", newTrace("b"))
+	assert.Equal(t, []string{"a", "b"}, store.Names())
+	// The oldest trace is discarded to make room.
+	store.Put("c", newTrace("c"))
+	assert.Equal(t, []string{"b", "c"}, store.Names())
+	assert.Nil(t, store.Get("a"))
+	// Replacing a trace makes it the newest.
+	store.Put("b", newTrace("d"))
+	store.Put("e", newTrace("e"))
+	assert.Equal(t, []string{"b", "e"}, store.Names())
+	assert.Equal(t, "d", store.Get("b").Events[0].(*ResponseBodyData).Message)
+	// A trace that is too big is discarded.
+	store.Put("f", newTrace(string(make([]byte, 2*size))))
+	assert.Equal(t, []string{"b", "e"}, store.Names())
+
+	// Traces of passing test cases are discarded first, even if newer.
+	store.PutPassing("g", newTrace("g"))
+	assert.Equal(t, []string{"e", "g"}, store.Names())
+	store.Put("h", newTrace("h"))
+	assert.Equal(t, []string{"e", "h"}, store.Names())
+	store.PutPassing("i", newTrace("i"))
+	store.PutPassing("j", newTrace("j"))
+	assert.Equal(t, []string{"h", "j"}, store.Names())
+	store.Put("k", newTrace("k"))
+	assert.Equal(t, []string{"h", "k"}, store.Names())
+}
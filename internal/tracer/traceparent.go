@@ -0,0 +1,98 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// TraceparentHeader is the name of the header used to propagate
+	// a W3C trace context.
+	TraceparentHeader = "traceparent"
+	// TraceresponseHeader is the name of the response header used to
+	// return the W3C trace context of the server's span to the client.
+	// Its value has the same format as a "traceparent" header. See
+	// https://www.w3.org/TR/trace-context-2/#traceresponse-header.
+	TraceresponseHeader = "traceresponse"
+)
+
+// TraceContext identifies a span, as propagated in a W3C "traceparent"
+// header. See https://www.w3.org/TR/trace-context/.
+type TraceContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+}
+
+// NewTraceContext returns a trace context for a new trace, with a
+// random trace ID and span ID.
+func NewTraceContext() TraceContext {
+	var traceCtx TraceContext
+	_, _ = rand.Read(traceCtx.TraceID[:])
+	return traceCtx.Child()
+}
+
+// TraceContextFromHeaders returns the trace context in the given headers.
+// It returns false if the headers have no "traceparent" header or if its
+// value is not valid.
+func TraceContextFromHeaders(headers http.Header) (TraceContext, bool) {
+	return ParseTraceparent(headers.Get(TraceparentHeader))
+}
+
+// ParseTraceparent parses the given value of a "traceparent" header. It
+// returns false if the value is not valid.
+func ParseTraceparent(value string) (TraceContext, bool) {
+	// version "-" trace-id "-" parent-id "-" trace-flags
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return TraceContext{}, false
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		// Only later versions may have additional fields.
+		return TraceContext{}, false
+	}
+	var traceCtx TraceContext
+	if _, err := hex.Decode(traceCtx.TraceID[:], []byte(parts[1])); err != nil {
+		return TraceContext{}, false
+	}
+	if _, err := hex.Decode(traceCtx.SpanID[:], []byte(parts[2])); err != nil {
+		return TraceContext{}, false
+	}
+	if _, err := hex.DecodeString(parts[0] + parts[3]); err != nil {
+		return TraceContext{}, false
+	}
+	if traceCtx.TraceID == [16]byte{} || traceCtx.SpanID == [8]byte{} {
+		return TraceContext{}, false
+	}
+	return traceCtx, true
+}
+
+// Child returns a trace context for a new span in the same trace, with a
+// random span ID.
+func (c TraceContext) Child() TraceContext {
+	_, _ = rand.Read(c.SpanID[:])
+	return c
+}
+
+// Traceparent returns the value of a "traceparent" header that propagates
+// this trace context. The span is always marked as sampled.
+func (c TraceContext) Traceparent() string {
+	return fmt.Sprintf("00-%x-%x-01", c.TraceID, c.SpanID)
+}
@@ -0,0 +1,64 @@
+// Copyright 2023-2024 The Connect Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracer
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	t.Parallel()
+	traceCtx, ok := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	require.True(t, ok)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", traceCtx.Traceparent())
+
+	// Later versions may have additional fields.
+	_, ok = ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-foo")
+	assert.True(t, ok)
+
+	for _, value := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-foo",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-zz",
+	} {
+		_, ok := ParseTraceparent(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestTraceContext(t *testing.T) {
+	t.Parallel()
+	traceCtx := NewTraceContext()
+	headers := http.Header{}
+	headers.Set(TraceparentHeader, traceCtx.Traceparent())
+	parsed, ok := TraceContextFromHeaders(headers)
+	require.True(t, ok)
+	assert.Equal(t, traceCtx, parsed)
+
+	child := traceCtx.Child()
+	assert.Equal(t, traceCtx.TraceID, child.TraceID)
+	assert.NotEqual(t, traceCtx.SpanID, child.SpanID)
+	assert.NotEqual(t, traceCtx.TraceID, NewTraceContext().TraceID)
+}
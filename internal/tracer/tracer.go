@@ -17,6 +17,7 @@ package tracer
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"sort"
 	"strings"
@@ -46,10 +47,37 @@ const (
 // the operation. (If Clear is never called, the Tracer will use more and more memory,
 // but limited by the amount to store all traces for every operation traced.)
 type Tracer struct {
+	// If true, traces produced at the connection level for HTTP/2
+	// also include the individual frames that were sent and received,
+	// in their Frames field. See TracingHTTP2Conn.
+	RecordFrames bool
+	// If non-nil, the clear-text bytes of connections traced at the
+	// connection level for HTTP/2 are written to this capture. See
+	// PacketCapture.
+	Capture *PacketCapture
+	// If true, the reference client sends a W3C trace context with the
+	// RPC for each test case, in a "traceparent" header, and the reference
+	// server returns the trace context of its span for each RPC, in a
+	// "traceresponse" header. This allows the traces to be correlated with
+	// the telemetry of the implementation under test.
+	PropagateTraceContext bool
+	// If non-nil, the TLS secrets for connections made or accepted by
+	// the reference client and server are written to this writer, in
+	// the NSS key log format. See tls.Config.KeyLogWriter.
+	KeyLog io.Writer
+
 	mu     sync.Mutex
 	traces map[string]*traceResult
 }
 
+// TracesHTTP2Conns returns true if HTTP/2 connections should be traced at the
+// connection level, using TracingHTTP2Conn, instead of with TracingHandler or
+// TracingRoundTripper. This is needed to record frames or to capture the
+// bytes of the connection.
+func (t *Tracer) TracesHTTP2Conns() bool {
+	return t != nil && (t.RecordFrames || t.Capture != nil)
+}
+
 // Init initializes the tracer to accept data for a trace for the given test name.
 // This must be called before Clear, Complete, or Await for the same name.
 func (t *Tracer) Init(testName string) {
@@ -128,16 +156,39 @@ func (t *Tracer) Await(ctx context.Context, testName string) (*Trace, error) {
 // Trace represents the sequence of activity for a single HTTP operation.
 type Trace struct {
 	TestName string
+	// The time at which the operation started. The offsets
+	// of all events are relative to this time.
+	Start time.Time
+	// True if the trace was recorded by the client; false
+	// if it was recorded by the server.
+	Client   bool
 	Request  *http.Request
 	Response *http.Response
 	Err      error
 	Events   []Event
+	// The low-level HTTP/2 frames for the operation. This
+	// includes the frames for the operation's stream as
+	// well as any connection-level frames (those with a
+	// stream ID of zero) that were sent or received while
+	// the operation was in progress. This is only populated
+	// when Tracer.RecordFrames is enabled and the operation
+	// was traced at the connection level.
+	Frames []*Frame
 }
 
 func (t *Trace) Print(printer internal.Printer) {
+	// Frames are interleaved with events, ordered by offset.
+	frames := t.Frames
 	for _, event := range t.Events {
+		for len(frames) > 0 && frames[0].Offset <= event.offset() {
+			frames[0].print(printer)
+			frames = frames[1:]
+		}
 		event.print(printer)
 	}
+	for _, frame := range frames {
+		frame.print(printer)
+	}
 	if t.Response != nil && len(t.Response.Trailer) > 0 {
 		printer.Printf(responsePrefix)
 		printHeaders(responsePrefix, t.Response.ProtoMajor == 1, t.Response.Trailer, printer)
@@ -158,6 +209,7 @@ var _ Collector = (*Tracer)(nil)
 // Event is a single item in a sequence of activity for an HTTP operation.
 type Event interface {
 	setEventOffset(time.Duration)
+	offset() time.Duration
 	print(internal.Printer)
 }
 
@@ -218,12 +270,16 @@ type RequestBodyData struct {
 	// in the stream should have an index of zero, and
 	// then one, etc.
 	MessageIndex int
+	// The message in JSON form. This is empty if the
+	// message could not be decoded, such as when it is
+	// incomplete or too large or its type is not known.
+	Message string
 
 	eventOffset
 }
 
 func (r *RequestBodyData) print(printer internal.Printer) {
-	printData(requestPrefix, r.offsetMillis(), r.MessageIndex, r.Envelope, r.Len, printer)
+	printData(requestPrefix, r.offsetMillis(), r.MessageIndex, r.Envelope, r.Len, r.Message, printer)
 }
 
 // RequestBodyEnd represents the end of the request body being reached.
@@ -300,12 +356,18 @@ type ResponseBodyData struct {
 	// in the stream should have an index of zero, and
 	// then one, etc.
 	MessageIndex int
+	// The message in JSON form. This is empty if the
+	// message could not be decoded, such as when it is
+	// incomplete or too large or its type is not known.
+	// For a Connect unary error, this is the error, with
+	// any error details decoded.
+	Message string
 
 	eventOffset
 }
 
 func (r *ResponseBodyData) print(printer internal.Printer) {
-	printData(responsePrefix, r.offsetMillis(), r.MessageIndex, r.Envelope, r.Len, printer)
+	printData(responsePrefix, r.offsetMillis(), r.MessageIndex, r.Envelope, r.Len, r.Message, printer)
 }
 
 // ResponseBodyEndStream represents the an "end-stream" message in the
@@ -314,6 +376,10 @@ func (r *ResponseBodyData) print(printer internal.Printer) {
 // body.
 type ResponseBodyEndStream struct {
 	Content string
+	// The error described by the end-stream message in
+	// JSON form, with any error details decoded. This is
+	// empty if the message indicates success.
+	Error string
 
 	eventOffset
 }
@@ -324,6 +390,9 @@ func (r *ResponseBodyEndStream) print(printer internal.Printer) {
 		line = strings.Trim(line, "\r")
 		printer.Printf("%s %11s   eos: %s", responsePrefix, "", line)
 	}
+	if r.Error != "" {
+		printer.Printf("%s %11s   err: %s", responsePrefix, "", r.Error)
+	}
 }
 
 // ResponseBodyEnd represents the end of the response body being reached.
@@ -355,6 +424,41 @@ func (r *RequestCanceled) print(printer internal.Printer) {
 	printer.Printf("%s %9.3fms canceled", requestPrefix, r.offsetMillis())
 }
 
+// Frame represents a single HTTP/2 frame that was sent or received
+// on the connection used by an HTTP operation. Unlike events, which
+// describe the operation at the level of HTTP semantics, frames show
+// the activity on the wire, including flow control and other
+// connection-level frames that are otherwise invisible.
+type Frame struct {
+	// True if the frame was sent by the client; false if it
+	// was sent by the server.
+	FromClient bool
+	// The ID of the frame's stream. Connection-level frames,
+	// like SETTINGS, PING, and GOAWAY, have a stream ID of zero.
+	StreamID uint32
+	// The frame type, like "HEADERS" or "RST_STREAM".
+	Type string
+	// The length of the frame's payload, in bytes.
+	Length uint32
+	// A summary of the frame's flags and contents. This may
+	// be empty if the frame has no interesting details.
+	Summary string
+
+	eventOffset
+}
+
+func (f *Frame) print(printer internal.Printer) {
+	prefix := responsePrefix
+	if f.FromClient {
+		prefix = requestPrefix
+	}
+	summary := f.Summary
+	if summary != "" {
+		summary = " " + summary
+	}
+	printer.Printf("%s %9.3fms frame: %s stream=%d len=%d%s", prefix, f.offsetMillis(), f.Type, f.StreamID, f.Length, summary)
+}
+
 // GetDecompressor returns a decompressor that can handle the given encoding.
 func GetDecompressor(encoding string) connect.Decompressor {
 	var comp conformancev1.Compression
@@ -381,6 +485,13 @@ func GetDecompressor(encoding string) connect.Decompressor {
 	return decomp
 }
 
+// recordsFrames returns true if the given collector wants traces
+// to include the individual HTTP/2 frames.
+func recordsFrames(collector Collector) bool {
+	tracer, ok := collector.(*Tracer)
+	return ok && tracer != nil && tracer.RecordFrames
+}
+
 type traceResult struct {
 	trace Trace
 	done  chan struct{}
@@ -394,6 +505,10 @@ func (o *eventOffset) setEventOffset(offset time.Duration) {
 	o.Offset = offset
 }
 
+func (o *eventOffset) offset() time.Duration {
+	return o.Offset
+}
+
 func (o *eventOffset) offsetMillis() float64 {
 	return o.Offset.Seconds() * 1000
 }
@@ -432,7 +547,7 @@ func isPrintableASCII(s string) bool {
 	return true
 }
 
-func printData(prefix string, offsetMillis float64, index int, env *Envelope, length uint64, printer internal.Printer) {
+func printData(prefix string, offsetMillis float64, index int, env *Envelope, length uint64, message string, printer internal.Printer) {
 	if env != nil {
 		printer.Printf("%s %9.3fms message #%d: prefix: flags=%d, len=%d", prefix, offsetMillis, index+1, env.Flags, env.Len)
 		if length > 0 {
@@ -441,4 +556,7 @@ func printData(prefix string, offsetMillis float64, index int, env *Envelope, le
 	} else {
 		printer.Printf("%s %9.3fms message #%d: data: %d bytes", prefix, offsetMillis, index+1, length)
 	}
+	if message != "" {
+		printer.Printf("%s %11s message #%d: json: %s", prefix, "", index+1, message)
+	}
 }
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/base64"
 	"encoding/binary"
 	"fmt"
 	"io"
@@ -152,6 +153,12 @@ func TestTracer(t *testing.T) {
 						_, _ = respWriter.Write([]byte{128})
 						_, _ = respWriter.Write(responseLenPrefix[:])
 						_, _ = respWriter.Write(responseData)
+					case "application/grpc-web-text+proto":
+						respWriter.Header().Set("Custom-Header", "ABC")
+						respWriter.Header().Set("Content-Type", "application/grpc-web-text")
+						// Each write is encoded separately, so the body contains padding between chunks.
+						_, _ = respWriter.Write(base64Concat([]byte{0}, responseLenPrefix[:], responseData))
+						_, _ = respWriter.Write(base64Concat([]byte{128}, responseLenPrefix[:], responseData))
 					case "application/grpc+proto":
 						respWriter.Header().Set("Custom-Header", "ABC")
 						respWriter.Header().Set("Content-Type", "application/grpc")
@@ -372,6 +379,62 @@ func TestTracer(t *testing.T) {
 						},
 					},
 				},
+				{
+					name: "grpc-web-text",
+					expectTrace: &Trace{
+						Request: &http.Request{
+							Method: http.MethodPost,
+							URL: &url.URL{
+								Path: "/com.foo.Service/Bar",
+							},
+							Header: headers(
+								"Content-Type", "application/grpc-web-text+proto",
+							),
+							Body: io.NopCloser(bytes.NewReader(base64Concat(
+								[]byte{0},
+								requestLenPrefix[:],
+								requestData,
+							))),
+						},
+						Response: &http.Response{
+							StatusCode: http.StatusOK,
+							Header: headers(
+								"Custom-Header", "ABC",
+								"Content-Type", "application/grpc-web-text",
+							),
+						},
+						Events: []Event{
+							&RequestStart{},
+							&RequestBodyData{
+								Envelope: &Envelope{
+									Flags: 0,
+									Len:   uint32(len(requestData)),
+								},
+								Len: uint64(len(requestData)),
+							},
+							&RequestBodyEnd{},
+							&ResponseStart{},
+							&ResponseBodyData{
+								Envelope: &Envelope{
+									Flags: 0,
+									Len:   uint32(len(responseData)),
+								},
+								Len: uint64(len(responseData)),
+							},
+							&ResponseBodyData{
+								Envelope: &Envelope{
+									Flags: 128,
+									Len:   uint32(len(responseData)),
+								},
+								Len: uint64(len(responseData)),
+							},
+							&ResponseBodyEndStream{
+								Content: string(responseData),
+							},
+							&ResponseBodyEnd{},
+						},
+					},
+				},
 				{
 					name: "grpc",
 					expectTrace: &Trace{
@@ -546,6 +609,16 @@ func concat(data ...[]byte) []byte {
 	return result
 }
 
+// base64Concat encodes each of the given byte slices separately, with
+// padding, and concatenates the results, like a gRPC-Web text sender.
+func base64Concat(data ...[]byte) []byte {
+	var result []byte
+	for _, chunk := range data {
+		result = append(result, base64.StdEncoding.EncodeToString(chunk)...)
+	}
+	return result
+}
+
 func eventTypes(events []Event) string {
 	types := make([]string, len(events))
 	for i := range events {